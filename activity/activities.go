@@ -0,0 +1,37 @@
+package activity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AddToUpdates adds the activity to the multi-path updates of a repository so it is
+// stored in the same atomic write as the change it records, nil activities are skipped
+func AddToUpdates(updates map[string]interface{}, _activity *models.Activity) {
+	if _activity == nil {
+		return
+	}
+
+	_activity.ID = primitive.NewObjectID()
+	_activity.CreatedAt = time.Now()
+
+	updates[fmt.Sprintf("activities/%s", _activity.ID.Hex())] = _activity
+}
+
+// NewCardActivity returns the activity of a change the requester made to the card,
+// the activity belongs to the history of the card as well as to the board
+func NewCardActivity(requesterID, boardID, cardID primitive.ObjectID, verb string, before, after interface{}) *models.Activity {
+	return &models.Activity{
+		BoardID:  boardID,
+		CardID:   &cardID,
+		ActorID:  requesterID,
+		Verb:     verb,
+		Target:   models.ActivityTargetCard,
+		TargetID: cardID,
+		Before:   before,
+		After:    after,
+	}
+}
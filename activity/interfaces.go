@@ -0,0 +1,21 @@
+package activity
+
+import (
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultActivitiesLimit = 20
+	MaxActivitiesLimit     = 50
+)
+
+type Repository interface {
+	GetBoardActivities(boardID primitive.ObjectID) ([]*models.Activity, error)
+	GetCardActivities(cardID primitive.ObjectID) ([]*models.Activity, error)
+}
+
+type Usecase interface {
+	GetBoardActivities(requesterID, boardID primitive.ObjectID, cursor string, limit int) ([]*models.Activity, string, error)
+	GetCardActivities(requesterID, boardID, listID, cardID primitive.ObjectID, cursor string, limit int) ([]*models.Activity, string, error)
+}
@@ -0,0 +1,76 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// GetBoardActivities provides a mock function with given fields: boardID
+func (_m *Repository) GetBoardActivities(boardID primitive.ObjectID) ([]*models.Activity, error) {
+	ret := _m.Called(boardID)
+
+	var r0 []*models.Activity
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.Activity); ok {
+		r0 = rf(boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Activity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCardActivities provides a mock function with given fields: cardID
+func (_m *Repository) GetCardActivities(cardID primitive.ObjectID) ([]*models.Activity, error) {
+	ret := _m.Called(cardID)
+
+	var r0 []*models.Activity
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.Activity); ok {
+		r0 = rf(cardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Activity)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(cardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
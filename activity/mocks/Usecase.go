@@ -0,0 +1,90 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// GetBoardActivities provides a mock function with given fields: requesterID, boardID, cursor, limit
+func (_m *Usecase) GetBoardActivities(requesterID primitive.ObjectID, boardID primitive.ObjectID, cursor string, limit int) ([]*models.Activity, string, error) {
+	ret := _m.Called(requesterID, boardID, cursor, limit)
+
+	var r0 []*models.Activity
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, string, int) []*models.Activity); ok {
+		r0 = rf(requesterID, boardID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Activity)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID, string, int) string); ok {
+		r1 = rf(requesterID, boardID, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(primitive.ObjectID, primitive.ObjectID, string, int) error); ok {
+		r2 = rf(requesterID, boardID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// GetCardActivities provides a mock function with given fields: requesterID, boardID, listID, cardID, cursor, limit
+func (_m *Usecase) GetCardActivities(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, cursor string, limit int) ([]*models.Activity, string, error) {
+	ret := _m.Called(requesterID, boardID, listID, cardID, cursor, limit)
+
+	var r0 []*models.Activity
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string, int) []*models.Activity); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Activity)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string, int) string); ok {
+		r1 = rf(requesterID, boardID, listID, cardID, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string, int) error); ok {
+		r2 = rf(requesterID, boardID, listID, cardID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+type mockConstructorTestingTNewUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsecase creates a new instance of Usecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsecase(t mockConstructorTestingTNewUsecase) *Usecase {
+	mock := &Usecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type activityRepository struct {
+	dbClient *db.Client
+}
+
+func NewActivityRepository(dbClient *db.Client) activity.Repository {
+	return &activityRepository{dbClient: dbClient}
+}
+
+func (repo *activityRepository) GetBoardActivities(boardID primitive.ObjectID) ([]*models.Activity, error) {
+	return repo.getActivities("board_id", boardID)
+}
+
+func (repo *activityRepository) GetCardActivities(cardID primitive.ObjectID) ([]*models.Activity, error) {
+	return repo.getActivities("card_id", cardID)
+}
+
+func (repo *activityRepository) getActivities(key string, ID primitive.ObjectID) ([]*models.Activity, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("activities").OrderByChild(key).EqualTo(ID.Hex())
+
+	activitiesMap := make(map[string]*models.Activity)
+
+	err := ref.Get(ctx, &activitiesMap)
+	if err != nil {
+		return nil, err
+	}
+
+	activities := []*models.Activity{}
+
+	for _, _activity := range activitiesMap {
+		activities = append(activities, _activity)
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"sort"
+
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/board"
+	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/list"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type activityUsecase struct {
+	activityRepo    activity.Repository
+	boardRepo       board.Repository
+	boardMemberRepo board_member.Repository
+	listRepo        list.Repository
+	cardRepo        card.Repository
+}
+
+func NewActivityUsecase(activityRepo activity.Repository, boardRepo board.Repository, boardMemberRepo board_member.Repository, listRepo list.Repository, cardRepo card.Repository) activity.Usecase {
+	return &activityUsecase{activityRepo: activityRepo, boardRepo: boardRepo, boardMemberRepo: boardMemberRepo, listRepo: listRepo, cardRepo: cardRepo}
+}
+
+func (usecase *activityUsecase) GetBoardActivities(requesterID, boardID primitive.ObjectID, cursor string, limit int) ([]*models.Activity, string, error) {
+	cursorActivity, limit, err := parsePagination(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = board.CheckIfRequesterCanSeeBoard(usecase.boardRepo, usecase.boardMemberRepo, requesterID, boardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	activities, err := usecase.activityRepo.GetBoardActivities(boardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	page, nextCursor := paginate(activities, cursorActivity, limit)
+
+	return page, nextCursor, nil
+}
+
+// GetCardActivities returns the history of the card, which includes the activities of its comments
+func (usecase *activityUsecase) GetCardActivities(requesterID, boardID, listID, cardID primitive.ObjectID, cursor string, limit int) ([]*models.Activity, string, error) {
+	cursorActivity, limit, err := parsePagination(cursor, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = board.CheckIfRequesterCanSeeBoard(usecase.boardRepo, usecase.boardMemberRepo, requesterID, boardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	list, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if list.BoardID != boardID {
+		return nil, "", custom_errors.ErrRecordNotFound
+	}
+
+	card, err := usecase.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if card.ListID != listID {
+		return nil, "", custom_errors.ErrRecordNotFound
+	}
+
+	cardActivities, err := usecase.activityRepo.GetCardActivities(cardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// a card that was moved over from another board keeps the activities it had there,
+	// they belong to the history of that board and can only be seen from it
+	activities := []*models.Activity{}
+	for _, _activity := range cardActivities {
+		if _activity.BoardID == boardID {
+			activities = append(activities, _activity)
+		}
+	}
+
+	page, nextCursor := paginate(activities, cursorActivity, limit)
+
+	return page, nextCursor, nil
+}
+
+func parsePagination(cursor string, limit int) (*models.Activity, int, error) {
+	if limit <= 0 {
+		limit = activity.DefaultActivitiesLimit
+	} else if limit > activity.MaxActivitiesLimit {
+		limit = activity.MaxActivitiesLimit
+	}
+
+	if cursor == "" {
+		return nil, limit, nil
+	}
+
+	cursorID, cursorCreatedAt, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return &models.Activity{ID: cursorID, CreatedAt: cursorCreatedAt}, limit, nil
+}
+
+// paginate returns the activities that come after the cursor activity and the cursor of the next page,
+// which is empty when there are no activities left
+func paginate(activities []*models.Activity, cursorActivity *models.Activity, limit int) ([]*models.Activity, string) {
+	sort.Slice(activities, func(i, j int) bool {
+		return utils.IsNewerRecord(activities[i].ID, activities[i].CreatedAt, activities[j].ID, activities[j].CreatedAt)
+	})
+
+	start := 0
+	if cursorActivity != nil {
+		start = len(activities)
+		for i, _activity := range activities {
+			if utils.IsNewerRecord(cursorActivity.ID, cursorActivity.CreatedAt, _activity.ID, _activity.CreatedAt) {
+				start = i
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end > len(activities) {
+		end = len(activities)
+	}
+
+	page := activities[start:end]
+
+	nextCursor := ""
+	if end < len(activities) {
+		last := page[len(page)-1]
+		nextCursor = utils.EncodeCursor(last.ID, last.CreatedAt)
+	}
+
+	return page, nextCursor
+}
@@ -0,0 +1,204 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jordyf15/thullo-api/activity"
+	acr "github.com/jordyf15/thullo-api/activity/mocks"
+	"github.com/jordyf15/thullo-api/activity/usecase"
+	br "github.com/jordyf15/thullo-api/board/mocks"
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	cr "github.com/jordyf15/thullo-api/card/mocks"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	lr "github.com/jordyf15/thullo-api/list/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestActivityUsecase(t *testing.T) {
+	suite.Run(t, new(activityUsecaseSuite))
+}
+
+var (
+	privateBoard = &models.Board{
+		ID:         primitive.NewObjectID(),
+		Visibility: models.BoardVisibilityPrivate,
+	}
+	publicBoard = &models.Board{
+		ID:         primitive.NewObjectID(),
+		Visibility: models.BoardVisibilityPublic,
+	}
+
+	boardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		BoardID: privateBoard.ID,
+		UserID:  primitive.NewObjectID(),
+		Role:    models.MemberRoleMember,
+	}
+
+	list1 = &models.List{
+		ID:      primitive.NewObjectID(),
+		BoardID: privateBoard.ID,
+	}
+	card1 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list1.ID,
+	}
+	card2 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: primitive.NewObjectID(),
+	}
+
+	createdAt = time.Now()
+
+	activity1 = &models.Activity{ID: primitive.NewObjectID(), BoardID: privateBoard.ID, CreatedAt: createdAt.Add(-2 * time.Minute)}
+	activity2 = &models.Activity{ID: primitive.NewObjectID(), BoardID: privateBoard.ID, CardID: &card1.ID, CreatedAt: createdAt.Add(-time.Minute)}
+	activity3 = &models.Activity{ID: primitive.NewObjectID(), BoardID: privateBoard.ID, CardID: &card1.ID, CreatedAt: createdAt}
+	// recorded before card1 was moved over from another board
+	otherBoardActivity = &models.Activity{ID: primitive.NewObjectID(), BoardID: primitive.NewObjectID(), CardID: &card1.ID, CreatedAt: createdAt.Add(-time.Hour)}
+)
+
+type activityUsecaseSuite struct {
+	suite.Suite
+
+	usecase         activity.Usecase
+	activityRepo    *acr.Repository
+	boardRepo       *br.Repository
+	boardMemberRepo *bmr.Repository
+	listRepo        *lr.Repository
+	cardRepo        *cr.Repository
+}
+
+func (s *activityUsecaseSuite) SetupTest() {
+	s.activityRepo = new(acr.Repository)
+	s.boardRepo = new(br.Repository)
+	s.boardMemberRepo = new(bmr.Repository)
+	s.listRepo = new(lr.Repository)
+	s.cardRepo = new(cr.Repository)
+
+	s.boardRepo.On("GetBoardByID", mock.AnythingOfType("primitive.ObjectID")).Return(func(boardID primitive.ObjectID) *models.Board {
+		switch boardID {
+		case privateBoard.ID:
+			return privateBoard
+		case publicBoard.ID:
+			return publicBoard
+		}
+
+		return nil
+	}, func(boardID primitive.ObjectID) error {
+		if boardID != privateBoard.ID && boardID != publicBoard.ID {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(func(boardID primitive.ObjectID) []*models.BoardMember {
+		if boardID == privateBoard.ID {
+			return []*models.BoardMember{boardMember}
+		}
+
+		return []*models.BoardMember{}
+	}, nil)
+	s.listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(list1, nil)
+	s.cardRepo.On("GetCardByID", mock.AnythingOfType("primitive.ObjectID")).Return(func(cardID primitive.ObjectID) *models.Card {
+		if cardID == card2.ID {
+			return card2
+		}
+
+		return card1
+	}, nil)
+	// the repository does not sort its results
+	s.activityRepo.On("GetBoardActivities", mock.AnythingOfType("primitive.ObjectID")).Return(func(boardID primitive.ObjectID) []*models.Activity {
+		if boardID == privateBoard.ID {
+			return []*models.Activity{activity2, activity1, activity3}
+		}
+
+		return []*models.Activity{}
+	}, nil)
+	s.activityRepo.On("GetCardActivities", mock.AnythingOfType("primitive.ObjectID")).Return(func(cardID primitive.ObjectID) []*models.Activity {
+		return []*models.Activity{activity3, otherBoardActivity, activity2}
+	}, nil)
+
+	s.usecase = usecase.NewActivityUsecase(s.activityRepo, s.boardRepo, s.boardMemberRepo, s.listRepo, s.cardRepo)
+}
+
+func (s *activityUsecaseSuite) TestGetBoardActivitiesBoardNotFound() {
+	activities, _, err := s.usecase.GetBoardActivities(boardMember.UserID, primitive.NewObjectID(), "", 0)
+
+	assert.Nil(s.T(), activities)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.activityRepo.AssertNumberOfCalls(s.T(), "GetBoardActivities", 0)
+}
+
+func (s *activityUsecaseSuite) TestGetBoardActivitiesPrivateBoardAsNonMember() {
+	activities, _, err := s.usecase.GetBoardActivities(primitive.NewObjectID(), privateBoard.ID, "", 0)
+
+	assert.Nil(s.T(), activities)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.activityRepo.AssertNumberOfCalls(s.T(), "GetBoardActivities", 0)
+}
+
+func (s *activityUsecaseSuite) TestGetBoardActivitiesPublicBoardAsNonMember() {
+	activities, nextCursor, err := s.usecase.GetBoardActivities(primitive.NewObjectID(), publicBoard.ID, "", 0)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), activities, 0)
+	assert.Empty(s.T(), nextCursor)
+}
+
+func (s *activityUsecaseSuite) TestGetBoardActivitiesInvalidCursor() {
+	activities, _, err := s.usecase.GetBoardActivities(boardMember.UserID, privateBoard.ID, "not-a-cursor", 0)
+
+	assert.Nil(s.T(), activities)
+	assert.Equal(s.T(), custom_errors.ErrInvalidCursor.Error(), err.Error())
+}
+
+func (s *activityUsecaseSuite) TestGetBoardActivitiesPaginated() {
+	activities, nextCursor, err := s.usecase.GetBoardActivities(boardMember.UserID, privateBoard.ID, "", 2)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Activity{activity3, activity2}, activities)
+	assert.NotEmpty(s.T(), nextCursor)
+
+	activities, nextCursor, err = s.usecase.GetBoardActivities(boardMember.UserID, privateBoard.ID, nextCursor, 2)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Activity{activity1}, activities)
+	assert.Empty(s.T(), nextCursor)
+}
+
+func (s *activityUsecaseSuite) TestGetCardActivitiesListNotInBoard() {
+	activities, _, err := s.usecase.GetCardActivities(primitive.NewObjectID(), publicBoard.ID, list1.ID, card1.ID, "", 0)
+
+	assert.Nil(s.T(), activities)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.activityRepo.AssertNumberOfCalls(s.T(), "GetCardActivities", 0)
+}
+
+func (s *activityUsecaseSuite) TestGetCardActivitiesCardNotInList() {
+	activities, _, err := s.usecase.GetCardActivities(boardMember.UserID, privateBoard.ID, list1.ID, card2.ID, "", 0)
+
+	assert.Nil(s.T(), activities)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.activityRepo.AssertNumberOfCalls(s.T(), "GetCardActivities", 0)
+}
+
+func (s *activityUsecaseSuite) TestGetCardActivitiesSuccessful() {
+	activities, nextCursor, err := s.usecase.GetCardActivities(boardMember.UserID, privateBoard.ID, list1.ID, card1.ID, "", 0)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Activity{activity3, activity2}, activities)
+	assert.Empty(s.T(), nextCursor)
+	s.activityRepo.AssertCalled(s.T(), "GetCardActivities", card1.ID)
+}
+
+func (s *activityUsecaseSuite) TestGetCardActivitiesLeavesOutOtherBoards() {
+	activities, _, err := s.usecase.GetCardActivities(boardMember.UserID, privateBoard.ID, list1.ID, card1.ID, "", 0)
+
+	assert.NoError(s.T(), err)
+	assert.NotContains(s.T(), activities, otherBoardActivity)
+}
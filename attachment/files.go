@@ -0,0 +1,73 @@
+package attachment
+
+import (
+	"log"
+	"sync"
+
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/storage"
+)
+
+// StorageOf returns the storage the files of the attachment are uploaded to, the image storage
+// only accepts images so it gets the attachments with a thumbnail and the file storage gets the rest
+func StorageOf(_attachment *models.Attachment, imageStorage, fileStorage storage.Storage) storage.Storage {
+	if _, ok := ThumbnailFormats[_attachment.MimeType]; ok {
+		return imageStorage
+	}
+
+	return fileStorage
+}
+
+// AssignAttachmentURLs asks the storage of every attachment for the URLs of its files
+func AssignAttachmentURLs(imageStorage, fileStorage storage.Storage, attachments ...*models.Attachment) error {
+	images := map[storage.Storage]models.Images{}
+	for _, attachment := range attachments {
+		_storage := StorageOf(attachment, imageStorage, fileStorage)
+		images[_storage] = append(images[_storage], attachment.Images()...)
+	}
+
+	for _storage, storageImages := range images {
+		err := _storage.AssignImageURLToBoardCover(&models.BoardCover{Images: storageImages})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteAttachmentFiles removes the uploaded files and thumbnails of the attachments from their storage,
+// failures are only logged since the attachments are no longer referenced at this point.
+// Images that never made it to the storage are skipped.
+func DeleteAttachmentFiles(imageStorage, fileStorage storage.Storage, attachments ...*models.Attachment) {
+	images := map[storage.Storage]models.Images{}
+	imageCount := 0
+	for _, attachment := range attachments {
+		_storage := StorageOf(attachment, imageStorage, fileStorage)
+		for _, image := range attachment.Images() {
+			if image.ID != "" {
+				images[_storage] = append(images[_storage], image)
+				imageCount++
+			}
+		}
+	}
+
+	var wg sync.WaitGroup
+	deleteChannels := make(chan error, imageCount)
+	wg.Add(imageCount)
+
+	for _storage, storageImages := range images {
+		for _, image := range storageImages {
+			go _storage.DeleteFile(deleteChannels, &wg, image)
+		}
+	}
+
+	wg.Wait()
+	close(deleteChannels)
+
+	for err := range deleteChannels {
+		if err != nil {
+			log.Printf("failed to delete a file of an attachment: %s", err)
+		}
+	}
+}
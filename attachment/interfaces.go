@@ -0,0 +1,36 @@
+package attachment
+
+import (
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	// ThumbnailFormats are the MIME types of the attachments that get a thumbnail
+	// mapped to the file extension the thumbnail is saved with
+	ThumbnailFormats = map[string]string{
+		"image/jpeg": "jpg",
+		"image/png":  "png",
+		"image/gif":  "gif",
+	}
+)
+
+const (
+	ThumbnailWidth = 250
+	MaxFileSize    = 10 << 20
+)
+
+type Repository interface {
+	Create(attachment *models.Attachment, activity *models.Activity) error
+	GetAttachmentByID(attachmentID primitive.ObjectID) (*models.Attachment, error)
+	GetCardAttachments(cardID primitive.ObjectID) ([]*models.Attachment, error)
+	Delete(attachment *models.Attachment, updatedCard *models.Card, activity *models.Activity) error
+}
+
+type Usecase interface {
+	Upload(requesterID, boardID, listID, cardID primitive.ObjectID, file utils.NamedFileReader) error
+	GetCardAttachments(requesterID, boardID, listID, cardID primitive.ObjectID) ([]*models.Attachment, error)
+	SetAsCover(requesterID, boardID, listID, cardID, attachmentID primitive.ObjectID) error
+	Delete(requesterID, boardID, listID, cardID, attachmentID primitive.ObjectID) error
+}
@@ -0,0 +1,97 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	utils "github.com/jordyf15/thullo-api/utils"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// Delete provides a mock function with given fields: requesterID, boardID, listID, cardID, attachmentID
+func (_m *Usecase) Delete(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, attachmentID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, attachmentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCardAttachments provides a mock function with given fields: requesterID, boardID, listID, cardID
+func (_m *Usecase) GetCardAttachments(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID) ([]*models.Attachment, error) {
+	ret := _m.Called(requesterID, boardID, listID, cardID)
+
+	var r0 []*models.Attachment
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) []*models.Attachment); ok {
+		r0 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Attachment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetAsCover provides a mock function with given fields: requesterID, boardID, listID, cardID, attachmentID
+func (_m *Usecase) SetAsCover(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, attachmentID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, attachmentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, attachmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Upload provides a mock function with given fields: requesterID, boardID, listID, cardID, file
+func (_m *Usecase) Upload(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, file utils.NamedFileReader) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, file)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, utils.NamedFileReader) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, file)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsecase creates a new instance of Usecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsecase(t mockConstructorTestingTNewUsecase) *Usecase {
+	mock := &Usecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/attachment"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type attachmentRepository struct {
+	dbClient *db.Client
+}
+
+func NewAttachmentRepository(dbClient *db.Client) attachment.Repository {
+	return &attachmentRepository{dbClient: dbClient}
+}
+
+func (repo *attachmentRepository) Create(attachment *models.Attachment, _activity *models.Activity) error {
+	attachment.ID = primitive.NewObjectID()
+	attachment.CreatedAt = time.Now()
+
+	updates := map[string]interface{}{
+		fmt.Sprintf("attachments/%s", attachment.ID.Hex()): attachment,
+	}
+
+	if _activity != nil {
+		_activity.TargetID = attachment.ID
+	}
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *attachmentRepository) GetAttachmentByID(attachmentID primitive.ObjectID) (*models.Attachment, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("attachments/%s", attachmentID.Hex()))
+
+	attachment := &models.Attachment{}
+
+	err := ref.Get(ctx, &attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	if attachment == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return attachment, nil
+}
+
+func (repo *attachmentRepository) GetCardAttachments(cardID primitive.ObjectID) ([]*models.Attachment, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("attachments").OrderByChild("card_id").EqualTo(cardID.Hex())
+
+	attachmentsMap := make(map[string]*models.Attachment)
+
+	err := ref.Get(ctx, &attachmentsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := []*models.Attachment{}
+
+	for _, attachment := range attachmentsMap {
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+// Delete removes the attachment together with the update of the card,
+// updatedCard is nil when the attachment was not used as the card's cover
+func (repo *attachmentRepository) Delete(attachment *models.Attachment, updatedCard *models.Card, _activity *models.Activity) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("attachments/%s", attachment.ID.Hex()): nil,
+	}
+
+	if updatedCard != nil {
+		err := utils.AddFieldsToUpdates(updates, fmt.Sprintf("cards/%s", updatedCard.ID.Hex()), updatedCard, "cover", "updated_at")
+		if err != nil {
+			return err
+		}
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
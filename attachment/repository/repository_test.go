@@ -0,0 +1,49 @@
+package repository_test
+
+import (
+	"fmt"
+	"testing"
+
+	acr "github.com/jordyf15/thullo-api/activity/repository"
+	"github.com/jordyf15/thullo-api/attachment/repository"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAttachmentChangesRecordActivities(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	assert.NoError(t, err)
+
+	attachmentRepo := repository.NewAttachmentRepository(dbClient)
+	activityRepo := acr.NewActivityRepository(dbClient)
+
+	boardID := primitive.NewObjectID()
+	_card := &models.Card{ID: primitive.NewObjectID(), Title: "card", Rank: "i"}
+	assert.NoError(t, server.Set(fmt.Sprintf("cards/%s", _card.ID.Hex()), _card))
+
+	_attachment := &models.Attachment{CardID: _card.ID, Name: "notes.txt"}
+
+	err = attachmentRepo.Create(_attachment, &models.Activity{BoardID: boardID, CardID: &_card.ID, Verb: models.ActivityVerbCreated, Target: models.ActivityTargetAttachment})
+	assert.NoError(t, err)
+
+	err = attachmentRepo.Delete(_attachment, _card, &models.Activity{BoardID: boardID, CardID: &_card.ID, Verb: models.ActivityVerbDeleted, Target: models.ActivityTargetAttachment, TargetID: _attachment.ID})
+	assert.NoError(t, err)
+
+	// each change is written together with its activity
+	assert.Equal(t, 2, server.Requests("PATCH", "/"))
+
+	activities, err := activityRepo.GetCardActivities(_card.ID)
+	assert.NoError(t, err)
+	assert.Len(t, activities, 2)
+	for _, _activity := range activities {
+		assert.Equal(t, _attachment.ID, _activity.TargetID)
+	}
+
+	_, err = attachmentRepo.GetAttachmentByID(_attachment.ID)
+	assert.Error(t, err)
+}
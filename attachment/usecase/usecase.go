@@ -0,0 +1,369 @@
+package usecase
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/attachment"
+	"github.com/jordyf15/thullo-api/board"
+	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/list"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/storage"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type attachmentUsecase struct {
+	boardMemberRepo board_member.Repository
+	listRepo        list.Repository
+	cardRepo        card.Repository
+	attachmentRepo  attachment.Repository
+	eventRepo       event.Repository
+	storage         storage.Storage
+	fileStorage     storage.Storage
+}
+
+// NewAttachmentUsecase uploads the images to storage and every other type of file to fileStorage
+func NewAttachmentUsecase(boardMemberRepo board_member.Repository, listRepo list.Repository, cardRepo card.Repository, attachmentRepo attachment.Repository, eventRepo event.Repository, storage storage.Storage, fileStorage storage.Storage) attachment.Usecase {
+	return &attachmentUsecase{boardMemberRepo: boardMemberRepo, listRepo: listRepo, cardRepo: cardRepo, attachmentRepo: attachmentRepo, eventRepo: eventRepo, storage: storage, fileStorage: fileStorage}
+}
+
+func (usecase *attachmentUsecase) Upload(requesterID, boardID, listID, cardID primitive.ObjectID, file utils.NamedFileReader) error {
+	if file == nil {
+		return custom_errors.ErrAttachmentEmpty
+	}
+
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	if size == 0 {
+		return custom_errors.ErrAttachmentEmpty
+	}
+
+	if size > attachment.MaxFileSize {
+		return custom_errors.ErrAttachmentTooLarge
+	}
+
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	mimeType, err := detectMimeType(file)
+	if err != nil {
+		return err
+	}
+
+	// the storage only uploads files from the disk
+	tmpFile, err := ioutil.TempFile(os.TempDir(), "attachment-*."+utils.GetFileExtension(file.Name()))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmpFile.Name())
+
+	file.Seek(0, io.SeekStart)
+	if _, err = io.Copy(tmpFile, file); err != nil {
+		return err
+	}
+
+	_attachment := &models.Attachment{
+		CardID:     card.ID,
+		UploaderID: requesterID,
+		Name:       filepath.Base(file.Name()),
+		Size:       size,
+		MimeType:   mimeType,
+		File:       &models.Image{},
+	}
+
+	uploadedFiles := map[*models.Image]*os.File{
+		_attachment.File: tmpFile,
+	}
+
+	if thumbnailExtension, ok := attachment.ThumbnailFormats[mimeType]; ok {
+		// the extension of the name decides the format the thumbnail is saved in
+		thumbnailFile, err := utils.ResizeImage(utils.NewNamedFileReader(file, "thumbnail."+thumbnailExtension), attachment.ThumbnailWidth)
+		if err != nil {
+			return err
+		}
+		defer os.Remove(thumbnailFile.Name())
+
+		_attachment.Thumbnail = &models.Image{Width: attachment.ThumbnailWidth}
+		uploadedFiles[_attachment.Thumbnail] = thumbnailFile
+	}
+
+	_storage := attachment.StorageOf(_attachment, usecase.storage, usecase.fileStorage)
+
+	var wg sync.WaitGroup
+	uploadChannels := make(chan error, len(uploadedFiles))
+	wg.Add(len(uploadedFiles))
+
+	for image, uploadedFile := range uploadedFiles {
+		name := utils.RandString(8)
+		fileName := fmt.Sprintf("%s.%s", name, utils.GetFileExtension(uploadedFile.Name()))
+
+		metaData := map[string]string{
+			"name":        fileName,
+			"title":       name,
+			"description": fmt.Sprintf("attachment %s of card %s", _attachment.Name, card.Title),
+		}
+
+		go _storage.UploadFile(uploadChannels, &wg, image, uploadedFile, metaData)
+	}
+
+	wg.Wait()
+	close(uploadChannels)
+
+	errors := []error{}
+	for err = range uploadChannels {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if len(errors) > 0 {
+		// the files that were uploaded before the others failed are not referenced anywhere
+		attachment.DeleteAttachmentFiles(usecase.storage, usecase.fileStorage, _attachment)
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	for _, image := range _attachment.Images() {
+		image.URL = ""
+	}
+
+	err = usecase.attachmentRepo.Create(_attachment, &models.Activity{
+		BoardID: boardID,
+		CardID:  &_attachment.CardID,
+		ActorID: requesterID,
+		Verb:    models.ActivityVerbCreated,
+		Target:  models.ActivityTargetAttachment,
+		After:   _attachment,
+	})
+	if err != nil {
+		attachment.DeleteAttachmentFiles(usecase.storage, usecase.fileStorage, _attachment)
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventAttachmentCreated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _attachment,
+	})
+
+	return nil
+}
+
+func (usecase *attachmentUsecase) GetCardAttachments(requesterID, boardID, listID, cardID primitive.ObjectID) ([]*models.Attachment, error) {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := usecase.attachmentRepo.GetCardAttachments(card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(attachments, func(i, j int) bool {
+		return attachments[i].CreatedAt.After(attachments[j].CreatedAt)
+	})
+
+	err = attachment.AssignAttachmentURLs(usecase.storage, usecase.fileStorage, attachments...)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (usecase *attachmentUsecase) SetAsCover(requesterID, boardID, listID, cardID, attachmentID primitive.ObjectID) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_attachment, err := usecase.attachmentRepo.GetAttachmentByID(attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if _attachment.CardID != card.ID {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	if _attachment.Thumbnail == nil {
+		return custom_errors.ErrAttachmentNotImage
+	}
+
+	prevCover := card.Cover
+	card.Cover = &models.BoardCover{
+		PhotoID: _attachment.ID.Hex(),
+		Source:  models.AttachmentCoverSource,
+		Images: models.Images{
+			{ID: _attachment.Thumbnail.ID, Width: _attachment.Thumbnail.Width},
+		},
+	}
+	card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"cover", "updated_at"}, activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated,
+		map[string]interface{}{"cover": prevCover},
+		map[string]interface{}{"cover": card.Cover},
+	))
+	if err != nil {
+		return err
+	}
+
+	if prevCover != nil {
+		board.DeleteCoverImages(usecase.storage, prevCover)
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *attachmentUsecase) Delete(requesterID, boardID, listID, cardID, attachmentID primitive.ObjectID) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_attachment, err := usecase.attachmentRepo.GetAttachmentByID(attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if _attachment.CardID != card.ID {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	// the card loses its cover together with the attachment it came from
+	var updatedCard *models.Card
+	if card.Cover != nil && card.Cover.Source == models.AttachmentCoverSource && card.Cover.PhotoID == _attachment.ID.Hex() {
+		card.Cover = nil
+		card.UpdatedAt = time.Now()
+		updatedCard = card
+	}
+
+	err = usecase.attachmentRepo.Delete(_attachment, updatedCard, &models.Activity{
+		BoardID:  boardID,
+		CardID:   &card.ID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbDeleted,
+		Target:   models.ActivityTargetAttachment,
+		TargetID: _attachment.ID,
+		Before:   _attachment,
+	})
+	if err != nil {
+		return err
+	}
+
+	attachment.DeleteAttachmentFiles(usecase.storage, usecase.fileStorage, _attachment)
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventAttachmentDeleted,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _attachment,
+	})
+
+	if updatedCard != nil {
+		usecase.eventRepo.Publish(&models.BoardEvent{
+			Type:    models.BoardEventCardUpdated,
+			BoardID: boardID,
+			ActorID: requesterID,
+			Data:    updatedCard,
+		})
+	}
+
+	return nil
+}
+
+func (usecase *attachmentUsecase) getBoardCard(requesterID, boardID, listID, cardID primitive.ObjectID) (*models.Card, error) {
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.BoardID != boardID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	card, err := usecase.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if card.ListID != listID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return card, nil
+}
+
+func (usecase *attachmentUsecase) checkIfRequesterIsMemberOfBoard(requesterID, boardID primitive.ObjectID) error {
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	// if there are no board members it means there are no board
+	// a board will always atleast have 1 member
+	if len(boardMembers) == 0 {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID {
+			return nil
+		}
+	}
+
+	return custom_errors.ErrNotAuthorized
+}
+
+// detectMimeType sniffs the MIME type from the content of the file and
+// falls back to the extension of its name when the content is not recognized
+func detectMimeType(file utils.NamedFileReader) (string, error) {
+	file.Seek(0, io.SeekStart)
+
+	header := make([]byte, 512)
+	n, err := file.Read(header)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+
+	mimeType := http.DetectContentType(header[:n])
+	if mimeType == "application/octet-stream" {
+		if extensionType := mime.TypeByExtension(filepath.Ext(file.Name())); extensionType != "" {
+			mimeType = extensionType
+		}
+	}
+
+	return mimeType, nil
+}
@@ -0,0 +1,351 @@
+package usecase_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jordyf15/thullo-api/attachment"
+	ar "github.com/jordyf15/thullo-api/attachment/mocks"
+	"github.com/jordyf15/thullo-api/attachment/usecase"
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	cr "github.com/jordyf15/thullo-api/card/mocks"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	lr "github.com/jordyf15/thullo-api/list/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	sr "github.com/jordyf15/thullo-api/storage/mocks"
+	"github.com/jordyf15/thullo-api/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAttachmentUsecase(t *testing.T) {
+	suite.Run(t, new(attachmentUsecaseSuite))
+}
+
+var (
+	boardID     = primitive.NewObjectID()
+	boardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		BoardID: boardID,
+	}
+
+	list1 = &models.List{
+		ID:      primitive.NewObjectID(),
+		BoardID: boardID,
+	}
+
+	card1 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		Title:  "card 1",
+		ListID: list1.ID,
+	}
+	card2 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		Title:  "card 2",
+		ListID: list1.ID,
+	}
+
+	imageAttachment = &models.Attachment{
+		ID:        primitive.NewObjectID(),
+		CardID:    card1.ID,
+		Name:      "photo.png",
+		MimeType:  "image/png",
+		File:      &models.Image{ID: "file1"},
+		Thumbnail: &models.Image{ID: "thumbnail1", Width: attachment.ThumbnailWidth},
+	}
+	documentAttachment = &models.Attachment{
+		ID:       primitive.NewObjectID(),
+		CardID:   card1.ID,
+		Name:     "notes.txt",
+		MimeType: "text/plain; charset=utf-8",
+		File:     &models.Image{ID: "file2"},
+	}
+)
+
+type attachmentUsecaseSuite struct {
+	suite.Suite
+
+	usecase         attachment.Usecase
+	boardMemberRepo *bmr.Repository
+	listRepo        *lr.Repository
+	cardRepo        *cr.Repository
+	attachmentRepo  *ar.Repository
+	eventRepo       *er.Repository
+	storage         *sr.Storage
+	fileStorage     *sr.Storage
+
+	createdAttachment *models.Attachment
+	updatedCard       *models.Card
+	activities        []*models.Activity
+	events            []*models.BoardEvent
+}
+
+func (s *attachmentUsecaseSuite) SetupTest() {
+	s.boardMemberRepo = new(bmr.Repository)
+	s.listRepo = new(lr.Repository)
+	s.cardRepo = new(cr.Repository)
+	s.attachmentRepo = new(ar.Repository)
+	s.eventRepo = new(er.Repository)
+	s.storage = new(sr.Storage)
+	s.fileStorage = new(sr.Storage)
+
+	s.createdAttachment = nil
+	s.updatedCard = nil
+	s.activities = nil
+	card1.Cover = nil
+
+	getBoardMembers := func(_boardID primitive.ObjectID) []*models.BoardMember {
+		if _boardID == boardID {
+			return []*models.BoardMember{boardMember}
+		}
+
+		return []*models.BoardMember{}
+	}
+
+	getCardByID := func(cardID primitive.ObjectID) *models.Card {
+		switch cardID {
+		case card1.ID:
+			return card1
+		case card2.ID:
+			return card2
+		default:
+			return &models.Card{}
+		}
+	}
+
+	getAttachmentByID := func(attachmentID primitive.ObjectID) *models.Attachment {
+		switch attachmentID {
+		case imageAttachment.ID:
+			return imageAttachment
+		case documentAttachment.ID:
+			return documentAttachment
+		default:
+			return &models.Attachment{}
+		}
+	}
+
+	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardMembers, nil)
+	s.listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(list1, nil)
+	s.cardRepo.On("GetCardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getCardByID, nil)
+	s.cardRepo.On("UpdateCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.Card"), mock.AnythingOfType("[]string"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.updatedCard = args[1].(*models.Card)
+	}).Return(nil)
+	s.attachmentRepo.On("Create", mock.AnythingOfType("*models.Attachment"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.createdAttachment = args[0].(*models.Attachment)
+		s.activities = append(s.activities, args[1].(*models.Activity))
+	}).Return(nil)
+	s.attachmentRepo.On("GetAttachmentByID", mock.AnythingOfType("primitive.ObjectID")).Return(getAttachmentByID, nil)
+	s.attachmentRepo.On("GetCardAttachments", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Attachment{documentAttachment, imageAttachment}, nil)
+	s.attachmentRepo.On("Delete", mock.AnythingOfType("*models.Attachment"), mock.AnythingOfType("*models.Card"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.updatedCard = args[1].(*models.Card)
+		s.activities = append(s.activities, args[2].(*models.Activity))
+	}).Return(nil)
+	for _, _storage := range []*sr.Storage{s.storage, s.fileStorage} {
+		_storage.On("UploadFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image"), mock.AnythingOfType("*os.File"), mock.AnythingOfType("map[string]string")).Run(func(args mock.Arguments) {
+			args[2].(*models.Image).ID = primitive.NewObjectID().Hex()
+			args[2].(*models.Image).URL = "https://example.com/image"
+			arg1 := args[0].(chan<- error)
+			arg1 <- nil
+			arg2 := args[1].(*sync.WaitGroup)
+			arg2.Done()
+		})
+		_storage.On("DeleteFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image")).Run(func(args mock.Arguments) {
+			arg1 := args[0].(chan<- error)
+			arg1 <- nil
+			arg2 := args[1].(*sync.WaitGroup)
+			arg2.Done()
+		})
+		_storage.On("AssignImageURLToBoardCover", mock.AnythingOfType("*models.BoardCover")).Return(nil)
+	}
+
+	s.events = []*models.BoardEvent{}
+	s.eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Run(func(args mock.Arguments) {
+		s.events = append(s.events, args[0].(*models.BoardEvent))
+	}).Return()
+
+	s.usecase = usecase.NewAttachmentUsecase(s.boardMemberRepo, s.listRepo, s.cardRepo, s.attachmentRepo, s.eventRepo, s.storage, s.fileStorage)
+}
+
+func newPNGFile(name string) utils.NamedFileReader {
+	buf := new(bytes.Buffer)
+	png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 400, 300)))
+
+	return utils.NewNamedFileReader(bytes.NewReader(buf.Bytes()), name)
+}
+
+func (s *attachmentUsecaseSuite) TestUploadNotAuthorized() {
+	err := s.usecase.Upload(primitive.NewObjectID(), boardID, list1.ID, card1.ID, newPNGFile("photo.png"))
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.attachmentRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *attachmentUsecaseSuite) TestUploadEmptyFile() {
+	file := utils.NewNamedFileReader(bytes.NewReader([]byte{}), "empty.txt")
+
+	err := s.usecase.Upload(boardMember.UserID, boardID, list1.ID, card1.ID, file)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrAttachmentEmpty.Error(), err.Error())
+}
+
+func (s *attachmentUsecaseSuite) TestUploadFileTooLarge() {
+	file := utils.NewNamedFileReader(bytes.NewReader(make([]byte, attachment.MaxFileSize+1)), "large.bin")
+
+	err := s.usecase.Upload(boardMember.UserID, boardID, list1.ID, card1.ID, file)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrAttachmentTooLarge.Error(), err.Error())
+	s.storage.AssertNumberOfCalls(s.T(), "UploadFile", 0)
+}
+
+func (s *attachmentUsecaseSuite) TestUploadDocument() {
+	content := "meeting notes"
+	file := utils.NewNamedFileReader(strings.NewReader(content), "notes.txt")
+
+	err := s.usecase.Upload(boardMember.UserID, boardID, list1.ID, card1.ID, file)
+
+	assert.NoError(s.T(), err)
+	// imgur only takes images, so the document goes to the file storage
+	s.fileStorage.AssertNumberOfCalls(s.T(), "UploadFile", 1)
+	s.storage.AssertNumberOfCalls(s.T(), "UploadFile", 0)
+	assert.Equal(s.T(), card1.ID, s.createdAttachment.CardID)
+	assert.Equal(s.T(), boardMember.UserID, s.createdAttachment.UploaderID)
+	assert.Equal(s.T(), "notes.txt", s.createdAttachment.Name)
+	assert.Equal(s.T(), int64(len(content)), s.createdAttachment.Size)
+	assert.Equal(s.T(), "text/plain; charset=utf-8", s.createdAttachment.MimeType)
+	assert.NotEmpty(s.T(), s.createdAttachment.File.ID)
+	assert.Empty(s.T(), s.createdAttachment.File.URL)
+	assert.Nil(s.T(), s.createdAttachment.Thumbnail)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbCreated, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetAttachment, s.activities[0].Target)
+	assert.Equal(s.T(), card1.ID, *s.activities[0].CardID)
+}
+
+func (s *attachmentUsecaseSuite) TestUploadImage() {
+	err := s.usecase.Upload(boardMember.UserID, boardID, list1.ID, card1.ID, newPNGFile("photo.png"))
+
+	assert.NoError(s.T(), err)
+	s.storage.AssertNumberOfCalls(s.T(), "UploadFile", 2)
+	s.fileStorage.AssertNumberOfCalls(s.T(), "UploadFile", 0)
+	assert.Equal(s.T(), "image/png", s.createdAttachment.MimeType)
+	assert.NotNil(s.T(), s.createdAttachment.Thumbnail)
+	assert.NotEmpty(s.T(), s.createdAttachment.Thumbnail.ID)
+	assert.Equal(s.T(), uint(attachment.ThumbnailWidth), s.createdAttachment.Thumbnail.Width)
+	assert.Empty(s.T(), s.createdAttachment.Thumbnail.URL)
+}
+
+func (s *attachmentUsecaseSuite) TestGetCardAttachmentsNotAuthorized() {
+	attachments, err := s.usecase.GetCardAttachments(primitive.NewObjectID(), boardID, list1.ID, card1.ID)
+
+	assert.Nil(s.T(), attachments)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+}
+
+func (s *attachmentUsecaseSuite) TestGetCardAttachmentsSuccessful() {
+	attachments, err := s.usecase.GetCardAttachments(boardMember.UserID, boardID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), attachments, 2)
+	s.storage.AssertNumberOfCalls(s.T(), "AssignImageURLToBoardCover", 1)
+	s.fileStorage.AssertNumberOfCalls(s.T(), "AssignImageURLToBoardCover", 1)
+}
+
+func (s *attachmentUsecaseSuite) TestSetAsCoverOtherCardAttachment() {
+	err := s.usecase.SetAsCover(boardMember.UserID, boardID, list1.ID, card2.ID, imageAttachment.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *attachmentUsecaseSuite) TestSetAsCoverNotImage() {
+	err := s.usecase.SetAsCover(boardMember.UserID, boardID, list1.ID, card1.ID, documentAttachment.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrAttachmentNotImage.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *attachmentUsecaseSuite) TestSetAsCoverSuccessful() {
+	card1.Cover = &models.BoardCover{
+		Source: "unsplash",
+		Images: models.Images{{ID: "unsplash1", Width: 1080}, {ID: "unsplash2", Width: 450}},
+	}
+
+	err := s.usecase.SetAsCover(boardMember.UserID, boardID, list1.ID, card1.ID, imageAttachment.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), models.AttachmentCoverSource, s.updatedCard.Cover.Source)
+	assert.Equal(s.T(), imageAttachment.ID.Hex(), s.updatedCard.Cover.PhotoID)
+	assert.Equal(s.T(), imageAttachment.Thumbnail.ID, s.updatedCard.Cover.Images[0].ID)
+	// only the images of the previous unsplash cover are deleted
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 2)
+}
+
+func (s *attachmentUsecaseSuite) TestSetAsCoverReplacingAttachmentCover() {
+	card1.Cover = &models.BoardCover{
+		PhotoID: primitive.NewObjectID().Hex(),
+		Source:  models.AttachmentCoverSource,
+		Images:  models.Images{{ID: "thumbnail3", Width: attachment.ThumbnailWidth}},
+	}
+
+	err := s.usecase.SetAsCover(boardMember.UserID, boardID, list1.ID, card1.ID, imageAttachment.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 0)
+}
+
+func (s *attachmentUsecaseSuite) TestDeleteNotAuthorized() {
+	err := s.usecase.Delete(primitive.NewObjectID(), boardID, list1.ID, card1.ID, documentAttachment.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.attachmentRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *attachmentUsecaseSuite) TestDeleteSuccessful() {
+	err := s.usecase.Delete(boardMember.UserID, boardID, list1.ID, card1.ID, documentAttachment.ID)
+
+	assert.NoError(s.T(), err)
+	s.attachmentRepo.AssertNumberOfCalls(s.T(), "Delete", 1)
+	assert.Nil(s.T(), s.updatedCard)
+	s.fileStorage.AssertNumberOfCalls(s.T(), "DeleteFile", 1)
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 0)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbDeleted, s.activities[0].Verb)
+	assert.Equal(s.T(), documentAttachment.ID, s.activities[0].TargetID)
+	assert.Equal(s.T(), documentAttachment, s.activities[0].Before)
+}
+
+func (s *attachmentUsecaseSuite) TestDeleteCoverAttachment() {
+	card1.Cover = &models.BoardCover{
+		PhotoID: imageAttachment.ID.Hex(),
+		Source:  models.AttachmentCoverSource,
+		Images:  models.Images{{ID: imageAttachment.Thumbnail.ID, Width: attachment.ThumbnailWidth}},
+	}
+
+	err := s.usecase.Delete(boardMember.UserID, boardID, list1.ID, card1.ID, imageAttachment.ID)
+
+	assert.NoError(s.T(), err)
+	s.attachmentRepo.AssertNumberOfCalls(s.T(), "Delete", 1)
+	assert.Equal(s.T(), card1.ID, s.updatedCard.ID)
+	assert.Nil(s.T(), s.updatedCard.Cover)
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 2)
+	assert.Len(s.T(), s.events, 2)
+	assert.Equal(s.T(), models.BoardEventAttachmentDeleted, s.events[0].Type)
+	assert.Equal(s.T(), models.BoardEventCardUpdated, s.events[1].Type)
+}
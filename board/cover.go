@@ -0,0 +1,128 @@
+package board
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"sync"
+
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/storage"
+	"github.com/jordyf15/thullo-api/unsplash"
+	"github.com/jordyf15/thullo-api/utils"
+)
+
+// VerifyCover validates the source and focal point of a cover and returns the rounded focal point
+func VerifyCover(source string, focalPointY float64) (float64, []error) {
+	errors := []error{}
+
+	focalPointY = math.Round(focalPointY*1000) / 1000
+
+	if _, exist := BoardCoverSources[source]; !exist {
+		errors = append(errors, custom_errors.ErrInvalidCoverSource)
+	}
+
+	if focalPointY > 1 {
+		errors = append(errors, custom_errors.ErrUnsplashFocalPointYTooHigh)
+	}
+
+	if focalPointY < 0 {
+		errors = append(errors, custom_errors.ErrUnsplashFocalPointYTooLow)
+	}
+
+	return focalPointY, errors
+}
+
+// UploadCover fetches the unsplash photo in every cover size and uploads them to the storage,
+// description is used as the prefix of the uploaded images' description
+func UploadCover(unsplashRepo unsplash.Repository, _storage storage.Storage, source, photoID string, focalPointY float64, description string) (*models.BoardCover, error) {
+	imageFiles, err := unsplashRepo.GetImagesForID(photoID, focalPointY)
+	if err != nil {
+		return nil, err
+	}
+
+	cover := &models.BoardCover{
+		PhotoID:     photoID,
+		Source:      source,
+		FocalPointY: focalPointY,
+		Images:      make(models.Images, len(BoardCoverSizes)),
+	}
+
+	for i, width := range BoardCoverSizes {
+		image := &models.Image{
+			Width: width,
+		}
+
+		cover.Images[i] = image
+	}
+
+	var wg sync.WaitGroup
+	uploadChannels := make(chan error, len(cover.Images))
+	wg.Add(len(cover.Images))
+
+	for idx, image := range cover.Images {
+		imageFile := imageFiles[idx]
+		name := utils.RandString(8)
+		fileName := fmt.Sprintf("%s.%s", name, utils.GetFileExtension(imageFile.Name()))
+
+		metaData := map[string]string{
+			"name":        fileName,
+			"title":       name,
+			"description": fmt.Sprintf("%s with width of %v", description, image.Width),
+		}
+
+		go _storage.UploadFile(uploadChannels, &wg, image, imageFile, metaData)
+	}
+
+	wg.Wait()
+	close(uploadChannels)
+
+	errors := []error{}
+	for err = range uploadChannels {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if len(errors) > 0 {
+		return nil, &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	for _, image := range cover.Images {
+		image.URL = ""
+	}
+
+	return cover, nil
+}
+
+// DeleteCoverImages removes the uploaded images of the covers from the storage,
+// failures are only logged since the covers are no longer referenced at this point.
+// Covers made from an attachment are skipped, their images are deleted with the attachment.
+func DeleteCoverImages(_storage storage.Storage, covers ...*models.BoardCover) {
+	images := models.Images{}
+	for _, cover := range covers {
+		if cover.Source == models.AttachmentCoverSource {
+			continue
+		}
+
+		images = append(images, cover.Images...)
+	}
+
+	var wg sync.WaitGroup
+	deleteChannels := make(chan error, len(images))
+	wg.Add(len(images))
+
+	for _, image := range images {
+		go _storage.DeleteFile(deleteChannels, &wg, image)
+	}
+
+	wg.Wait()
+	close(deleteChannels)
+
+	for err := range deleteChannels {
+		if err != nil {
+			log.Printf("failed to delete an image of a board cover: %s", err)
+		}
+	}
+}
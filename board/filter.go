@@ -0,0 +1,71 @@
+package board
+
+import (
+	"time"
+
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DueStatusOverdue = "overdue"
+	DueStatusDueSoon = "due_soon"
+
+	// DueSoonWindow is how far ahead of its due date a card counts as due soon
+	DueSoonWindow = 24 * time.Hour
+)
+
+var (
+	DueStatuses = map[string]bool{
+		DueStatusOverdue: true,
+		DueStatusDueSoon: true,
+	}
+)
+
+// CardFilter narrows down the cards returned when a board is read,
+// the zero value keeps every card
+type CardFilter struct {
+	// LabelIDs keeps the cards that have atleast one of the labels
+	LabelIDs []primitive.ObjectID
+	// DueStatuses keeps the cards that are in atleast one of the due statuses
+	DueStatuses []string
+}
+
+// Match reports whether the card passes every condition of the filter at the given time
+func (filter CardFilter) Match(card *models.Card, now time.Time) bool {
+	if len(filter.LabelIDs) > 0 {
+		hasLabel := false
+		for _, labelID := range filter.LabelIDs {
+			if card.HasLabel(labelID) {
+				hasLabel = true
+				break
+			}
+		}
+
+		if !hasLabel {
+			return false
+		}
+	}
+
+	if len(filter.DueStatuses) > 0 {
+		hasDueStatus := false
+		for _, dueStatus := range filter.DueStatuses {
+			switch dueStatus {
+			case DueStatusOverdue:
+				hasDueStatus = card.IsOverdue(now)
+			case DueStatusDueSoon:
+				hasDueStatus = card.IsDueSoon(now, DueSoonWindow)
+			}
+
+			if hasDueStatus {
+				break
+			}
+		}
+
+		if !hasDueStatus {
+			return false
+		}
+	}
+
+	return true
+}
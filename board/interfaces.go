@@ -12,18 +12,33 @@ var (
 	BoardCoverSizes = []uint{1080, 450, 150}
 )
 
+const (
+	SortByUpdatedAt = "updated_at"
+	SortByTitle     = "title"
+
+	DefaultUserBoardsLimit = 20
+	MaxUserBoardsLimit     = 50
+)
+
 type Repository interface {
-	Create(board *models.Board) error
-	Update(board *models.Board) error
+	Create(board *models.Board, activity *models.Activity) error
+	Update(board *models.Board, activity *models.Activity) error
 	GetBoardByID(boardID primitive.ObjectID) (*models.Board, error)
+	Delete(board *models.Board, lists []*models.List, cards []*models.Card, comments []*models.Comment, attachments []*models.Attachment, labels []*models.Label, boardMembers []*models.BoardMember, activity *models.Activity) error
 }
 
 type Usecase interface {
 	Create(userID primitive.ObjectID, title string, visibility string, boardCover map[string]interface{}) error
+	GetBoardByID(requesterID, boardID primitive.ObjectID, filter CardFilter) (*models.Board, error)
+	GetUserBoards(requesterID primitive.ObjectID, sortBy, cursor string, limit int, archived bool) ([]*models.UserBoard, string, error)
 	UpdateVisibility(requesterID, boardID primitive.ObjectID, visibility string) error
 	UpdateTitle(requesterID, boardID primitive.ObjectID, title string) error
 	UpdateDescription(requesterID, boardID primitive.ObjectID, description string) error
 	AddMember(requesterID, boardID, memberID primitive.ObjectID) error
 	UpdateMemberRole(requesterID, boardID, memberID primitive.ObjectID, role string) error
 	DeleteMember(requesterID, boardID, memberID primitive.ObjectID) error
+	Delete(requesterID, boardID primitive.ObjectID) error
+	Archive(requesterID, boardID primitive.ObjectID) error
+	Unarchive(requesterID, boardID primitive.ObjectID) error
+	GetArchivedItems(requesterID, boardID primitive.ObjectID) (*models.ArchivedItems, error)
 }
@@ -14,13 +14,27 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: _a0
-func (_m *Repository) Create(_a0 *models.Board) error {
-	ret := _m.Called(_a0)
+// Create provides a mock function with given fields: _a0, activity
+func (_m *Repository) Create(_a0 *models.Board, activity *models.Activity) error {
+	ret := _m.Called(_a0, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Board) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*models.Board, *models.Activity) error); ok {
+		r0 = rf(_a0, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: _a0, lists, cards, comments, attachments, labels, boardMembers, activity
+func (_m *Repository) Delete(_a0 *models.Board, lists []*models.List, cards []*models.Card, comments []*models.Comment, attachments []*models.Attachment, labels []*models.Label, boardMembers []*models.BoardMember, activity *models.Activity) error {
+	ret := _m.Called(_a0, lists, cards, comments, attachments, labels, boardMembers, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Board, []*models.List, []*models.Card, []*models.Comment, []*models.Attachment, []*models.Label, []*models.BoardMember, *models.Activity) error); ok {
+		r0 = rf(_a0, lists, cards, comments, attachments, labels, boardMembers, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -51,13 +65,13 @@ func (_m *Repository) GetBoardByID(boardID primitive.ObjectID) (*models.Board, e
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: _a0
-func (_m *Repository) Update(_a0 *models.Board) error {
-	ret := _m.Called(_a0)
+// Update provides a mock function with given fields: _a0, activity
+func (_m *Repository) Update(_a0 *models.Board, activity *models.Activity) error {
+	ret := _m.Called(_a0, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Board) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*models.Board, *models.Activity) error); ok {
+		r0 = rf(_a0, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
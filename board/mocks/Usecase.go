@@ -3,7 +3,11 @@
 package mocks
 
 import (
+	board "github.com/jordyf15/thullo-api/board"
 	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/jordyf15/thullo-api/models"
+
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -26,6 +30,20 @@ func (_m *Usecase) AddMember(requesterID primitive.ObjectID, boardID primitive.O
 	return r0
 }
 
+// Archive provides a mock function with given fields: requesterID, boardID
+func (_m *Usecase) Archive(requesterID primitive.ObjectID, boardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: userID, title, visibility, boardCover
 func (_m *Usecase) Create(userID primitive.ObjectID, title string, visibility string, boardCover map[string]interface{}) error {
 	ret := _m.Called(userID, title, visibility, boardCover)
@@ -40,6 +58,20 @@ func (_m *Usecase) Create(userID primitive.ObjectID, title string, visibility st
 	return r0
 }
 
+// Delete provides a mock function with given fields: requesterID, boardID
+func (_m *Usecase) Delete(requesterID primitive.ObjectID, boardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeleteMember provides a mock function with given fields: requesterID, boardID, memberID
 func (_m *Usecase) DeleteMember(requesterID primitive.ObjectID, boardID primitive.ObjectID, memberID primitive.ObjectID) error {
 	ret := _m.Called(requesterID, boardID, memberID)
@@ -54,6 +86,96 @@ func (_m *Usecase) DeleteMember(requesterID primitive.ObjectID, boardID primitiv
 	return r0
 }
 
+// GetArchivedItems provides a mock function with given fields: requesterID, boardID
+func (_m *Usecase) GetArchivedItems(requesterID primitive.ObjectID, boardID primitive.ObjectID) (*models.ArchivedItems, error) {
+	ret := _m.Called(requesterID, boardID)
+
+	var r0 *models.ArchivedItems
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) *models.ArchivedItems); ok {
+		r0 = rf(requesterID, boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ArchivedItems)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(requesterID, boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBoardByID provides a mock function with given fields: requesterID, boardID, filter
+func (_m *Usecase) GetBoardByID(requesterID primitive.ObjectID, boardID primitive.ObjectID, filter board.CardFilter) (*models.Board, error) {
+	ret := _m.Called(requesterID, boardID, filter)
+
+	var r0 *models.Board
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, board.CardFilter) *models.Board); ok {
+		r0 = rf(requesterID, boardID, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Board)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID, board.CardFilter) error); ok {
+		r1 = rf(requesterID, boardID, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserBoards provides a mock function with given fields: requesterID, sortBy, cursor, limit, archived
+func (_m *Usecase) GetUserBoards(requesterID primitive.ObjectID, sortBy string, cursor string, limit int, archived bool) ([]*models.UserBoard, string, error) {
+	ret := _m.Called(requesterID, sortBy, cursor, limit, archived)
+
+	var r0 []*models.UserBoard
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, string, string, int, bool) []*models.UserBoard); ok {
+		r0 = rf(requesterID, sortBy, cursor, limit, archived)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.UserBoard)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, string, string, int, bool) string); ok {
+		r1 = rf(requesterID, sortBy, cursor, limit, archived)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(primitive.ObjectID, string, string, int, bool) error); ok {
+		r2 = rf(requesterID, sortBy, cursor, limit, archived)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Unarchive provides a mock function with given fields: requesterID, boardID
+func (_m *Usecase) Unarchive(requesterID primitive.ObjectID, boardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateDescription provides a mock function with given fields: requesterID, boardID, description
 func (_m *Usecase) UpdateDescription(requesterID primitive.ObjectID, boardID primitive.ObjectID, description string) error {
 	ret := _m.Called(requesterID, boardID, description)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
 	"github.com/jordyf15/thullo-api/board"
 	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/models"
@@ -20,15 +21,26 @@ func NewBoardRepository(dbClient *db.Client) board.Repository {
 	return &boardRepository{dbClient: dbClient}
 }
 
-func (repo *boardRepository) Create(board *models.Board) error {
+// Create stores the board together with the activity of its creation, the activity
+// is pointed at the board since the ID of the board is only known at this point
+func (repo *boardRepository) Create(board *models.Board, _activity *models.Activity) error {
 	board.ID = primitive.NewObjectID()
 	board.CreatedAt = time.Now()
 	board.UpdatedAt = board.CreatedAt
 
+	updates := map[string]interface{}{
+		fmt.Sprintf("boards/%s", board.ID.Hex()): board,
+	}
+
+	if _activity != nil {
+		_activity.BoardID, _activity.TargetID = board.ID, board.ID
+	}
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("boards/%s", board.ID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Set(ctx, board)
+	return ref.Update(ctx, updates)
 }
 
 func (repo *boardRepository) GetBoardByID(boardID primitive.ObjectID) (*models.Board, error) {
@@ -49,11 +61,81 @@ func (repo *boardRepository) GetBoardByID(boardID primitive.ObjectID) (*models.B
 	return board, nil
 }
 
-func (repo *boardRepository) Update(board *models.Board) error {
+// Delete removes the board along with all of its dependent records in a single
+// multi-path update so that either everything or nothing is removed, the board is taken
+// out of the members' board index as well and its webhooks are removed together with their
+// delivery logs. The activities of the board are kept as the record of what happened to it.
+func (repo *boardRepository) Delete(board *models.Board, lists []*models.List, cards []*models.Card, comments []*models.Comment, attachments []*models.Attachment, labels []*models.Label, boardMembers []*models.BoardMember, _activity *models.Activity) error {
+	ctx := context.Background()
+
+	updates := map[string]interface{}{
+		fmt.Sprintf("boards/%s", board.ID.Hex()): nil,
+	}
+
+	webhooksMap := make(map[string]*models.Webhook)
+	err := repo.dbClient.NewRef("webhooks").OrderByChild("board_id").EqualTo(board.ID.Hex()).Get(ctx, &webhooksMap)
+	if err != nil {
+		return err
+	}
+
+	for _, webhook := range webhooksMap {
+		updates[fmt.Sprintf("webhooks/%s", webhook.ID.Hex())] = nil
+
+		deliveryLogsMap := make(map[string]*models.WebhookDeliveryLog)
+		err = repo.dbClient.NewRef("webhook_delivery_logs").OrderByChild("webhook_id").EqualTo(webhook.ID.Hex()).Get(ctx, &deliveryLogsMap)
+		if err != nil {
+			return err
+		}
+
+		for _, deliveryLog := range deliveryLogsMap {
+			updates[fmt.Sprintf("webhook_delivery_logs/%s", deliveryLog.ID.Hex())] = nil
+		}
+	}
+
+	for _, list := range lists {
+		updates[fmt.Sprintf("lists/%s", list.ID.Hex())] = nil
+	}
+
+	for _, card := range cards {
+		updates[fmt.Sprintf("cards/%s", card.ID.Hex())] = nil
+		updates[fmt.Sprintf("checklists/%s", card.ID.Hex())] = nil
+	}
+
+	for _, comment := range comments {
+		updates[fmt.Sprintf("comments/%s", comment.ID.Hex())] = nil
+	}
+
+	for _, attachment := range attachments {
+		updates[fmt.Sprintf("attachments/%s", attachment.ID.Hex())] = nil
+	}
+
+	for _, label := range labels {
+		updates[fmt.Sprintf("labels/%s", label.ID.Hex())] = nil
+	}
+
+	for _, boardMember := range boardMembers {
+		updates[fmt.Sprintf("board_members/%s", boardMember.ID.Hex())] = nil
+		updates[fmt.Sprintf("user_boards/%s/%s", boardMember.UserID.Hex(), board.ID.Hex())] = nil
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *boardRepository) Update(board *models.Board, _activity *models.Activity) error {
 	board.UpdatedAt = time.Now()
 
+	updates := map[string]interface{}{
+		fmt.Sprintf("boards/%s", board.ID.Hex()): board,
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("boards/%s", board.ID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Set(ctx, board)
+	return ref.Update(ctx, updates)
 }
@@ -0,0 +1,89 @@
+package repository_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jordyf15/thullo-api/board/repository"
+	"github.com/jordyf15/thullo-api/models"
+	ubr "github.com/jordyf15/thullo-api/user_boards/repository"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDeleteRemovesBoardFromMembersIndex(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	assert.NoError(t, err)
+
+	boardRepo := repository.NewBoardRepository(dbClient)
+	userBoardsRepo := ubr.NewUserBoardsRepository(dbClient)
+
+	_board := &models.Board{ID: primitive.NewObjectID(), Title: "board"}
+	otherBoardID := primitive.NewObjectID()
+	boardMember := &models.BoardMember{ID: primitive.NewObjectID(), UserID: primitive.NewObjectID(), BoardID: _board.ID}
+
+	assert.NoError(t, server.Set(fmt.Sprintf("boards/%s", _board.ID.Hex()), _board))
+	assert.NoError(t, server.Set(fmt.Sprintf("board_members/%s", boardMember.ID.Hex()), boardMember))
+	assert.NoError(t, userBoardsRepo.AddBoard(boardMember.UserID, _board.ID))
+	assert.NoError(t, userBoardsRepo.AddBoard(boardMember.UserID, otherBoardID))
+
+	err = boardRepo.Delete(_board, nil, nil, nil, nil, nil, []*models.BoardMember{boardMember}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, server.Requests("PATCH", "/"))
+
+	userBoards, err := userBoardsRepo.GetUserBoards(boardMember.UserID)
+	assert.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{otherBoardID}, userBoards.BoardIDs)
+
+	_, err = boardRepo.GetBoardByID(_board.ID)
+	assert.Error(t, err)
+}
+
+func TestDeleteRemovesBoardWebhooksAndDeliveryLogs(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	assert.NoError(t, err)
+
+	boardRepo := repository.NewBoardRepository(dbClient)
+
+	_board := &models.Board{ID: primitive.NewObjectID(), Title: "board"}
+	webhook := &models.Webhook{ID: primitive.NewObjectID(), BoardID: _board.ID, URL: "https://example.com/hook", Active: true}
+	otherWebhook := &models.Webhook{ID: primitive.NewObjectID(), BoardID: primitive.NewObjectID(), URL: "https://example.com/hook", Active: true}
+	deliveryLog := &models.WebhookDeliveryLog{ID: primitive.NewObjectID(), WebhookID: webhook.ID, Attempt: 1, Succeeded: true}
+	otherDeliveryLog := &models.WebhookDeliveryLog{ID: primitive.NewObjectID(), WebhookID: otherWebhook.ID, Attempt: 1, Succeeded: true}
+
+	assert.NoError(t, server.Set(fmt.Sprintf("boards/%s", _board.ID.Hex()), _board))
+	assert.NoError(t, server.Set(fmt.Sprintf("webhooks/%s", webhook.ID.Hex()), webhook))
+	assert.NoError(t, server.Set(fmt.Sprintf("webhooks/%s", otherWebhook.ID.Hex()), otherWebhook))
+	assert.NoError(t, server.Set(fmt.Sprintf("webhook_delivery_logs/%s", deliveryLog.ID.Hex()), deliveryLog))
+	assert.NoError(t, server.Set(fmt.Sprintf("webhook_delivery_logs/%s", otherDeliveryLog.ID.Hex()), otherDeliveryLog))
+
+	err = boardRepo.Delete(_board, nil, nil, nil, nil, nil, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, server.Requests("PATCH", "/"))
+
+	remaining := map[string]interface{}{}
+	assert.NoError(t, server.Get("webhooks", &remaining))
+	assert.Equal(t, []string{otherWebhook.ID.Hex()}, keys(remaining))
+
+	remaining = map[string]interface{}{}
+	assert.NoError(t, server.Get("webhook_delivery_logs", &remaining))
+	assert.Equal(t, []string{otherDeliveryLog.ID.Hex()}, keys(remaining))
+}
+
+func keys(values map[string]interface{}) []string {
+	result := []string{}
+	for key := range values {
+		result = append(result, key)
+	}
+
+	return result
+}
@@ -1,53 +1,57 @@
 package usecase
 
 import (
+	"encoding/base64"
 	"fmt"
-	"math"
-	"sync"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/jordyf15/thullo-api/attachment"
 	"github.com/jordyf15/thullo-api/board"
 	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/comment"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/label"
+	"github.com/jordyf15/thullo-api/list"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/notification"
 	"github.com/jordyf15/thullo-api/storage"
 	"github.com/jordyf15/thullo-api/unsplash"
 	"github.com/jordyf15/thullo-api/user"
-	"github.com/jordyf15/thullo-api/utils"
+	"github.com/jordyf15/thullo-api/user_boards"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type boardUsecase struct {
-	boardRepo       board.Repository
-	unsplashRepo    unsplash.Repository
-	boardMemberRepo board_member.Repository
-	userRepo        user.Repository
-	storage         storage.Storage
+	boardRepo        board.Repository
+	unsplashRepo     unsplash.Repository
+	boardMemberRepo  board_member.Repository
+	userRepo         user.Repository
+	listRepo         list.Repository
+	cardRepo         card.Repository
+	userBoardsRepo   user_boards.Repository
+	commentRepo      comment.Repository
+	attachmentRepo   attachment.Repository
+	labelRepo        label.Repository
+	notificationRepo notification.Repository
+	eventRepo        event.Repository
+	storage          storage.Storage
+	fileStorage      storage.Storage
 }
 
-func NewBoardUsecase(boardRepo board.Repository, unsplashRepo unsplash.Repository, boardMemberRepo board_member.Repository, userRepo user.Repository, storage storage.Storage) board.Usecase {
-	return &boardUsecase{boardRepo: boardRepo, unsplashRepo: unsplashRepo, boardMemberRepo: boardMemberRepo, userRepo: userRepo, storage: storage}
+func NewBoardUsecase(boardRepo board.Repository, unsplashRepo unsplash.Repository, boardMemberRepo board_member.Repository, userRepo user.Repository, listRepo list.Repository, cardRepo card.Repository, userBoardsRepo user_boards.Repository, commentRepo comment.Repository, attachmentRepo attachment.Repository, labelRepo label.Repository, notificationRepo notification.Repository, eventRepo event.Repository, storage storage.Storage, fileStorage storage.Storage) board.Usecase {
+	return &boardUsecase{boardRepo: boardRepo, unsplashRepo: unsplashRepo, boardMemberRepo: boardMemberRepo, userRepo: userRepo, listRepo: listRepo, cardRepo: cardRepo, userBoardsRepo: userBoardsRepo, commentRepo: commentRepo, attachmentRepo: attachmentRepo, labelRepo: labelRepo, notificationRepo: notificationRepo, eventRepo: eventRepo, storage: storage, fileStorage: fileStorage}
 }
 
 func (usecase *boardUsecase) Create(userID primitive.ObjectID, title string, visibility string, cover map[string]interface{}) error {
-	errors := []error{}
-
 	source := cover["source"].(string)
 	focalPointY := cover["fp_y"].(float64)
 	photoID := cover["photo_id"].(string)
 
-	focalPointY = math.Round(focalPointY*1000) / 1000
-
-	if _, exist := board.BoardCoverSources[source]; !exist {
-		errors = append(errors, custom_errors.ErrInvalidCoverSource)
-	}
-
-	if focalPointY > 1 {
-		errors = append(errors, custom_errors.ErrUnsplashFocalPointYTooHigh)
-	}
-
-	if focalPointY < 0 {
-		errors = append(errors, custom_errors.ErrUnsplashFocalPointYTooLow)
-	}
+	focalPointY, errors := board.VerifyCover(source, focalPointY)
 
 	if title == "" {
 		errors = append(errors, custom_errors.ErrBoardTitleEmpty)
@@ -61,84 +65,287 @@ func (usecase *boardUsecase) Create(userID primitive.ObjectID, title string, vis
 		return &custom_errors.MultipleErrors{Errors: errors}
 	}
 
-	imageFiles, err := usecase.unsplashRepo.GetImagesForID(photoID, focalPointY)
+	boardCover, err := board.UploadCover(usecase.unsplashRepo, usecase.storage, source, photoID, focalPointY, fmt.Sprintf("cover picture of board %s", title))
+	if err != nil {
+		return err
+	}
+
+	_board := &models.Board{
+		Title:   title,
+		OwnerID: userID,
+		Cover:   boardCover,
+	}
+	_board.SetVisibility(visibility)
+	_board.EmptyImageURLs()
+
+	err = usecase.boardRepo.Create(_board, &models.Activity{
+		ActorID: userID,
+		Verb:    models.ActivityVerbCreated,
+		Target:  models.ActivityTargetBoard,
+		After:   _board,
+	})
+	if err != nil {
+		return err
+	}
+
+	boardMember := &models.BoardMember{
+		UserID:  userID,
+		BoardID: _board.ID,
+		Role:    models.MemberRoleAdmin,
+	}
+
+	// the creation of the board already records its first admin
+	err = usecase.boardMemberRepo.Create(boardMember, nil)
+	if err != nil {
+		return err
+	}
+
+	err = usecase.userBoardsRepo.AddBoard(userID, _board.ID)
 	if err != nil {
 		return err
 	}
 
-	boardCover := &models.BoardCover{
-		PhotoID:     photoID,
-		Source:      source,
-		FocalPointY: focalPointY,
-		Images:      make(models.Images, len(board.BoardCoverSizes)),
+	return nil
+}
+
+func (usecase *boardUsecase) GetBoardByID(requesterID, boardID primitive.ObjectID, filter board.CardFilter) (*models.Board, error) {
+	_board, err := usecase.boardRepo.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
 	}
 
-	for i, width := range board.BoardCoverSizes {
-		image := &models.Image{
-			Width: width,
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	isRequesterBoardMember := false
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID {
+			isRequesterBoardMember = true
+			break
 		}
+	}
+
+	// private boards can only be seen by its members
+	if _board.Visibility == models.BoardVisibilityPrivate && !isRequesterBoardMember {
+		return nil, custom_errors.ErrNotAuthorized
+	}
+
+	if _board.Cover != nil {
+		err = usecase.storage.AssignImageURLToBoardCover(_board.Cover)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	boardLists, err := usecase.listRepo.GetBoardLists(boardID)
+	if err != nil {
+		return nil, err
+	}
 
-		boardCover.Images[i] = image
+	lists := []*models.List{}
+	for _, list := range boardLists {
+		if !list.Archived {
+			lists = append(lists, list)
+		}
 	}
 
-	var wg sync.WaitGroup
-	uploadChannels := make(chan error, len(boardCover.Images))
-	wg.Add(len(boardCover.Images))
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].Rank < lists[j].Rank
+	})
+
+	now := time.Now()
+	for position, list := range lists {
+		list.Position = position
+
+		listCards, err := usecase.cardRepo.GetListCards(list.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		cards := []*models.Card{}
+		for _, card := range listCards {
+			if !card.Archived {
+				cards = append(cards, card)
+			}
+		}
+
+		sort.Slice(cards, func(i, j int) bool {
+			return cards[i].Rank < cards[j].Rank
+		})
+
+		// the positions are taken before filtering so they stay
+		// the positions of the cards among every active card
+		filteredCards := []*models.Card{}
+		for position, card := range cards {
+			card.Position = position
+
+			if !filter.Match(card, now) {
+				continue
+			}
 
-	for idx, image := range boardCover.Images {
-		imageFile := imageFiles[idx]
-		name := utils.RandString(8)
-		fileName := fmt.Sprintf("%s.%s", name, utils.GetFileExtension(imageFile.Name()))
+			if card.Cover != nil {
+				err = usecase.storage.AssignImageURLToBoardCover(card.Cover)
+				if err != nil {
+					return nil, err
+				}
+			}
 
-		metaData := map[string]string{
-			"name":        fileName,
-			"title":       name,
-			"description": fmt.Sprintf("cover picture of board %s with width of %v", title, image.Width),
+			filteredCards = append(filteredCards, card)
 		}
 
-		go usecase.storage.UploadFile(uploadChannels, &wg, image, imageFile, metaData)
+		list.Cards = filteredCards
 	}
 
-	wg.Wait()
-	close(uploadChannels)
+	labels, err := usecase.labelRepo.GetBoardLabels(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].CreatedAt.Before(labels[j].CreatedAt)
+	})
 
-	for err = range uploadChannels {
+	for _, boardMember := range boardMembers {
+		member, err := usecase.userRepo.GetByID(boardMember.UserID)
 		if err != nil {
-			errors = append(errors, err)
+			return nil, err
 		}
+
+		err = usecase.storage.AssignImageURLToUser(member)
+		if err != nil {
+			return nil, err
+		}
+
+		member.EmptyImageIDs()
+		boardMember.User = member.Profile()
 	}
 
-	if len(errors) > 0 {
-		return &custom_errors.MultipleErrors{Errors: errors}
+	_board.Lists = lists
+	_board.Labels = labels
+	_board.Members = boardMembers
+
+	return _board, nil
+}
+
+// GetUserBoards returns a page of the boards the requester is a member of, either the
+// active ones or, when archived is set, the ones that have been archived
+func (usecase *boardUsecase) GetUserBoards(requesterID primitive.ObjectID, sortBy, cursor string, limit int, archived bool) ([]*models.UserBoard, string, error) {
+	if sortBy == "" {
+		sortBy = board.SortByUpdatedAt
 	}
 
-	<-uploadChannels
+	if sortBy != board.SortByUpdatedAt && sortBy != board.SortByTitle {
+		return nil, "", custom_errors.ErrBoardInvalidSortOption
+	}
 
-	_board := &models.Board{
-		Title:   title,
-		OwnerID: userID,
-		Cover:   boardCover,
+	if limit <= 0 {
+		limit = board.DefaultUserBoardsLimit
+	} else if limit > board.MaxUserBoardsLimit {
+		limit = board.MaxUserBoardsLimit
 	}
-	_board.SetVisibility(visibility)
-	_board.EmptyImageURLs()
 
-	err = usecase.boardRepo.Create(_board)
+	var cursorBoard *models.UserBoard
+	if cursor != "" {
+		var err error
+		cursorBoard, err = decodeUserBoardsCursor(cursor, sortBy)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	userBoards, err := usecase.userBoardsRepo.GetUserBoards(requesterID)
 	if err != nil {
-		return err
+		return nil, "", err
 	}
 
-	boardMember := &models.BoardMember{
-		UserID:  userID,
-		BoardID: _board.ID,
-		Role:    models.MemberRoleAdmin,
+	boards := []*models.UserBoard{}
+	for _, boardID := range userBoards.BoardIDs {
+		_board, err := usecase.boardRepo.GetBoardByID(boardID)
+		if err == custom_errors.ErrRecordNotFound {
+			continue
+		}
+
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _board.Archived != archived {
+			continue
+		}
+
+		boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		var role models.MemberRole
+		for _, boardMember := range boardMembers {
+			if boardMember.UserID == requesterID {
+				role = boardMember.Role
+				break
+			}
+		}
+
+		// the index can still point to a board the user has left
+		if role == "" {
+			continue
+		}
+
+		userBoard := &models.UserBoard{
+			BoardID:    _board.ID,
+			Title:      _board.Title,
+			Visibility: _board.Visibility,
+			Role:       role,
+			UpdatedAt:  _board.UpdatedAt,
+		}
+
+		if _board.Cover != nil {
+			userBoard.Thumbnail = _board.Cover.Thumbnail()
+		}
+
+		boards = append(boards, userBoard)
 	}
 
-	err = usecase.boardMemberRepo.Create(boardMember)
-	if err != nil {
-		return err
+	sort.Slice(boards, func(i, j int) bool {
+		return isUserBoardBefore(boards[i], boards[j], sortBy)
+	})
+
+	start := 0
+	if cursorBoard != nil {
+		start = len(boards)
+		for i, userBoard := range boards {
+			if isUserBoardBefore(cursorBoard, userBoard, sortBy) {
+				start = i
+				break
+			}
+		}
 	}
 
-	return nil
+	end := start + limit
+	if end > len(boards) {
+		end = len(boards)
+	}
+
+	page := boards[start:end]
+
+	// only fetch the thumbnail url of the boards that are returned
+	for _, userBoard := range page {
+		if userBoard.Thumbnail != nil {
+			err = usecase.storage.AssignImageURLToBoardCover(&models.BoardCover{Images: models.Images{userBoard.Thumbnail}})
+			if err != nil {
+				return nil, "", err
+			}
+		}
+	}
+
+	nextCursor := ""
+	if end < len(boards) {
+		nextCursor = encodeUserBoardsCursor(page[len(page)-1], sortBy)
+	}
+
+	return page, nextCursor, nil
 }
 
 func (usecase *boardUsecase) UpdateVisibility(requesterID, boardID primitive.ObjectID, visibility string) error {
@@ -168,13 +375,30 @@ func (usecase *boardUsecase) UpdateVisibility(requesterID, boardID primitive.Obj
 		return custom_errors.ErrNotAuthorized
 	}
 
+	_activity := &models.Activity{
+		BoardID:  board.ID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUpdated,
+		Target:   models.ActivityTargetBoard,
+		TargetID: board.ID,
+		Before:   map[string]interface{}{"visibility": board.Visibility},
+	}
+
 	board.Visibility = models.BoardVisibility(visibility)
+	_activity.After = map[string]interface{}{"visibility": board.Visibility}
 
-	err = usecase.boardRepo.Update(board)
+	err = usecase.boardRepo.Update(board, _activity)
 	if err != nil {
 		return err
 	}
 
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventBoardUpdated,
+		BoardID: board.ID,
+		ActorID: requesterID,
+		Data:    board,
+	})
+
 	return nil
 }
 
@@ -205,13 +429,30 @@ func (usecase *boardUsecase) UpdateTitle(requesterID, boardID primitive.ObjectID
 		return custom_errors.ErrNotAuthorized
 	}
 
+	_activity := &models.Activity{
+		BoardID:  board.ID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUpdated,
+		Target:   models.ActivityTargetBoard,
+		TargetID: board.ID,
+		Before:   map[string]interface{}{"title": board.Title},
+	}
+
 	board.Title = title
+	_activity.After = map[string]interface{}{"title": board.Title}
 
-	err = usecase.boardRepo.Update(board)
+	err = usecase.boardRepo.Update(board, _activity)
 	if err != nil {
 		return err
 	}
 
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventBoardUpdated,
+		BoardID: board.ID,
+		ActorID: requesterID,
+		Data:    board,
+	})
+
 	return nil
 }
 
@@ -238,13 +479,30 @@ func (usecase *boardUsecase) UpdateDescription(requesterID, boardID primitive.Ob
 		return custom_errors.ErrNotAuthorized
 	}
 
+	_activity := &models.Activity{
+		BoardID:  board.ID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUpdated,
+		Target:   models.ActivityTargetBoard,
+		TargetID: board.ID,
+		Before:   map[string]interface{}{"description": board.Description},
+	}
+
 	board.Description = description
+	_activity.After = map[string]interface{}{"description": board.Description}
 
-	err = usecase.boardRepo.Update(board)
+	err = usecase.boardRepo.Update(board, _activity)
 	if err != nil {
 		return err
 	}
 
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventBoardUpdated,
+		BoardID: board.ID,
+		ActorID: requesterID,
+		Data:    board,
+	})
+
 	return nil
 }
 
@@ -254,11 +512,6 @@ func (usecase *boardUsecase) AddMember(requesterID, boardID, memberID primitive.
 		return err
 	}
 
-	_, err = usecase.userRepo.GetByID(memberID)
-	if err != nil {
-		return err
-	}
-
 	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(board.ID)
 	if err != nil {
 		return err
@@ -288,13 +541,52 @@ func (usecase *boardUsecase) AddMember(requesterID, boardID, memberID primitive.
 		return custom_errors.ErrUserIsAlreadyBoardMember
 	}
 
+	// the new member is only looked up once the requester is known to be allowed to add members
+	// so users that are not on the board can not find out which accounts are verified
+	member, err := usecase.userRepo.GetByID(memberID)
+	if err != nil {
+		return err
+	}
+
+	if !member.EmailVerified {
+		return custom_errors.ErrUserEmailNotVerified
+	}
+
 	boardMember := &models.BoardMember{
 		UserID:  memberID,
 		BoardID: board.ID,
 		Role:    models.MemberRoleMember,
 	}
 
-	err = usecase.boardMemberRepo.Create(boardMember)
+	err = usecase.boardMemberRepo.Create(boardMember, &models.Activity{
+		BoardID:  board.ID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbAdded,
+		Target:   models.ActivityTargetMember,
+		TargetID: memberID,
+		After:    boardMember,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = usecase.userBoardsRepo.AddBoard(memberID, board.ID)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventMemberAdded,
+		BoardID: board.ID,
+		ActorID: requesterID,
+		Data:    boardMember,
+	})
+
+	err = usecase.notifyMember(requesterID, memberID, &models.Notification{
+		Type:    models.NotificationTypeBoardMemberAdded,
+		BoardID: board.ID,
+		Role:    boardMember.Role,
+	})
 	if err != nil {
 		return err
 	}
@@ -347,7 +639,44 @@ func (usecase *boardUsecase) UpdateMemberRole(requesterID, boardID, memberID pri
 		return custom_errors.ErrBoardMustHaveAnAdmin
 	}
 
-	err = usecase.boardMemberRepo.UpdateBoardMemberRole(memberBoardMember.ID, models.MemberRole(role))
+	isRoleChanged := memberBoardMember.Role != models.MemberRole(role)
+
+	// writing the same role again is not a change worth recording
+	var _activity *models.Activity
+	if isRoleChanged {
+		_activity = &models.Activity{
+			BoardID:  boardID,
+			ActorID:  requesterID,
+			Verb:     models.ActivityVerbUpdated,
+			Target:   models.ActivityTargetMember,
+			TargetID: memberID,
+			Before:   map[string]interface{}{"role": memberBoardMember.Role},
+			After:    map[string]interface{}{"role": role},
+		}
+	}
+
+	err = usecase.boardMemberRepo.UpdateBoardMemberRole(memberBoardMember.ID, models.MemberRole(role), _activity)
+	if err != nil {
+		return err
+	}
+
+	if !isRoleChanged {
+		return nil
+	}
+
+	memberBoardMember.Role = models.MemberRole(role)
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventMemberUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    memberBoardMember,
+	})
+
+	err = usecase.notifyMember(requesterID, memberID, &models.Notification{
+		Type:    models.NotificationTypeBoardRoleUpdated,
+		BoardID: boardID,
+		Role:    models.MemberRole(role),
+	})
 	if err != nil {
 		return err
 	}
@@ -396,10 +725,408 @@ func (usecase *boardUsecase) DeleteMember(requesterID, boardID, memberID primiti
 		return custom_errors.ErrBoardMustHaveAnAdmin
 	}
 
-	err = usecase.boardMemberRepo.DeleteBoardMemberByID(memberBoardMember.ID)
+	lists, err := usecase.listRepo.GetBoardLists(boardID)
+	if err != nil {
+		return err
+	}
+
+	// archived cards are unassigned and unwatched as well so the member does not come back when they are restored
+	unassignedCards := []*models.Card{}
+	for _, list := range lists {
+		cards, err := usecase.cardRepo.GetListCards(list.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, card := range cards {
+			isUnassigned := card.Unassign(memberID)
+			isUnwatched := card.Unwatch(memberID)
+			if isUnassigned || isUnwatched {
+				unassignedCards = append(unassignedCards, card)
+			}
+		}
+	}
+
+	err = usecase.boardMemberRepo.DeleteBoardMemberByID(memberBoardMember.ID, unassignedCards, &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbRemoved,
+		Target:   models.ActivityTargetMember,
+		TargetID: memberID,
+		Before:   memberBoardMember,
+	})
+	if err != nil {
+		return err
+	}
+
+	err = usecase.userBoardsRepo.RemoveBoard(memberID, boardID)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventMemberRemoved,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    memberBoardMember,
+	})
+
+	err = usecase.notifyMember(requesterID, memberID, &models.Notification{
+		Type:    models.NotificationTypeBoardMemberRemoved,
+		BoardID: boardID,
+	})
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// notifyMember sends the notification to the member on behalf of the requester,
+// members are not notified about changes they made to their own membership
+func (usecase *boardUsecase) notifyMember(requesterID, memberID primitive.ObjectID, _notification *models.Notification) error {
+	if requesterID == memberID {
+		return nil
+	}
+
+	_notification.UserID = memberID
+	_notification.ActorID = requesterID
+
+	return usecase.notificationRepo.Create([]*models.Notification{_notification})
+}
+
+func (usecase *boardUsecase) Delete(requesterID, boardID primitive.ObjectID) error {
+	_board, err := usecase.boardRepo.GetBoardByID(boardID)
+	if err != nil {
+		return err
+	}
+
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	var requesterBoardMember *models.BoardMember
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID {
+			requesterBoardMember = boardMember
+			break
+		}
+	}
+
+	if requesterBoardMember == nil || requesterBoardMember.Role != models.MemberRoleAdmin {
+		return custom_errors.ErrNotAuthorized
+	}
+
+	lists, err := usecase.listRepo.GetBoardLists(boardID)
+	if err != nil {
+		return err
+	}
+
+	covers := []*models.BoardCover{}
+	if _board.Cover != nil {
+		covers = append(covers, _board.Cover)
+	}
+
+	cards := []*models.Card{}
+	comments := []*models.Comment{}
+	attachments := []*models.Attachment{}
+	for _, list := range lists {
+		listCards, err := usecase.cardRepo.GetListCards(list.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, card := range listCards {
+			cardComments, err := usecase.commentRepo.GetCardComments(card.ID)
+			if err != nil {
+				return err
+			}
+
+			cardAttachments, err := usecase.attachmentRepo.GetCardAttachments(card.ID)
+			if err != nil {
+				return err
+			}
+
+			if card.Cover != nil {
+				covers = append(covers, card.Cover)
+			}
+
+			comments = append(comments, cardComments...)
+			attachments = append(attachments, cardAttachments...)
+		}
+
+		cards = append(cards, listCards...)
+	}
+
+	labels, err := usecase.labelRepo.GetBoardLabels(boardID)
+	if err != nil {
+		return err
+	}
+
+	err = usecase.boardRepo.Delete(_board, lists, cards, comments, attachments, labels, boardMembers, &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbDeleted,
+		Target:   models.ActivityTargetBoard,
+		TargetID: boardID,
+		Before:   _board,
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventBoardDeleted,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _board,
+	})
+
+	// the board is already gone at this point so failing to clean up the uploaded
+	// covers or attachments should not fail the request
+	board.DeleteCoverImages(usecase.storage, covers...)
+	attachment.DeleteAttachmentFiles(usecase.storage, usecase.fileStorage, attachments...)
+
+	return nil
+}
+
+func (usecase *boardUsecase) Archive(requesterID, boardID primitive.ObjectID) error {
+	_board, err := usecase.getBoardAsAdmin(requesterID, boardID)
+	if err != nil {
+		return err
+	}
+
+	if _board.Archived {
+		return custom_errors.ErrBoardArchived
+	}
+
+	now := time.Now()
+	_board.Archived = true
+	_board.ArchivedAt = &now
+
+	err = usecase.boardRepo.Update(_board, &models.Activity{
+		BoardID:  _board.ID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbArchived,
+		Target:   models.ActivityTargetBoard,
+		TargetID: _board.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventBoardArchived,
+		BoardID: _board.ID,
+		ActorID: requesterID,
+		Data:    _board,
+	})
+
+	return nil
+}
+
+func (usecase *boardUsecase) Unarchive(requesterID, boardID primitive.ObjectID) error {
+	_board, err := usecase.getBoardAsAdmin(requesterID, boardID)
+	if err != nil {
+		return err
+	}
+
+	if !_board.Archived {
+		return custom_errors.ErrBoardNotArchived
+	}
+
+	_board.Archived = false
+	_board.ArchivedAt = nil
+
+	err = usecase.boardRepo.Update(_board, &models.Activity{
+		BoardID:  _board.ID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUnarchived,
+		Target:   models.ActivityTargetBoard,
+		TargetID: _board.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventBoardUnarchived,
+		BoardID: _board.ID,
+		ActorID: requesterID,
+		Data:    _board,
+	})
+
+	return nil
+}
+
+func (usecase *boardUsecase) GetArchivedItems(requesterID, boardID primitive.ObjectID) (*models.ArchivedItems, error) {
+	_board, err := usecase.boardRepo.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _board.Visibility == models.BoardVisibilityPrivate {
+		boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+		if err != nil {
+			return nil, err
+		}
+
+		isRequesterBoardMember := false
+		for _, boardMember := range boardMembers {
+			if boardMember.UserID == requesterID {
+				isRequesterBoardMember = true
+				break
+			}
+		}
+
+		if !isRequesterBoardMember {
+			return nil, custom_errors.ErrNotAuthorized
+		}
+	}
+
+	lists, err := usecase.listRepo.GetBoardLists(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	archivedItems := &models.ArchivedItems{Lists: []*models.List{}, Cards: []*models.Card{}}
+	for _, list := range lists {
+		cards, err := usecase.cardRepo.GetListCards(list.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// an archived list comes with the cards that will be restored along with it
+		activeCards := []*models.Card{}
+		for _, card := range cards {
+			if card.Archived {
+				archivedItems.Cards = append(archivedItems.Cards, card)
+			} else {
+				activeCards = append(activeCards, card)
+			}
+		}
+
+		if list.Archived {
+			sort.Slice(activeCards, func(i, j int) bool {
+				return activeCards[i].Rank < activeCards[j].Rank
+			})
+
+			for position, card := range activeCards {
+				card.Position = position
+			}
+
+			list.Cards = activeCards
+			archivedItems.Lists = append(archivedItems.Lists, list)
+		}
+	}
+
+	// the most recently archived items come first
+	sort.Slice(archivedItems.Lists, func(i, j int) bool {
+		return archiveTime(archivedItems.Lists[i].ArchivedAt, archivedItems.Lists[i].UpdatedAt).After(archiveTime(archivedItems.Lists[j].ArchivedAt, archivedItems.Lists[j].UpdatedAt))
+	})
+
+	sort.Slice(archivedItems.Cards, func(i, j int) bool {
+		return archiveTime(archivedItems.Cards[i].ArchivedAt, archivedItems.Cards[i].UpdatedAt).After(archiveTime(archivedItems.Cards[j].ArchivedAt, archivedItems.Cards[j].UpdatedAt))
+	})
+
+	return archivedItems, nil
+}
+
+// archiveTime returns when an item has been archived, items that were archived before
+// the time was stored fall back to their last update which used to be the archiving
+func archiveTime(archivedAt *time.Time, updatedAt time.Time) time.Time {
+	if archivedAt != nil {
+		return *archivedAt
+	}
+
+	return updatedAt
+}
+
+func (usecase *boardUsecase) getBoardAsAdmin(requesterID, boardID primitive.ObjectID) (*models.Board, error) {
+	_board, err := usecase.boardRepo.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	var requesterBoardMember *models.BoardMember
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID {
+			requesterBoardMember = boardMember
+			break
+		}
+	}
+
+	if requesterBoardMember == nil || requesterBoardMember.Role != models.MemberRoleAdmin {
+		return nil, custom_errors.ErrNotAuthorized
+	}
+
+	return _board, nil
+}
+
+// isUserBoardBefore reports whether a comes before b for the given sort option,
+// the board ID is used as a tie breaker so that the order is always stable
+func isUserBoardBefore(a, b *models.UserBoard, sortBy string) bool {
+	switch sortBy {
+	case board.SortByTitle:
+		aTitle, bTitle := strings.ToLower(a.Title), strings.ToLower(b.Title)
+		if aTitle != bTitle {
+			return aTitle < bTitle
+		}
+	default:
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.After(b.UpdatedAt)
+		}
+	}
+
+	return a.BoardID.Hex() < b.BoardID.Hex()
+}
+
+// a cursor holds the sort option, the board ID and the sort value of the last returned board
+func encodeUserBoardsCursor(userBoard *models.UserBoard, sortBy string) string {
+	var value string
+	switch sortBy {
+	case board.SortByTitle:
+		value = userBoard.Title
+	default:
+		value = userBoard.UpdatedAt.Format(time.RFC3339Nano)
+	}
+
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s|%s|%s", sortBy, userBoard.BoardID.Hex(), value)))
+}
+
+func decodeUserBoardsCursor(cursor, sortBy string) (*models.UserBoard, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, custom_errors.ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(decoded), "|", 3)
+	if len(parts) != 3 || parts[0] != sortBy {
+		return nil, custom_errors.ErrInvalidCursor
+	}
+
+	boardID, err := primitive.ObjectIDFromHex(parts[1])
+	if err != nil {
+		return nil, custom_errors.ErrInvalidCursor
+	}
+
+	userBoard := &models.UserBoard{BoardID: boardID}
+
+	switch sortBy {
+	case board.SortByTitle:
+		userBoard.Title = parts[2]
+	default:
+		userBoard.UpdatedAt, err = time.Parse(time.RFC3339Nano, parts[2])
+		if err != nil {
+			return nil, custom_errors.ErrInvalidCursor
+		}
+	}
+
+	return userBoard, nil
+}
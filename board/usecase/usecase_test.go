@@ -1,19 +1,29 @@
 package usecase_test
 
 import (
+	"encoding/json"
 	"os"
 	"sync"
 	"testing"
+	"time"
 
+	ar "github.com/jordyf15/thullo-api/attachment/mocks"
 	"github.com/jordyf15/thullo-api/board"
 	br "github.com/jordyf15/thullo-api/board/mocks"
 	"github.com/jordyf15/thullo-api/board/usecase"
 	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	cr "github.com/jordyf15/thullo-api/card/mocks"
+	cmr "github.com/jordyf15/thullo-api/comment/mocks"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	lbr "github.com/jordyf15/thullo-api/label/mocks"
+	lr "github.com/jordyf15/thullo-api/list/mocks"
 	"github.com/jordyf15/thullo-api/models"
+	nr "github.com/jordyf15/thullo-api/notification/mocks"
 	sr "github.com/jordyf15/thullo-api/storage/mocks"
 	unr "github.com/jordyf15/thullo-api/unsplash/mocks"
 	ur "github.com/jordyf15/thullo-api/user/mocks"
+	ubr "github.com/jordyf15/thullo-api/user_boards/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -25,17 +35,35 @@ func TestBoardUsecase(t *testing.T) {
 }
 
 var (
-	img1         *os.File
-	img2         *os.File
-	img3         *os.File
-	requesterID1 = primitive.NewObjectID()
-	requesterID2 = primitive.NewObjectID()
-	newMemberID1 = primitive.NewObjectID()
-	newMemberID2 = primitive.NewObjectID()
+	img1           *os.File
+	img2           *os.File
+	img3           *os.File
+	requesterID1   = primitive.NewObjectID()
+	requesterID2   = primitive.NewObjectID()
+	newMemberID1   = primitive.NewObjectID()
+	newMemberID2   = primitive.NewObjectID()
+	deletedBoardID = primitive.NewObjectID()
 
 	board1 = &models.Board{
 		ID: primitive.NewObjectID(),
 	}
+	board2 = &models.Board{
+		ID:         primitive.NewObjectID(),
+		Visibility: models.BoardVisibilityPrivate,
+	}
+	board3 = &models.Board{
+		ID:        primitive.NewObjectID(),
+		Title:     "b board",
+		UpdatedAt: time.Now().Add(-time.Hour),
+		Cover: &models.BoardCover{
+			Images: models.Images{{Width: 1080, ID: "img-1080"}, {Width: 150, ID: "img-150"}},
+		},
+	}
+	board4 = &models.Board{
+		ID:        primitive.NewObjectID(),
+		Title:     "A board",
+		UpdatedAt: time.Now(),
+	}
 	boardMember1 = &models.BoardMember{
 		ID:      primitive.NewObjectID(),
 		UserID:  requesterID1,
@@ -48,8 +76,57 @@ var (
 		BoardID: board1.ID,
 		Role:    models.MemberRoleMember,
 	}
+	boardMember3 = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		UserID:  requesterID1,
+		BoardID: board3.ID,
+		Role:    models.MemberRoleAdmin,
+	}
+	boardMember4 = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		UserID:  requesterID1,
+		BoardID: board4.ID,
+		Role:    models.MemberRoleMember,
+	}
 	user1 = &models.User{
-		ID: newMemberID1,
+		ID:            newMemberID1,
+		Email:         "user1@mail.com",
+		EmailVerified: true,
+	}
+	unverifiedUser = &models.User{
+		ID: primitive.NewObjectID(),
+	}
+
+	list1 = &models.List{
+		ID:      primitive.NewObjectID(),
+		BoardID: board1.ID,
+		Rank:    "r",
+	}
+	list2 = &models.List{
+		ID:      primitive.NewObjectID(),
+		BoardID: board1.ID,
+		Rank:    "i",
+	}
+	label1 = &models.Label{
+		ID:      primitive.NewObjectID(),
+		BoardID: board1.ID,
+	}
+	card1 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list1.ID,
+		Rank:   "r",
+	}
+	card2 = &models.Card{
+		ID:       primitive.NewObjectID(),
+		ListID:   list1.ID,
+		LabelIDs: []primitive.ObjectID{label1.ID},
+		Rank:     "i",
+	}
+	card3 = &models.Card{
+		ID:       primitive.NewObjectID(),
+		ListID:   list1.ID,
+		Rank:     "m",
+		Archived: true,
 	}
 )
 
@@ -58,11 +135,24 @@ type boardUsecaseSuite struct {
 
 	usecase board.Usecase
 
-	boardRepo       *br.Repository
-	unsplashRepo    *unr.Repository
-	boardMemberRepo *bmr.Repository
-	userRepo        *ur.Repository
-	storage         *sr.Storage
+	boardRepo        *br.Repository
+	unsplashRepo     *unr.Repository
+	boardMemberRepo  *bmr.Repository
+	userRepo         *ur.Repository
+	listRepo         *lr.Repository
+	cardRepo         *cr.Repository
+	userBoardsRepo   *ubr.Repository
+	commentRepo      *cmr.Repository
+	attachmentRepo   *ar.Repository
+	labelRepo        *lbr.Repository
+	notificationRepo *nr.Repository
+	eventRepo        *er.Repository
+	storage          *sr.Storage
+	fileStorage      *sr.Storage
+
+	notifications []*models.Notification
+	events        []*models.BoardEvent
+	activities    []*models.Activity
 }
 
 func (s *boardUsecaseSuite) SetupTest() {
@@ -70,20 +160,78 @@ func (s *boardUsecaseSuite) SetupTest() {
 	s.unsplashRepo = new(unr.Repository)
 	s.userRepo = new(ur.Repository)
 	s.boardMemberRepo = new(bmr.Repository)
+	s.listRepo = new(lr.Repository)
+	s.cardRepo = new(cr.Repository)
+	s.userBoardsRepo = new(ubr.Repository)
+	s.commentRepo = new(cmr.Repository)
+	s.attachmentRepo = new(ar.Repository)
+	s.labelRepo = new(lbr.Repository)
+	s.notificationRepo = new(nr.Repository)
+	s.eventRepo = new(er.Repository)
 	s.storage = new(sr.Storage)
+	s.fileStorage = new(sr.Storage)
 
 	img1, _ = os.Create("image1.jpg")
 	img2, _ = os.Create("image2.jpg")
 	img3, _ = os.Create("image3.jpg")
 
 	getBoardMembers := func(boardID primitive.ObjectID) []*models.BoardMember {
-		if boardID == board1.ID {
+		switch boardID {
+		case board1.ID:
 			return []*models.BoardMember{boardMember1, boardMember2}
+		case board3.ID:
+			return []*models.BoardMember{boardMember3}
+		case board4.ID:
+			return []*models.BoardMember{boardMember4}
 		}
 
 		return []*models.BoardMember{}
 	}
 
+	board3.Archived = false
+	board3.ArchivedAt = nil
+	card1.Archived = false
+	card1.ArchivedAt = nil
+	card3.ArchivedAt = nil
+
+	getBoardByID := func(boardID primitive.ObjectID) *models.Board {
+		switch boardID {
+		case board2.ID:
+			return board2
+		case board3.ID:
+			return board3
+		case board4.ID:
+			return board4
+		case deletedBoardID:
+			return nil
+		}
+
+		return board1
+	}
+
+	getBoardByIDError := func(boardID primitive.ObjectID) error {
+		if boardID == deletedBoardID {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		return nil
+	}
+
+	getListCards := func(listID primitive.ObjectID) []*models.Card {
+		if listID == list1.ID {
+			return []*models.Card{card1, card2, card3}
+		}
+
+		return []*models.Card{}
+	}
+
+	s.activities = []*models.Activity{}
+	recordActivity := func(args mock.Arguments) {
+		if _activity, _ := args.Get(len(args) - 1).(*models.Activity); _activity != nil {
+			s.activities = append(s.activities, _activity)
+		}
+	}
+
 	s.unsplashRepo.On("GetImagesForID", mock.AnythingOfType("string"), mock.AnythingOfType("float64")).Return([]*os.File{img1, img2, img3}, nil)
 	s.storage.On("UploadFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image"), mock.AnythingOfType("*os.File"), mock.AnythingOfType("map[string]string")).Run(func(args mock.Arguments) {
 		arg1 := args[0].(chan<- error)
@@ -91,16 +239,58 @@ func (s *boardUsecaseSuite) SetupTest() {
 		arg2 := args[1].(*sync.WaitGroup)
 		arg2.Done()
 	})
-	s.boardRepo.On("Create", mock.AnythingOfType("*models.Board")).Return(nil)
-	s.boardRepo.On("GetBoardByID", mock.AnythingOfType("primitive.ObjectID")).Return(board1, nil)
-	s.boardRepo.On("Update", mock.AnythingOfType("*models.Board")).Return(nil)
-	s.userRepo.On("GetByID", mock.AnythingOfType("primitive.ObjectID")).Return(user1, nil)
-	s.boardMemberRepo.On("Create", mock.AnythingOfType("*models.BoardMember")).Return(nil)
+	s.boardRepo.On("Create", mock.AnythingOfType("*models.Board"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.boardRepo.On("GetBoardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardByID, getBoardByIDError)
+	s.boardRepo.On("Update", mock.AnythingOfType("*models.Board"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.userRepo.On("GetByID", mock.AnythingOfType("primitive.ObjectID")).Return(func(userID primitive.ObjectID) *models.User {
+		if userID == unverifiedUser.ID {
+			return unverifiedUser
+		}
+
+		return user1
+	}, nil)
+	s.boardMemberRepo.On("Create", mock.AnythingOfType("*models.BoardMember"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
 	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardMembers, nil)
-	s.boardMemberRepo.On("UpdateBoardMemberRole", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("models.MemberRole")).Return(nil)
-	s.boardMemberRepo.On("DeleteBoardMemberByID", mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.boardMemberRepo.On("UpdateBoardMemberRole", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("models.MemberRole"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.boardMemberRepo.On("DeleteBoardMemberByID", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("[]*models.Card"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.listRepo.On("GetBoardLists", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.List{list1, list2}, nil)
+	s.cardRepo.On("GetListCards", mock.AnythingOfType("primitive.ObjectID")).Return(getListCards, nil)
+	s.storage.On("AssignImageURLToUser", mock.AnythingOfType("*models.User")).Return(nil)
+	s.storage.On("AssignImageURLToBoardCover", mock.AnythingOfType("*models.BoardCover")).Return(nil)
+	s.userBoardsRepo.On("GetUserBoards", mock.AnythingOfType("primitive.ObjectID")).Return(&models.UserBoards{
+		UserID:   requesterID1,
+		BoardIDs: []primitive.ObjectID{board3.ID, deletedBoardID, board4.ID, board2.ID},
+	}, nil)
+	s.userBoardsRepo.On("AddBoard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.userBoardsRepo.On("RemoveBoard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.commentRepo.On("GetCardComments", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Comment{{ID: primitive.NewObjectID()}}, nil)
+	s.attachmentRepo.On("GetCardAttachments", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Attachment{{ID: primitive.NewObjectID(), File: &models.Image{ID: "file"}}}, nil)
+	s.labelRepo.On("GetBoardLabels", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Label{label1}, nil)
+	s.boardRepo.On("Delete", mock.AnythingOfType("*models.Board"), mock.AnythingOfType("[]*models.List"), mock.AnythingOfType("[]*models.Card"), mock.AnythingOfType("[]*models.Comment"), mock.AnythingOfType("[]*models.Attachment"), mock.AnythingOfType("[]*models.Label"), mock.AnythingOfType("[]*models.BoardMember"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.storage.On("DeleteFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image")).Run(func(args mock.Arguments) {
+		arg1 := args[0].(chan<- error)
+		arg1 <- nil
+		arg2 := args[1].(*sync.WaitGroup)
+		arg2.Done()
+	})
+	s.fileStorage.On("DeleteFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image")).Run(func(args mock.Arguments) {
+		arg1 := args[0].(chan<- error)
+		arg1 <- nil
+		arg2 := args[1].(*sync.WaitGroup)
+		arg2.Done()
+	})
+
+	s.notifications = nil
+	s.notificationRepo.On("Create", mock.AnythingOfType("[]*models.Notification")).Run(func(args mock.Arguments) {
+		s.notifications = append(s.notifications, args[0].([]*models.Notification)...)
+	}).Return(nil)
+
+	s.events = []*models.BoardEvent{}
+	s.eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Run(func(args mock.Arguments) {
+		s.events = append(s.events, args[0].(*models.BoardEvent))
+	}).Return()
 
-	s.usecase = usecase.NewBoardUsecase(s.boardRepo, s.unsplashRepo, s.boardMemberRepo, s.userRepo, s.storage)
+	s.usecase = usecase.NewBoardUsecase(s.boardRepo, s.unsplashRepo, s.boardMemberRepo, s.userRepo, s.listRepo, s.cardRepo, s.userBoardsRepo, s.commentRepo, s.attachmentRepo, s.labelRepo, s.notificationRepo, s.eventRepo, s.storage, s.fileStorage)
 }
 
 func (s *boardUsecaseSuite) AfterTest(suiteName, testName string) {
@@ -135,6 +325,88 @@ func (s *boardUsecaseSuite) TestCreateSuccessful() {
 
 	assert.NoError(s.T(), err)
 	s.boardRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	s.userBoardsRepo.AssertNumberOfCalls(s.T(), "AddBoard", 1)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbCreated, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetBoard, s.activities[0].Target)
+}
+
+func (s *boardUsecaseSuite) TestGetBoardByIDPrivateBoardAsNonMember() {
+	board, err := s.usecase.GetBoardByID(primitive.NewObjectID(), board2.ID, board.CardFilter{})
+
+	assert.Nil(s.T(), board)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "GetBoardLists", 0)
+}
+
+func (s *boardUsecaseSuite) TestGetBoardByIDSuccessful() {
+	board, err := s.usecase.GetBoardByID(primitive.NewObjectID(), board1.ID, board.CardFilter{})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), board.Labels, 1)
+	assert.Len(s.T(), board.Lists, 2)
+	assert.Equal(s.T(), list2.ID, board.Lists[0].ID)
+	assert.Equal(s.T(), list1.ID, board.Lists[1].ID)
+	assert.Len(s.T(), board.Lists[1].Cards, 2)
+	assert.Equal(s.T(), card2.ID, board.Lists[1].Cards[0].ID)
+	assert.Equal(s.T(), card1.ID, board.Lists[1].Cards[1].ID)
+	assert.Len(s.T(), board.Members, 2)
+	for _, member := range board.Members {
+		assert.NotNil(s.T(), member.User)
+	}
+
+	// the other members of the board only get to see the profile of each member
+	response, err := json.Marshal(board)
+	assert.NoError(s.T(), err)
+	assert.NotContains(s.T(), string(response), user1.Email)
+	assert.NotContains(s.T(), string(response), "email")
+}
+
+func (s *boardUsecaseSuite) TestGetUserBoardsInvalidSortOption() {
+	boards, _, err := s.usecase.GetUserBoards(requesterID1, "owner", "", 0, false)
+
+	assert.Nil(s.T(), boards)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrBoardInvalidSortOption.Error(), err.Error())
+}
+
+func (s *boardUsecaseSuite) TestGetUserBoardsInvalidCursor() {
+	boards, _, err := s.usecase.GetUserBoards(requesterID1, "", "not-a-cursor", 0, false)
+
+	assert.Nil(s.T(), boards)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrInvalidCursor.Error(), err.Error())
+}
+
+func (s *boardUsecaseSuite) TestGetUserBoardsSortByUpdatedAt() {
+	boards, nextCursor, err := s.usecase.GetUserBoards(requesterID1, "", "", 0, false)
+
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), nextCursor)
+	assert.Len(s.T(), boards, 2)
+	assert.Equal(s.T(), board4.ID, boards[0].BoardID)
+	assert.Equal(s.T(), models.MemberRole(models.MemberRoleMember), boards[0].Role)
+	assert.Nil(s.T(), boards[0].Thumbnail)
+	assert.Equal(s.T(), board3.ID, boards[1].BoardID)
+	assert.Equal(s.T(), models.MemberRole(models.MemberRoleAdmin), boards[1].Role)
+	assert.Equal(s.T(), uint(150), boards[1].Thumbnail.Width)
+}
+
+func (s *boardUsecaseSuite) TestGetUserBoardsPaginated() {
+	boards, nextCursor, err := s.usecase.GetUserBoards(requesterID1, "title", "", 1, false)
+
+	assert.NoError(s.T(), err)
+	assert.NotEmpty(s.T(), nextCursor)
+	assert.Len(s.T(), boards, 1)
+	assert.Equal(s.T(), board4.ID, boards[0].BoardID)
+
+	boards, nextCursor, err = s.usecase.GetUserBoards(requesterID1, "title", nextCursor, 1, false)
+
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), nextCursor)
+	assert.Len(s.T(), boards, 1)
+	assert.Equal(s.T(), board3.ID, boards[0].BoardID)
 }
 
 func (s *boardUsecaseSuite) TestUpdateBoardVisibilityInvalidVisibility() {
@@ -197,6 +469,11 @@ func (s *boardUsecaseSuite) TestUpdateBoardTitleSuccessful() {
 
 	assert.NoError(s.T(), err)
 	s.boardRepo.AssertNumberOfCalls(s.T(), "Update", 1)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), boardMember1.UserID, s.activities[0].ActorID)
+	assert.Equal(s.T(), board1.ID, s.activities[0].BoardID)
+	assert.Equal(s.T(), models.ActivityVerbUpdated, s.activities[0].Verb)
+	assert.Equal(s.T(), map[string]interface{}{"title": "updated title"}, s.activities[0].After)
 }
 
 func (s *boardUsecaseSuite) TestUpdateBoardDescriptionAsNonMember() {
@@ -230,11 +507,34 @@ func (s *boardUsecaseSuite) TestAddedMemberIsAlreadyMember() {
 	s.boardMemberRepo.AssertNumberOfCalls(s.T(), "Create", 0)
 }
 
+func (s *boardUsecaseSuite) TestAddMemberEmailNotVerified() {
+	err := s.usecase.AddMember(requesterID1, board1.ID, unverifiedUser.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrUserEmailNotVerified.Error(), err.Error())
+	s.boardMemberRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *boardUsecaseSuite) TestAddMemberEmailNotVerifiedByNonMember() {
+	err := s.usecase.AddMember(requesterID2, board1.ID, unverifiedUser.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.userRepo.AssertNumberOfCalls(s.T(), "GetByID", 0)
+	s.boardMemberRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
 func (s *boardUsecaseSuite) TestAddMemberSuccessful() {
 	err := s.usecase.AddMember(requesterID1, board1.ID, newMemberID2)
 
 	assert.NoError(s.T(), err)
 	s.boardMemberRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	s.userBoardsRepo.AssertCalled(s.T(), "AddBoard", newMemberID2, board1.ID)
+	assert.Len(s.T(), s.notifications, 1)
+	assert.Equal(s.T(), newMemberID2, s.notifications[0].UserID)
+	assert.Equal(s.T(), requesterID1, s.notifications[0].ActorID)
+	assert.Equal(s.T(), models.NotificationTypeBoardMemberAdded, s.notifications[0].Type)
+	assert.Equal(s.T(), board1.ID, s.notifications[0].BoardID)
 }
 
 func (s *boardUsecaseSuite) TestUpdateMemberRoleInvalidRole() {
@@ -290,6 +590,18 @@ func (s *boardUsecaseSuite) TestUpdateMemberRoleSuccessful() {
 
 	assert.NoError(s.T(), err)
 	s.boardMemberRepo.AssertNumberOfCalls(s.T(), "UpdateBoardMemberRole", 1)
+	assert.Len(s.T(), s.notifications, 1)
+	assert.Equal(s.T(), boardMember2.UserID, s.notifications[0].UserID)
+	assert.Equal(s.T(), models.NotificationTypeBoardRoleUpdated, s.notifications[0].Type)
+	assert.Equal(s.T(), models.MemberRole(models.MemberRoleAdmin), s.notifications[0].Role)
+}
+
+func (s *boardUsecaseSuite) TestUpdateMemberRoleUnchangedNotNotified() {
+	err := s.usecase.UpdateMemberRole(boardMember1.UserID, board1.ID, boardMember2.UserID, string(boardMember2.Role))
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.notifications, 0)
+	assert.Len(s.T(), s.activities, 0)
 }
 
 func (s *boardUsecaseSuite) TestDeleteMemberNoMembers() {
@@ -329,4 +641,213 @@ func (s *boardUsecaseSuite) TestDeleteMemberSuccessful() {
 
 	assert.NoError(s.T(), err)
 	s.boardMemberRepo.AssertNumberOfCalls(s.T(), "DeleteBoardMemberByID", 1)
+	s.userBoardsRepo.AssertCalled(s.T(), "RemoveBoard", boardMember2.UserID, board1.ID)
+	assert.Len(s.T(), s.notifications, 1)
+	assert.Equal(s.T(), boardMember2.UserID, s.notifications[0].UserID)
+	assert.Equal(s.T(), models.NotificationTypeBoardMemberRemoved, s.notifications[0].Type)
+	assert.Len(s.T(), s.events, 1)
+	assert.Equal(s.T(), models.BoardEventMemberRemoved, s.events[0].Type)
+	assert.Equal(s.T(), boardMember1.UserID, s.events[0].ActorID)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbRemoved, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetMember, s.activities[0].Target)
+	assert.Equal(s.T(), boardMember2.UserID, s.activities[0].TargetID)
+	assert.Equal(s.T(), boardMember2, s.activities[0].Before)
+}
+
+func (s *boardUsecaseSuite) TestDeleteMemberUnassignsFromCards() {
+	card1.AssigneeIDs = []primitive.ObjectID{boardMember1.UserID, boardMember2.UserID}
+	card3.AssigneeIDs = []primitive.ObjectID{boardMember2.UserID}
+	defer func() { card1.AssigneeIDs, card3.AssigneeIDs = nil, nil }()
+
+	err := s.usecase.DeleteMember(boardMember1.UserID, board1.ID, boardMember2.UserID)
+
+	assert.NoError(s.T(), err)
+	s.boardMemberRepo.AssertCalled(s.T(), "DeleteBoardMemberByID", boardMember2.ID, mock.AnythingOfType("[]*models.Card"), mock.AnythingOfType("*models.Activity"))
+
+	unassignedCards := s.boardMemberRepo.Calls[len(s.boardMemberRepo.Calls)-1].Arguments.Get(1).([]*models.Card)
+	assert.ElementsMatch(s.T(), []*models.Card{card1, card3}, unassignedCards)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember1.UserID}, card1.AssigneeIDs)
+	assert.Empty(s.T(), card3.AssigneeIDs)
+}
+
+func (s *boardUsecaseSuite) TestDeleteMemberUnwatchesCards() {
+	card2.WatcherIDs = []primitive.ObjectID{boardMember2.UserID, boardMember1.UserID}
+	defer func() { card2.WatcherIDs = nil }()
+
+	err := s.usecase.DeleteMember(boardMember1.UserID, board1.ID, boardMember2.UserID)
+
+	assert.NoError(s.T(), err)
+
+	unassignedCards := s.boardMemberRepo.Calls[len(s.boardMemberRepo.Calls)-1].Arguments.Get(1).([]*models.Card)
+	assert.Equal(s.T(), []*models.Card{card2}, unassignedCards)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember1.UserID}, card2.WatcherIDs)
+}
+
+func (s *boardUsecaseSuite) TestDeleteAsNonMember() {
+	err := s.usecase.Delete(primitive.NewObjectID(), board1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.boardRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *boardUsecaseSuite) TestDeleteAsMember() {
+	err := s.usecase.Delete(boardMember2.UserID, board1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.boardRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *boardUsecaseSuite) TestDeleteSuccessful() {
+	err := s.usecase.Delete(requesterID1, board3.ID)
+
+	assert.NoError(s.T(), err)
+	s.boardRepo.AssertNumberOfCalls(s.T(), "Delete", 1)
+	s.boardRepo.AssertCalled(s.T(), "Delete", board3, []*models.List{list1, list2}, []*models.Card{card1, card2, card3}, mock.AnythingOfType("[]*models.Comment"), mock.AnythingOfType("[]*models.Attachment"), []*models.Label{label1}, []*models.BoardMember{boardMember3}, mock.AnythingOfType("*models.Activity"))
+	// the board cover images go to imgur while the files of the card's attachments are documents
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", len(board3.Cover.Images))
+	s.fileStorage.AssertNumberOfCalls(s.T(), "DeleteFile", 3)
+	s.userBoardsRepo.AssertNotCalled(s.T(), "RemoveBoard", requesterID1, board3.ID)
+}
+
+func (s *boardUsecaseSuite) TestArchiveAsMember() {
+	err := s.usecase.Archive(boardMember2.UserID, board1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.boardRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *boardUsecaseSuite) TestArchiveSuccessful() {
+	err := s.usecase.Archive(requesterID1, board3.ID)
+
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), board3.Archived)
+	assert.NotNil(s.T(), board3.ArchivedAt)
+	s.boardRepo.AssertNumberOfCalls(s.T(), "Update", 1)
+
+	boards, _, err := s.usecase.GetUserBoards(requesterID1, "", "", 0, false)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), boards, 1)
+	assert.Equal(s.T(), board4.ID, boards[0].BoardID)
+
+	// the archived board is still listed among the archived boards of its members
+	boards, _, err = s.usecase.GetUserBoards(requesterID1, "", "", 0, true)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), boards, 1)
+	assert.Equal(s.T(), board3.ID, boards[0].BoardID)
+}
+
+func (s *boardUsecaseSuite) TestUnarchiveNotArchived() {
+	err := s.usecase.Unarchive(requesterID1, board3.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrBoardNotArchived.Error(), err.Error())
+	s.boardRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *boardUsecaseSuite) TestUnarchiveSuccessful() {
+	archivedAt := time.Now()
+	board3.Archived = true
+	board3.ArchivedAt = &archivedAt
+
+	err := s.usecase.Unarchive(requesterID1, board3.ID)
+
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), board3.Archived)
+	assert.Nil(s.T(), board3.ArchivedAt)
+	s.boardRepo.AssertNumberOfCalls(s.T(), "Update", 1)
+}
+
+func (s *boardUsecaseSuite) TestGetArchivedItemsPrivateBoardAsNonMember() {
+	archivedItems, err := s.usecase.GetArchivedItems(primitive.NewObjectID(), board2.ID)
+
+	assert.Nil(s.T(), archivedItems)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+}
+
+func (s *boardUsecaseSuite) TestGetArchivedItemsSuccessful() {
+	archivedItems, err := s.usecase.GetArchivedItems(requesterID1, board1.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), archivedItems.Lists, 0)
+	assert.Len(s.T(), archivedItems.Cards, 1)
+	assert.Equal(s.T(), card3.ID, archivedItems.Cards[0].ID)
+}
+
+func (s *boardUsecaseSuite) TestGetArchivedItemsSortedByArchiveTime() {
+	// card1 has been edited after it was archived, which must not move it before card3
+	card1.Archived = true
+	card1.ArchivedAt = &time.Time{}
+	card1.UpdatedAt = time.Now()
+	archivedAt := time.Now().Add(-time.Minute)
+	card3.ArchivedAt = &archivedAt
+
+	archivedItems, err := s.usecase.GetArchivedItems(requesterID1, board1.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), archivedItems.Cards, 2)
+	assert.Equal(s.T(), card3.ID, archivedItems.Cards[0].ID)
+	assert.Equal(s.T(), card1.ID, archivedItems.Cards[1].ID)
+}
+
+func (s *boardUsecaseSuite) TestGetBoardByIDFilterByLabel() {
+	_board, err := s.usecase.GetBoardByID(primitive.NewObjectID(), board1.ID, board.CardFilter{LabelIDs: []primitive.ObjectID{label1.ID}})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), _board.Lists, 2)
+	assert.Len(s.T(), _board.Lists[0].Cards, 0)
+	assert.Len(s.T(), _board.Lists[1].Cards, 1)
+	assert.Equal(s.T(), card2.ID, _board.Lists[1].Cards[0].ID)
+	assert.Equal(s.T(), 0, _board.Lists[1].Cards[0].Position)
+}
+
+func (s *boardUsecaseSuite) TestGetBoardByIDFilterKeepsPositions() {
+	label2 := primitive.NewObjectID()
+	card1.LabelIDs = []primitive.ObjectID{label2}
+	defer func() { card1.LabelIDs = nil }()
+
+	_board, err := s.usecase.GetBoardByID(primitive.NewObjectID(), board1.ID, board.CardFilter{LabelIDs: []primitive.ObjectID{label2}})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), _board.Lists[1].Cards, 1)
+	assert.Equal(s.T(), card1.ID, _board.Lists[1].Cards[0].ID)
+	assert.Equal(s.T(), 1, _board.Lists[1].Cards[0].Position)
+}
+
+func (s *boardUsecaseSuite) TestGetBoardByIDFilterByDueStatus() {
+	overdue := time.Now().Add(-time.Hour)
+	dueSoon := time.Now().Add(time.Hour)
+	card1.DueDate = &overdue
+	card2.DueDate = &dueSoon
+	defer func() { card1.DueDate, card2.DueDate = nil, nil }()
+
+	_board, err := s.usecase.GetBoardByID(primitive.NewObjectID(), board1.ID, board.CardFilter{DueStatuses: []string{board.DueStatusOverdue}})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), _board.Lists[1].Cards, 1)
+	assert.Equal(s.T(), card1.ID, _board.Lists[1].Cards[0].ID)
+
+	_board, err = s.usecase.GetBoardByID(primitive.NewObjectID(), board1.ID, board.CardFilter{DueStatuses: []string{board.DueStatusOverdue, board.DueStatusDueSoon}})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), _board.Lists[1].Cards, 2)
+}
+
+func (s *boardUsecaseSuite) TestGetBoardByIDFilterByDueStatusSkipsDoneCards() {
+	overdue := time.Now().Add(-time.Hour)
+	card1.DueDate = &overdue
+	card1.Done = true
+	defer func() { card1.DueDate, card1.Done = nil, false }()
+
+	_board, err := s.usecase.GetBoardByID(primitive.NewObjectID(), board1.ID, board.CardFilter{DueStatuses: []string{board.DueStatusOverdue}})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), _board.Lists[0].Cards, 0)
+	assert.Len(s.T(), _board.Lists[1].Cards, 0)
 }
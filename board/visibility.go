@@ -0,0 +1,34 @@
+package board
+
+import (
+	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// CheckIfRequesterCanSeeBoard fails with custom_errors.ErrNotAuthorized when the board is private
+// and the requester is not one of its members, public boards can be seen by everyone
+func CheckIfRequesterCanSeeBoard(boardRepo Repository, boardMemberRepo board_member.Repository, requesterID, boardID primitive.ObjectID) error {
+	_board, err := boardRepo.GetBoardByID(boardID)
+	if err != nil {
+		return err
+	}
+
+	if _board.Visibility != models.BoardVisibilityPrivate {
+		return nil
+	}
+
+	boardMembers, err := boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID {
+			return nil
+		}
+	}
+
+	return custom_errors.ErrNotAuthorized
+}
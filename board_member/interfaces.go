@@ -6,8 +6,8 @@ import (
 )
 
 type Repository interface {
-	Create(boardMember *models.BoardMember) error
+	Create(boardMember *models.BoardMember, activity *models.Activity) error
 	GetBoardMembers(boardID primitive.ObjectID) ([]*models.BoardMember, error)
-	UpdateBoardMemberRole(ID primitive.ObjectID, role models.MemberRole) error
-	DeleteBoardMemberByID(ID primitive.ObjectID) error
+	UpdateBoardMemberRole(ID primitive.ObjectID, role models.MemberRole, activity *models.Activity) error
+	DeleteBoardMemberByID(ID primitive.ObjectID, unassignedCards []*models.Card, activity *models.Activity) error
 }
@@ -14,13 +14,13 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: boardMember
-func (_m *Repository) Create(boardMember *models.BoardMember) error {
-	ret := _m.Called(boardMember)
+// Create provides a mock function with given fields: boardMember, activity
+func (_m *Repository) Create(boardMember *models.BoardMember, activity *models.Activity) error {
+	ret := _m.Called(boardMember, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.BoardMember) error); ok {
-		r0 = rf(boardMember)
+	if rf, ok := ret.Get(0).(func(*models.BoardMember, *models.Activity) error); ok {
+		r0 = rf(boardMember, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,13 +28,13 @@ func (_m *Repository) Create(boardMember *models.BoardMember) error {
 	return r0
 }
 
-// DeleteBoardMemberByID provides a mock function with given fields: ID
-func (_m *Repository) DeleteBoardMemberByID(ID primitive.ObjectID) error {
-	ret := _m.Called(ID)
+// DeleteBoardMemberByID provides a mock function with given fields: ID, unassignedCards, activity
+func (_m *Repository) DeleteBoardMemberByID(ID primitive.ObjectID, unassignedCards []*models.Card, activity *models.Activity) error {
+	ret := _m.Called(ID, unassignedCards, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
-		r0 = rf(ID)
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, []*models.Card, *models.Activity) error); ok {
+		r0 = rf(ID, unassignedCards, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -65,13 +65,13 @@ func (_m *Repository) GetBoardMembers(boardID primitive.ObjectID) ([]*models.Boa
 	return r0, r1
 }
 
-// UpdateBoardMemberRole provides a mock function with given fields: ID, role
-func (_m *Repository) UpdateBoardMemberRole(ID primitive.ObjectID, role models.MemberRole) error {
-	ret := _m.Called(ID, role)
+// UpdateBoardMemberRole provides a mock function with given fields: ID, role, activity
+func (_m *Repository) UpdateBoardMemberRole(ID primitive.ObjectID, role models.MemberRole, activity *models.Activity) error {
+	ret := _m.Called(ID, role, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, models.MemberRole) error); ok {
-		r0 = rf(ID, role)
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, models.MemberRole, *models.Activity) error); ok {
+		r0 = rf(ID, role, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
 	"github.com/jordyf15/thullo-api/board_member"
 	"github.com/jordyf15/thullo-api/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -18,13 +19,19 @@ func NewBoardMemberRepository(dbClient *db.Client) board_member.Repository {
 	return &boardMemberRepository{dbClient: dbClient}
 }
 
-func (repo *boardMemberRepository) Create(boardMember *models.BoardMember) error {
+func (repo *boardMemberRepository) Create(boardMember *models.BoardMember, _activity *models.Activity) error {
 	boardMember.ID = primitive.NewObjectID()
 
+	updates := map[string]interface{}{
+		fmt.Sprintf("board_members/%s", boardMember.ID.Hex()): boardMember,
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("board_members/%s", boardMember.ID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Set(ctx, boardMember)
+	return ref.Update(ctx, updates)
 }
 
 func (repo *boardMemberRepository) GetBoardMembers(boardID primitive.ObjectID) ([]*models.BoardMember, error) {
@@ -47,16 +54,35 @@ func (repo *boardMemberRepository) GetBoardMembers(boardID primitive.ObjectID) (
 	return boardMembers, nil
 }
 
-func (repo *boardMemberRepository) UpdateBoardMemberRole(ID primitive.ObjectID, role models.MemberRole) error {
+func (repo *boardMemberRepository) UpdateBoardMemberRole(ID primitive.ObjectID, role models.MemberRole, _activity *models.Activity) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("board_members/%s/role", ID.Hex()): role,
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("board_members/%s/role", ID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Set(ctx, role)
+	return ref.Update(ctx, updates)
 }
 
-func (repo *boardMemberRepository) DeleteBoardMemberByID(ID primitive.ObjectID) error {
+// DeleteBoardMemberByID removes the board member together with the member from the assignees and watchers of the cards
+// it was removed from, only the assignees and watchers of the cards are written so changes made to the rest are kept
+func (repo *boardMemberRepository) DeleteBoardMemberByID(ID primitive.ObjectID, unassignedCards []*models.Card, _activity *models.Activity) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("board_members/%s", ID.Hex()): nil,
+	}
+
+	for _, card := range unassignedCards {
+		updates[fmt.Sprintf("cards/%s/assignee_ids", card.ID.Hex())] = card.AssigneeIDs
+		updates[fmt.Sprintf("cards/%s/watcher_ids", card.ID.Hex())] = card.WatcherIDs
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("board_members/%s", ID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Delete(ctx)
+	return ref.Update(ctx, updates)
 }
@@ -1,16 +1,52 @@
 package card
 
 import (
+	"time"
+
 	"github.com/jordyf15/thullo-api/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// the keys of the dates given to UpdateDates, a key that is left out keeps
+// the current date and a key with a nil date clears it
+const (
+	StartDate = "start_date"
+	DueDate   = "due_date"
+)
+
 type Repository interface {
-	Create(card *models.Card) error
+	Create(card *models.Card, place func(cards []*models.Card) ([]*models.Card, error), activity *models.Activity) error
+	// CreateFromChecklistItem creates the card and writes the checklists that update returns, which no longer
+	// have the item the card is made of, in a single update
+	CreateFromChecklistItem(card *models.Card, place func(cards []*models.Card) ([]*models.Card, error), itemCardID primitive.ObjectID, update func(checklists []*models.Checklist) ([]*models.Checklist, error), cardActivity, checklistActivity *models.Activity) error
 	GetListCards(listID primitive.ObjectID) ([]*models.Card, error)
 	GetCardByID(cardID primitive.ObjectID) (*models.Card, error)
+	// UpdateCard only writes the given fields of the card, the rank and the list of a card are
+	// never written through it since they may only change under the position lock of the list
+	UpdateCard(cardID primitive.ObjectID, card *models.Card, fields []string, activity *models.Activity) error
+	// ReorderListCards writes the ranks of the cards that reorder returns under the position lock of the list
+	ReorderListCards(listID primitive.ObjectID, reorder func(cards []*models.Card) ([]*models.Card, error), activity *models.Activity) error
+	// MoveCard writes the ranks of the cards that move returns under the position locks of both lists,
+	// the moved card also has its list, its update time and movedFields written
+	MoveCard(sourceListID, targetListID primitive.ObjectID, move func(sourceCards, targetCards []*models.Card) ([]*models.Card, error), movedFields []string, activity *models.Activity) error
+	MoveCardsAndDeleteList(list *models.List, targetListID primitive.ObjectID, move func(cards, targetCards []*models.Card) ([]*models.Card, error), activity *models.Activity) error
+	MigratePositionsToRanks() error
 }
 
 type Usecase interface {
 	Create(requesterID, boardID, listID primitive.ObjectID, title string) error
+	UpdateTitle(requesterID, boardID, listID, cardID primitive.ObjectID, title string) error
+	UpdateDescription(requesterID, boardID, listID, cardID primitive.ObjectID, description string) error
+	UpdateCover(requesterID, boardID, listID, cardID primitive.ObjectID, cover map[string]interface{}) error
+	DeleteCover(requesterID, boardID, listID, cardID primitive.ObjectID) error
+	UpdateDates(requesterID, boardID, listID, cardID primitive.ObjectID, dates map[string]*time.Time) error
+	UpdateDone(requesterID, boardID, listID, cardID primitive.ObjectID, done bool) error
+	UpdatePosition(requesterID, boardID, listID, cardID primitive.ObjectID, newPosition int) error
+	Move(requesterID, boardID, listID, cardID, targetListID primitive.ObjectID, targetPosition int) error
+	Assign(requesterID, boardID, listID, cardID, assigneeID primitive.ObjectID) error
+	Unassign(requesterID, boardID, listID, cardID, assigneeID primitive.ObjectID) error
+	Watch(requesterID, boardID, listID, cardID primitive.ObjectID) error
+	Unwatch(requesterID, boardID, listID, cardID primitive.ObjectID) error
+	Archive(requesterID, boardID, listID, cardID primitive.ObjectID) error
+	Unarchive(requesterID, boardID, listID, cardID primitive.ObjectID) error
 }
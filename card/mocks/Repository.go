@@ -14,13 +14,27 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: _a0
-func (_m *Repository) Create(_a0 *models.Card) error {
-	ret := _m.Called(_a0)
+// Create provides a mock function with given fields: _a0, place, activity
+func (_m *Repository) Create(_a0 *models.Card, place func([]*models.Card) ([]*models.Card, error), activity *models.Activity) error {
+	ret := _m.Called(_a0, place, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Card) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*models.Card, func([]*models.Card) ([]*models.Card, error), *models.Activity) error); ok {
+		r0 = rf(_a0, place, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateFromChecklistItem provides a mock function with given fields: _a0, place, itemCardID, update, cardActivity, checklistActivity
+func (_m *Repository) CreateFromChecklistItem(_a0 *models.Card, place func([]*models.Card) ([]*models.Card, error), itemCardID primitive.ObjectID, update func([]*models.Checklist) ([]*models.Checklist, error), cardActivity *models.Activity, checklistActivity *models.Activity) error {
+	ret := _m.Called(_a0, place, itemCardID, update, cardActivity, checklistActivity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Card, func([]*models.Card) ([]*models.Card, error), primitive.ObjectID, func([]*models.Checklist) ([]*models.Checklist, error), *models.Activity, *models.Activity) error); ok {
+		r0 = rf(_a0, place, itemCardID, update, cardActivity, checklistActivity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -74,6 +88,76 @@ func (_m *Repository) GetListCards(listID primitive.ObjectID) ([]*models.Card, e
 	return r0, r1
 }
 
+// MigratePositionsToRanks provides a mock function with given fields:
+func (_m *Repository) MigratePositionsToRanks() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveCard provides a mock function with given fields: sourceListID, targetListID, move, movedFields, activity
+func (_m *Repository) MoveCard(sourceListID primitive.ObjectID, targetListID primitive.ObjectID, move func([]*models.Card, []*models.Card) ([]*models.Card, error), movedFields []string, activity *models.Activity) error {
+	ret := _m.Called(sourceListID, targetListID, move, movedFields, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, func([]*models.Card, []*models.Card) ([]*models.Card, error), []string, *models.Activity) error); ok {
+		r0 = rf(sourceListID, targetListID, move, movedFields, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MoveCardsAndDeleteList provides a mock function with given fields: list, targetListID, move, activity
+func (_m *Repository) MoveCardsAndDeleteList(list *models.List, targetListID primitive.ObjectID, move func([]*models.Card, []*models.Card) ([]*models.Card, error), activity *models.Activity) error {
+	ret := _m.Called(list, targetListID, move, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.List, primitive.ObjectID, func([]*models.Card, []*models.Card) ([]*models.Card, error), *models.Activity) error); ok {
+		r0 = rf(list, targetListID, move, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderListCards provides a mock function with given fields: listID, reorder, activity
+func (_m *Repository) ReorderListCards(listID primitive.ObjectID, reorder func([]*models.Card) ([]*models.Card, error), activity *models.Activity) error {
+	ret := _m.Called(listID, reorder, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, func([]*models.Card) ([]*models.Card, error), *models.Activity) error); ok {
+		r0 = rf(listID, reorder, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCard provides a mock function with given fields: cardID, _a1, fields, activity
+func (_m *Repository) UpdateCard(cardID primitive.ObjectID, _a1 *models.Card, fields []string, activity *models.Activity) error {
+	ret := _m.Called(cardID, _a1, fields, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *models.Card, []string, *models.Activity) error); ok {
+		r0 = rf(cardID, _a1, fields, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewRepository interface {
 	mock.TestingT
 	Cleanup(func())
@@ -5,6 +5,8 @@ package mocks
 import (
 	mock "github.com/stretchr/testify/mock"
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
 )
 
 // Usecase is an autogenerated mock type for the Usecase type
@@ -12,6 +14,34 @@ type Usecase struct {
 	mock.Mock
 }
 
+// Archive provides a mock function with given fields: requesterID, boardID, listID, cardID
+func (_m *Usecase) Archive(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Assign provides a mock function with given fields: requesterID, boardID, listID, cardID, assigneeID
+func (_m *Usecase) Assign(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, assigneeID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, assigneeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, assigneeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: requesterID, boardID, listID, title
 func (_m *Usecase) Create(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, title string) error {
 	ret := _m.Called(requesterID, boardID, listID, title)
@@ -26,6 +56,174 @@ func (_m *Usecase) Create(requesterID primitive.ObjectID, boardID primitive.Obje
 	return r0
 }
 
+// DeleteCover provides a mock function with given fields: requesterID, boardID, listID, cardID
+func (_m *Usecase) DeleteCover(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Move provides a mock function with given fields: requesterID, boardID, listID, cardID, targetListID, targetPosition
+func (_m *Usecase) Move(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, targetListID primitive.ObjectID, targetPosition int) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, targetListID, targetPosition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, int) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, targetListID, targetPosition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unarchive provides a mock function with given fields: requesterID, boardID, listID, cardID
+func (_m *Usecase) Unarchive(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unassign provides a mock function with given fields: requesterID, boardID, listID, cardID, assigneeID
+func (_m *Usecase) Unassign(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, assigneeID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, assigneeID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, assigneeID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unwatch provides a mock function with given fields: requesterID, boardID, listID, cardID
+func (_m *Usecase) Unwatch(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateCover provides a mock function with given fields: requesterID, boardID, listID, cardID, cover
+func (_m *Usecase) UpdateCover(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, cover map[string]interface{}) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, cover)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, map[string]interface{}) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, cover)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDates provides a mock function with given fields: requesterID, boardID, listID, cardID, dates
+func (_m *Usecase) UpdateDates(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, dates map[string]*time.Time) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, dates)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, map[string]*time.Time) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, dates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDescription provides a mock function with given fields: requesterID, boardID, listID, cardID, description
+func (_m *Usecase) UpdateDescription(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, description string) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, description)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, description)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDone provides a mock function with given fields: requesterID, boardID, listID, cardID, done
+func (_m *Usecase) UpdateDone(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, done bool) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, done)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, bool) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, done)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdatePosition provides a mock function with given fields: requesterID, boardID, listID, cardID, newPosition
+func (_m *Usecase) UpdatePosition(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, newPosition int) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, newPosition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, int) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, newPosition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTitle provides a mock function with given fields: requesterID, boardID, listID, cardID, title
+func (_m *Usecase) UpdateTitle(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, title string) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, title)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, title)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Watch provides a mock function with given fields: requesterID, boardID, listID, cardID
+func (_m *Usecase) Watch(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewUsecase interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,69 @@
+package card
+
+import (
+	"sort"
+
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+)
+
+// Place gives placedCard a rank that puts it at position among the active cards,
+// cards must be sorted by rank and must not contain placedCard. Only placedCard has to be
+// written unless the ranks of the list had to be rebalanced, then every card is returned.
+func Place(cards []*models.Card, placedCard *models.Card, position int) []*models.Card {
+	// the card goes right after the active card that currently comes before the position
+	index := 0
+	activeCardCount := 0
+	for i := 0; i < len(cards) && activeCardCount < position; i++ {
+		if !cards[i].Archived {
+			activeCardCount++
+			index = i + 1
+		}
+	}
+
+	prevRank, nextRank := "", ""
+	if index > 0 {
+		prevRank = cards[index-1].Rank
+	}
+
+	if index < len(cards) {
+		nextRank = cards[index].Rank
+	}
+
+	newRank, err := rank.Between(prevRank, nextRank)
+	if err == nil && !rank.NeedsRebalance(newRank) {
+		placedCard.Rank = newRank
+		return []*models.Card{placedCard}
+	}
+
+	// there is no room left between the neighbours or their ranks are broken
+	orderedCards := append([]*models.Card{}, cards[:index]...)
+	orderedCards = append(orderedCards, placedCard)
+	orderedCards = append(orderedCards, cards[index:]...)
+
+	for i, newRank := range rank.Spread(len(orderedCards)) {
+		orderedCards[i].Rank = newRank
+	}
+
+	return orderedCards
+}
+
+// Append places newCard after the last active card of the list, see Place
+func Append(cards []*models.Card, newCard *models.Card) []*models.Card {
+	activeCardCount := 0
+	for _, card := range cards {
+		if !card.Archived {
+			activeCardCount++
+		}
+	}
+
+	SortByRank(cards)
+
+	return Place(cards, newCard, activeCardCount)
+}
+
+func SortByRank(cards []*models.Card) {
+	sort.SliceStable(cards, func(i, j int) bool {
+		return cards[i].Rank < cards[j].Rank
+	})
+}
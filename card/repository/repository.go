@@ -3,12 +3,17 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
 	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/checklist"
 	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -20,15 +25,80 @@ func NewCardRepository(dbClient *db.Client) card.Repository {
 	return &cardRepository{dbClient: dbClient}
 }
 
-func (repo *cardRepository) Create(card *models.Card) error {
+// Create stores the card under the position lock of its list, place receives the cards of the list
+// and returns the new card together with the cards whose ranks had to be rebalanced to make room for it
+func (repo *cardRepository) Create(card *models.Card, place func(cards []*models.Card) ([]*models.Card, error), _activity *models.Activity) error {
 	card.ID = primitive.NewObjectID()
 	card.CreatedAt = time.Now()
 	card.UpdatedAt = card.CreatedAt
 
+	if _activity != nil {
+		_activity.CardID, _activity.TargetID = &card.ID, card.ID
+	}
+
+	return repo.reorderListCards(card.ListID, place, card, map[string]interface{}{}, _activity)
+}
+
+// CreateFromChecklistItem stores the card the way Create does and, in the same update, writes the checklists that
+// update returns under the checklist lock of the card the item came from, so the item is never left on its checklist
+// when the card is created or lost when it is not
+func (repo *cardRepository) CreateFromChecklistItem(card *models.Card, place func(cards []*models.Card) ([]*models.Card, error), itemCardID primitive.ObjectID, update func(checklists []*models.Checklist) ([]*models.Checklist, error), cardActivity, checklistActivity *models.Activity) error {
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("cards/%s", card.ID.Hex()))
+	lockPath := checklist.LockPath(itemCardID)
+	lockRef := repo.dbClient.NewRef(lockPath)
+
+	lease, err := utils.AcquireLock(ctx, lockRef)
+	if err != nil {
+		return err
+	}
+
+	isReleased := false
+	defer func() {
+		if !isReleased {
+			utils.ReleaseLock(ctx, lockRef, lease)
+		}
+	}()
+
+	checklistsMap := make(map[string]*models.Checklist)
+	err = repo.dbClient.NewRef(fmt.Sprintf("checklists/%s", itemCardID.Hex())).Get(ctx, &checklistsMap)
+	if err != nil {
+		return err
+	}
+
+	checklists := []*models.Checklist{}
+	for _, _checklist := range checklistsMap {
+		checklists = append(checklists, _checklist)
+	}
+
+	updatedChecklists, err := update(checklists)
+	if err != nil {
+		return err
+	}
 
-	return ref.Set(ctx, card)
+	// the checklist lock is released by the same update that releases the position lock
+	updates := map[string]interface{}{
+		lockPath: nil,
+	}
+
+	checklist.AddToUpdates(updates, itemCardID, checklists, updatedChecklists)
+	activity.AddToUpdates(updates, checklistActivity)
+
+	card.ID = primitive.NewObjectID()
+	card.CreatedAt = time.Now()
+	card.UpdatedAt = card.CreatedAt
+
+	if cardActivity != nil {
+		cardActivity.CardID, cardActivity.TargetID = &card.ID, card.ID
+	}
+
+	err = repo.reorderListCards(card.ListID, place, card, updates, cardActivity)
+	if err != nil {
+		return err
+	}
+
+	isReleased = true
+
+	return nil
 }
 
 func (repo *cardRepository) GetListCards(listID primitive.ObjectID) ([]*models.Card, error) {
@@ -68,3 +138,233 @@ func (repo *cardRepository) GetCardByID(cardID primitive.ObjectID) (*models.Card
 
 	return card, nil
 }
+
+func (repo *cardRepository) UpdateCard(cardID primitive.ObjectID, card *models.Card, fields []string, _activity *models.Activity) error {
+	updates := map[string]interface{}{}
+
+	err := utils.AddFieldsToUpdates(updates, fmt.Sprintf("cards/%s", cardID.Hex()), card, fields...)
+	if err != nil {
+		return err
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *cardRepository) ReorderListCards(listID primitive.ObjectID, reorder func(cards []*models.Card) ([]*models.Card, error), _activity *models.Activity) error {
+	return repo.reorderListCards(listID, reorder, nil, map[string]interface{}{}, _activity)
+}
+
+// reorderListCards writes the ranks of the cards returned by reorder under the position lock of the list together
+// with the given updates, createdCard is written as a whole when it is given
+func (repo *cardRepository) reorderListCards(listID primitive.ObjectID, reorder func(cards []*models.Card) ([]*models.Card, error), createdCard *models.Card, updates map[string]interface{}, _activity *models.Activity) error {
+	ctx := context.Background()
+	lockPath := fmt.Sprintf("card_position_locks/%s", listID.Hex())
+	lockRef := repo.dbClient.NewRef(lockPath)
+
+	lease, err := utils.AcquireLock(ctx, lockRef)
+	if err != nil {
+		return err
+	}
+
+	isReleased := false
+	defer func() {
+		if !isReleased {
+			utils.ReleaseLock(ctx, lockRef, lease)
+		}
+	}()
+
+	cards, err := repo.GetListCards(listID)
+	if err != nil {
+		return err
+	}
+
+	updatedCards, err := reorder(cards)
+	if err != nil {
+		return err
+	}
+
+	// the new positions are written together with the release of the lock
+	updates[lockPath] = nil
+
+	for _, card := range updatedCards {
+		if createdCard != nil && card.ID == createdCard.ID {
+			updates[fmt.Sprintf("cards/%s", card.ID.Hex())] = card
+			continue
+		}
+
+		updates[fmt.Sprintf("cards/%s/rank", card.ID.Hex())] = card.Rank
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ref := repo.dbClient.NewRef("")
+
+	err = ref.Update(ctx, updates)
+	if err != nil {
+		return err
+	}
+
+	isReleased = true
+
+	return nil
+}
+
+func (repo *cardRepository) MoveCard(sourceListID, targetListID primitive.ObjectID, move func(sourceCards, targetCards []*models.Card) ([]*models.Card, error), movedFields []string, _activity *models.Activity) error {
+	return repo.moveCards(sourceListID, targetListID, move, movedFields, map[string]interface{}{}, _activity)
+}
+
+// MoveCardsAndDeleteList moves the cards of the list to the target list and deletes the list in the same update
+func (repo *cardRepository) MoveCardsAndDeleteList(list *models.List, targetListID primitive.ObjectID, move func(cards, targetCards []*models.Card) ([]*models.Card, error), _activity *models.Activity) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("lists/%s", list.ID.Hex()): nil,
+	}
+
+	return repo.moveCards(list.ID, targetListID, move, nil, updates, _activity)
+}
+
+// moveCards writes the cards returned by move under the position locks of both lists, together with the given updates.
+// Only the ranks are written, the cards that came from the source list also have their list and movedFields written.
+func (repo *cardRepository) moveCards(sourceListID, targetListID primitive.ObjectID, move func(sourceCards, targetCards []*models.Card) ([]*models.Card, error), movedFields []string, updates map[string]interface{}, _activity *models.Activity) error {
+	ctx := context.Background()
+
+	// the locks are always taken in the same order so two opposite moves can not block each other
+	lockPaths := []string{
+		fmt.Sprintf("card_position_locks/%s", sourceListID.Hex()),
+		fmt.Sprintf("card_position_locks/%s", targetListID.Hex()),
+	}
+	if sourceListID.Hex() > targetListID.Hex() {
+		lockPaths[0], lockPaths[1] = lockPaths[1], lockPaths[0]
+	}
+
+	// every lock that has been acquired is released unless the update below released them
+	leases := []int64{}
+	isReleased := false
+	defer func() {
+		if !isReleased {
+			for i, lease := range leases {
+				utils.ReleaseLock(ctx, repo.dbClient.NewRef(lockPaths[i]), lease)
+			}
+		}
+	}()
+
+	for _, lockPath := range lockPaths {
+		lease, err := utils.AcquireLock(ctx, repo.dbClient.NewRef(lockPath))
+		if err != nil {
+			return err
+		}
+
+		leases = append(leases, lease)
+	}
+
+	sourceCards, err := repo.GetListCards(sourceListID)
+	if err != nil {
+		return err
+	}
+
+	targetCards, err := repo.GetListCards(targetListID)
+	if err != nil {
+		return err
+	}
+
+	isSourceCard := make(map[primitive.ObjectID]bool)
+	for _, card := range sourceCards {
+		isSourceCard[card.ID] = true
+	}
+
+	updatedCards, err := move(sourceCards, targetCards)
+	if err != nil {
+		return err
+	}
+
+	// the moved cards, the shifted cards and the release of both locks are written at once
+	for _, lockPath := range lockPaths {
+		updates[lockPath] = nil
+	}
+
+	for _, card := range updatedCards {
+		cardPath := fmt.Sprintf("cards/%s", card.ID.Hex())
+		updates[fmt.Sprintf("%s/rank", cardPath)] = card.Rank
+
+		if isSourceCard[card.ID] && card.ListID == targetListID {
+			err = utils.AddFieldsToUpdates(updates, cardPath, card, append([]string{"list_id", "updated_at"}, movedFields...)...)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ref := repo.dbClient.NewRef("")
+
+	err = ref.Update(ctx, updates)
+	if err != nil {
+		return err
+	}
+
+	isReleased = true
+
+	return nil
+}
+
+// MigratePositionsToRanks gives every card that was stored before ranks were introduced a rank
+// that keeps the order of its position, lists whose cards all have a rank are left untouched
+func (repo *cardRepository) MigratePositionsToRanks() error {
+	ctx := context.Background()
+	cardsMap := make(map[string]*models.Card)
+
+	err := repo.dbClient.NewRef("cards").Get(ctx, &cardsMap)
+	if err != nil {
+		return err
+	}
+
+	listCards := make(map[primitive.ObjectID][]*models.Card)
+	for _, card := range cardsMap {
+		listCards[card.ListID] = append(listCards[card.ListID], card)
+	}
+
+	updates := map[string]interface{}{}
+	for _, cards := range listCards {
+		if !hasCardWithoutRank(cards) {
+			continue
+		}
+
+		// archived cards kept their old position, so the active card wins a tie
+		sort.SliceStable(cards, func(i, j int) bool {
+			if cards[i].Position != cards[j].Position {
+				return cards[i].Position < cards[j].Position
+			}
+
+			if cards[i].Archived != cards[j].Archived {
+				return !cards[i].Archived
+			}
+
+			return cards[i].CreatedAt.Before(cards[j].CreatedAt)
+		})
+
+		for i, newRank := range rank.Spread(len(cards)) {
+			updates[fmt.Sprintf("cards/%s/rank", cards[i].ID.Hex())] = newRank
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return repo.dbClient.NewRef("").Update(ctx, updates)
+}
+
+func hasCardWithoutRank(cards []*models.Card) bool {
+	for _, card := range cards {
+		if card.Rank == "" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,251 @@
+package repository_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/card/repository"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCardRepository(t *testing.T) {
+	suite.Run(t, new(cardRepositorySuite))
+}
+
+type cardRepositorySuite struct {
+	suite.Suite
+	server     *rtdbtest.Server
+	repository card.Repository
+
+	sourceListID primitive.ObjectID
+	targetListID primitive.ObjectID
+	cards        []*models.Card
+}
+
+func (s *cardRepositorySuite) SetupTest() {
+	s.server = rtdbtest.NewServer()
+
+	dbClient, err := s.server.NewDatabaseClient()
+	s.Require().NoError(err)
+
+	s.repository = repository.NewCardRepository(dbClient)
+
+	s.sourceListID, s.targetListID = primitive.NewObjectID(), primitive.NewObjectID()
+	s.cards = []*models.Card{}
+	for _, cardRank := range rank.Spread(3) {
+		card := &models.Card{ID: primitive.NewObjectID(), ListID: s.sourceListID, Rank: cardRank}
+		s.Require().NoError(s.server.Set(fmt.Sprintf("cards/%s", card.ID.Hex()), card))
+		s.cards = append(s.cards, card)
+	}
+}
+
+func (s *cardRepositorySuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *cardRepositorySuite) assertUnlocked(listIDs ...primitive.ObjectID) {
+	for _, listID := range listIDs {
+		var lease int64
+		s.server.Get(fmt.Sprintf("card_position_locks/%s", listID.Hex()), &lease)
+		assert.Zero(s.T(), lease)
+	}
+}
+
+func (s *cardRepositorySuite) TestReorderListCardsReleasesLockWhenReorderFails() {
+	reorderErr := errors.New("reorder failed")
+
+	err := s.repository.ReorderListCards(s.sourceListID, func(cards []*models.Card) ([]*models.Card, error) {
+		return nil, reorderErr
+	}, nil)
+
+	assert.Equal(s.T(), reorderErr, err)
+	s.assertUnlocked(s.sourceListID)
+}
+
+func (s *cardRepositorySuite) TestReorderListCardsReleasesLockWhenUpdateFails() {
+	s.server.FailRequests("PATCH", true)
+
+	err := s.repository.ReorderListCards(s.sourceListID, func(cards []*models.Card) ([]*models.Card, error) {
+		return cards, nil
+	}, nil)
+
+	assert.Error(s.T(), err)
+	s.assertUnlocked(s.sourceListID)
+}
+
+func (s *cardRepositorySuite) TestMoveCardReleasesFirstLockWhenSecondIsHeld() {
+	err := s.repository.ReorderListCards(s.targetListID, func(cards []*models.Card) ([]*models.Card, error) {
+		return nil, s.repository.MoveCard(s.sourceListID, s.targetListID, func(sourceCards, targetCards []*models.Card) ([]*models.Card, error) {
+			return sourceCards, nil
+		}, nil, nil)
+	}, nil)
+
+	assert.Equal(s.T(), custom_errors.ErrConflictingUpdate, err)
+	s.assertUnlocked(s.sourceListID, s.targetListID)
+}
+
+func (s *cardRepositorySuite) TestMoveCardReleasesLocksWhenUpdateFails() {
+	s.server.FailRequests("PATCH", true)
+
+	err := s.repository.MoveCard(s.sourceListID, s.targetListID, func(sourceCards, targetCards []*models.Card) ([]*models.Card, error) {
+		return sourceCards, nil
+	}, nil, nil)
+
+	assert.Error(s.T(), err)
+	s.assertUnlocked(s.sourceListID, s.targetListID)
+}
+
+func (s *cardRepositorySuite) TestMoveCardSuccessful() {
+	movedCard := s.cards[1]
+
+	err := s.repository.MoveCard(s.sourceListID, s.targetListID, func(sourceCards, targetCards []*models.Card) ([]*models.Card, error) {
+		assert.Len(s.T(), sourceCards, 3)
+		assert.Len(s.T(), targetCards, 0)
+
+		for _, card := range sourceCards {
+			if card.ID == movedCard.ID {
+				card.ListID = s.targetListID
+				return []*models.Card{card}, nil
+			}
+		}
+
+		return nil, custom_errors.ErrRecordNotFound
+	}, nil, nil)
+
+	assert.NoError(s.T(), err)
+
+	targetCards, err := s.repository.GetListCards(s.targetListID)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), targetCards, 1)
+	assert.Equal(s.T(), movedCard.ID, targetCards[0].ID)
+	s.assertUnlocked(s.sourceListID, s.targetListID)
+}
+
+func (s *cardRepositorySuite) TestUpdateCardKeepsChecklistProgress() {
+	progress := &models.ChecklistProgress{Done: 1, Total: 2}
+	s.Require().NoError(s.server.Set(fmt.Sprintf("cards/%s/checklist_progress", s.cards[0].ID.Hex()), progress))
+
+	// the card was read before its checklists changed
+	s.cards[0].Title = "new title"
+	err := s.repository.UpdateCard(s.cards[0].ID, s.cards[0], []string{"title"}, nil)
+
+	assert.NoError(s.T(), err)
+
+	updatedCard, err := s.repository.GetCardByID(s.cards[0].ID)
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "new title", updatedCard.Title)
+	assert.Equal(s.T(), progress, updatedCard.ChecklistProgress)
+}
+
+func (s *cardRepositorySuite) TestUpdateCardKeepsPosition() {
+	staleCard := *s.cards[0]
+
+	err := s.repository.MoveCard(s.sourceListID, s.targetListID, func(sourceCards, targetCards []*models.Card) ([]*models.Card, error) {
+		for _, card := range sourceCards {
+			if card.ID == staleCard.ID {
+				card.ListID = s.targetListID
+				card.Rank = "m"
+				return []*models.Card{card}, nil
+			}
+		}
+
+		return nil, custom_errors.ErrRecordNotFound
+	}, nil, nil)
+	s.Require().NoError(err)
+
+	// the title is changed on the card as it was read before the move
+	staleCard.Title = "new title"
+	err = s.repository.UpdateCard(staleCard.ID, &staleCard, []string{"title", "updated_at"}, nil)
+
+	assert.NoError(s.T(), err)
+
+	updatedCard, err := s.repository.GetCardByID(staleCard.ID)
+	s.Require().NoError(err)
+	assert.Equal(s.T(), "new title", updatedCard.Title)
+	assert.Equal(s.T(), s.targetListID, updatedCard.ListID)
+	assert.Equal(s.T(), "m", updatedCard.Rank)
+}
+
+func (s *cardRepositorySuite) TestMoveCardsAndDeleteList() {
+	deletedList := &models.List{ID: s.sourceListID}
+	s.Require().NoError(s.server.Set(fmt.Sprintf("lists/%s", deletedList.ID.Hex()), deletedList))
+
+	err := s.repository.MoveCardsAndDeleteList(deletedList, s.targetListID, func(cards, targetCards []*models.Card) ([]*models.Card, error) {
+		assert.Len(s.T(), cards, 3)
+
+		for _, card := range cards {
+			card.ListID = s.targetListID
+		}
+
+		return cards, nil
+	}, nil)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, s.server.Requests("PATCH", "/"))
+
+	targetCards, err := s.repository.GetListCards(s.targetListID)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), targetCards, 3)
+
+	var storedList *models.List
+	s.server.Get(fmt.Sprintf("lists/%s", deletedList.ID.Hex()), &storedList)
+	assert.Nil(s.T(), storedList)
+	s.assertUnlocked(s.sourceListID, s.targetListID)
+}
+
+func (s *cardRepositorySuite) TestCreateFromChecklistItem() {
+	itemCard := s.cards[0]
+	item := &models.ChecklistItem{ID: primitive.NewObjectID(), Title: "item"}
+	_checklist := &models.Checklist{ID: primitive.NewObjectID(), CardID: itemCard.ID, Items: []*models.ChecklistItem{item}}
+	s.Require().NoError(s.server.Set(fmt.Sprintf("checklists/%s/%s", itemCard.ID.Hex(), _checklist.ID.Hex()), _checklist))
+
+	newCard := &models.Card{ListID: s.sourceListID, Title: item.Title}
+	err := s.repository.CreateFromChecklistItem(newCard, func(cards []*models.Card) ([]*models.Card, error) {
+		return card.Append(cards, newCard), nil
+	}, itemCard.ID, func(checklists []*models.Checklist) ([]*models.Checklist, error) {
+		checklists[0].Items = []*models.ChecklistItem{}
+		return checklists, nil
+	}, nil, nil)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, s.server.Requests("PATCH", "/"))
+
+	createdCard, err := s.repository.GetCardByID(newCard.ID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "item", createdCard.Title)
+
+	var storedChecklist *models.Checklist
+	s.server.Get(fmt.Sprintf("checklists/%s/%s", itemCard.ID.Hex(), _checklist.ID.Hex()), &storedChecklist)
+	assert.Empty(s.T(), storedChecklist.Items)
+
+	var lease int64
+	s.server.Get(fmt.Sprintf("checklist_locks/%s", itemCard.ID.Hex()), &lease)
+	assert.Zero(s.T(), lease)
+	s.assertUnlocked(s.sourceListID)
+}
+
+func (s *cardRepositorySuite) TestCreateFromChecklistItemReleasesLocksWhenUpdateFails() {
+	s.server.FailRequests("PATCH", true)
+
+	newCard := &models.Card{ListID: s.sourceListID}
+	err := s.repository.CreateFromChecklistItem(newCard, func(cards []*models.Card) ([]*models.Card, error) {
+		return card.Append(cards, newCard), nil
+	}, s.cards[0].ID, func(checklists []*models.Checklist) ([]*models.Checklist, error) {
+		return checklists, nil
+	}, nil, nil)
+
+	assert.Error(s.T(), err)
+
+	var lease int64
+	s.server.Get(fmt.Sprintf("checklist_locks/%s", s.cards[0].ID.Hex()), &lease)
+	assert.Zero(s.T(), lease)
+	s.assertUnlocked(s.sourceListID)
+}
@@ -0,0 +1,326 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	"github.com/jordyf15/thullo-api/card/repository"
+	"github.com/jordyf15/thullo-api/card/usecase"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	lr "github.com/jordyf15/thullo-api/list/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/utils"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestConcurrentCardReordersAndMoves runs the reorders and moves against the realtime database repository
+// so they contend for the same position locks the way concurrent requests do
+func TestConcurrentCardReordersAndMoves(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	boardID := primitive.NewObjectID()
+	requesterID := primitive.NewObjectID()
+	lists := []*models.List{
+		{ID: primitive.NewObjectID(), BoardID: boardID, Rank: "i"},
+		{ID: primitive.NewObjectID(), BoardID: boardID, Rank: "r"},
+	}
+
+	getListByID := func(listID primitive.ObjectID) *models.List {
+		for _, list := range lists {
+			if list.ID == listID {
+				return list
+			}
+		}
+
+		return &models.List{ID: listID}
+	}
+
+	listRepo := new(lr.Repository)
+	listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(getListByID, nil)
+	boardMemberRepo := new(bmr.Repository)
+	boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.BoardMember{{UserID: requesterID, BoardID: boardID}}, nil)
+
+	cardRepo := repository.NewCardRepository(dbClient)
+	cardIDs := []primitive.ObjectID{}
+	for _, list := range lists {
+		for _, cardRank := range rank.Spread(4) {
+			card := &models.Card{ID: primitive.NewObjectID(), ListID: list.ID, Rank: cardRank}
+			server.Set(fmt.Sprintf("cards/%s", card.ID.Hex()), card)
+			cardIDs = append(cardIDs, card.ID)
+		}
+	}
+
+	eventRepo := new(er.Repository)
+	eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Return()
+
+	cardUsecase := usecase.NewCardUsecase(listRepo, cardRepo, boardMemberRepo, nil, nil, nil, eventRepo, nil)
+
+	// the first list starts out locked by another request, so the first reorders and moves have to wait for it
+	lockRef := dbClient.NewRef(fmt.Sprintf("card_position_locks/%s", lists[0].ID.Hex()))
+	lease, err := utils.AcquireLock(context.Background(), lockRef)
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	time.AfterFunc(30*time.Millisecond, func() {
+		utils.ReleaseLock(context.Background(), lockRef, lease)
+	})
+
+	workerCount, operationsPerWorker := 4, 5
+	var wg sync.WaitGroup
+	errs := make(chan error, workerCount*operationsPerWorker)
+
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+
+			random := rand.New(rand.NewSource(seed))
+			for j := 0; j < operationsPerWorker; j++ {
+				card, err := cardRepo.GetCardByID(cardIDs[random.Intn(len(cardIDs))])
+				if err != nil {
+					errs <- err
+					continue
+				}
+
+				targetList := lists[random.Intn(len(lists))]
+				errs <- cardUsecase.Move(requesterID, boardID, card.ListID, card.ID, targetList.ID, random.Intn(4))
+			}
+		}(int64(i))
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		// the card could have been moved to another list or the target list
+		// could have become too short since the card was picked, but every
+		// call that ran into a lock has been retried until it got through
+		switch err {
+		case nil, custom_errors.ErrRecordNotFound, custom_errors.ErrCardPositionTooHigh:
+		default:
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+
+	total := 0
+	for _, list := range lists {
+		cards, err := cardRepo.GetListCards(list.ID)
+		assert.NoError(t, err)
+		total += len(cards)
+
+		// the positions are derived from the ranks, so distinct ranks mean they form a permutation
+		ranks := map[string]bool{}
+		for _, card := range cards {
+			ranks[card.Rank] = true
+		}
+
+		assert.Len(t, ranks, len(cards))
+	}
+
+	assert.Equal(t, len(cardIDs), total)
+}
+
+func TestConcurrentCardCreates(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	boardID := primitive.NewObjectID()
+	requesterID := primitive.NewObjectID()
+	list := &models.List{ID: primitive.NewObjectID(), BoardID: boardID, Rank: "i"}
+
+	listRepo := new(lr.Repository)
+	listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(list, nil)
+	boardMemberRepo := new(bmr.Repository)
+	boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.BoardMember{{UserID: requesterID, BoardID: boardID}}, nil)
+
+	eventRepo := new(er.Repository)
+	eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Return()
+
+	cardRepo := repository.NewCardRepository(dbClient)
+	cardUsecase := usecase.NewCardUsecase(listRepo, cardRepo, boardMemberRepo, nil, nil, nil, eventRepo, nil)
+
+	creationCount := 4
+	var wg sync.WaitGroup
+	errs := make(chan error, creationCount)
+
+	for i := 0; i < creationCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			errs <- cardUsecase.Create(requesterID, boardID, list.ID, fmt.Sprintf("card %d", i))
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	// every creation picked its rank from the cards written by the ones before it
+	cards, err := cardRepo.GetListCards(list.ID)
+	assert.NoError(t, err)
+	assert.Len(t, cards, creationCount)
+
+	ranks := map[string]bool{}
+	for _, card := range cards {
+		ranks[card.Rank] = true
+	}
+
+	assert.Len(t, ranks, creationCount)
+}
+
+// TestConcurrentCardTitleUpdatesAndMoves updates the title of a card while it is moved between lists,
+// the title updates must not write back the list and the rank of the card they read before a move
+func TestConcurrentCardTitleUpdatesAndMoves(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	boardID := primitive.NewObjectID()
+	requesterID := primitive.NewObjectID()
+	lists := []*models.List{
+		{ID: primitive.NewObjectID(), BoardID: boardID, Rank: "i"},
+		{ID: primitive.NewObjectID(), BoardID: boardID, Rank: "r"},
+	}
+
+	getListByID := func(listID primitive.ObjectID) *models.List {
+		for _, list := range lists {
+			if list.ID == listID {
+				return list
+			}
+		}
+
+		return &models.List{ID: listID}
+	}
+
+	listRepo := new(lr.Repository)
+	listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(getListByID, nil)
+	boardMemberRepo := new(bmr.Repository)
+	boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.BoardMember{{UserID: requesterID, BoardID: boardID}}, nil)
+
+	cardRepo := repository.NewCardRepository(dbClient)
+	cardCount := 0
+	for _, list := range lists {
+		for _, cardRank := range rank.Spread(3) {
+			card := &models.Card{ID: primitive.NewObjectID(), ListID: list.ID, Title: "card", Rank: cardRank}
+			server.Set(fmt.Sprintf("cards/%s", card.ID.Hex()), card)
+			cardCount++
+		}
+	}
+
+	movedCard := &models.Card{ID: primitive.NewObjectID(), ListID: lists[0].ID, Title: "moved card", Rank: "z"}
+	server.Set(fmt.Sprintf("cards/%s", movedCard.ID.Hex()), movedCard)
+	cardCount++
+
+	eventRepo := new(er.Repository)
+	eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Return()
+
+	cardUsecase := usecase.NewCardUsecase(listRepo, cardRepo, boardMemberRepo, nil, nil, nil, eventRepo, nil)
+
+	operationCount := 10
+	var wg sync.WaitGroup
+	var lastTargetList *models.List
+	lastTitle := movedCard.Title
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+
+		random := rand.New(rand.NewSource(1))
+		for i := 0; i < operationCount; i++ {
+			card, err := cardRepo.GetCardByID(movedCard.ID)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			targetList := lists[(i+1)%len(lists)]
+			err = cardUsecase.Move(requesterID, boardID, card.ListID, card.ID, targetList.ID, random.Intn(4))
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			lastTargetList = targetList
+		}
+	}()
+
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < operationCount; i++ {
+			card, err := cardRepo.GetCardByID(movedCard.ID)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			title := fmt.Sprintf("title %d", i)
+			err = cardUsecase.UpdateTitle(requesterID, boardID, card.ListID, card.ID, title)
+			switch err {
+			case nil:
+				lastTitle = title
+			case custom_errors.ErrRecordNotFound:
+				// the card has been moved to the other list since it was read
+			default:
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	storedCard, err := cardRepo.GetCardByID(movedCard.ID)
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	assert.Equal(t, lastTargetList.ID, storedCard.ListID)
+	assert.Equal(t, lastTitle, storedCard.Title)
+
+	total := 0
+	for _, list := range lists {
+		cards, err := cardRepo.GetListCards(list.ID)
+		assert.NoError(t, err)
+		total += len(cards)
+
+		ranks := map[string]bool{}
+		for _, card := range cards {
+			ranks[card.Rank] = true
+		}
+
+		assert.Len(t, ranks, len(cards))
+	}
+
+	assert.Equal(t, cardCount, total)
+}
@@ -1,22 +1,39 @@
 package usecase
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/board"
 	"github.com/jordyf15/thullo-api/board_member"
 	"github.com/jordyf15/thullo-api/card"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
 	"github.com/jordyf15/thullo-api/list"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/notification"
+	"github.com/jordyf15/thullo-api/storage"
+	"github.com/jordyf15/thullo-api/unsplash"
+	"github.com/jordyf15/thullo-api/user"
+	"github.com/jordyf15/thullo-api/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type cardUsecase struct {
-	listRepo        list.Repository
-	cardRepo        card.Repository
-	boardMemberRepo board_member.Repository
+	listRepo         list.Repository
+	cardRepo         card.Repository
+	boardMemberRepo  board_member.Repository
+	unsplashRepo     unsplash.Repository
+	userRepo         user.Repository
+	notificationRepo notification.Repository
+	eventRepo        event.Repository
+	storage          storage.Storage
 }
 
-func NewCardUsecase(listRepo list.Repository, cardRepo card.Repository, boardMemberRepo board_member.Repository) card.Usecase {
-	return &cardUsecase{listRepo: listRepo, cardRepo: cardRepo, boardMemberRepo: boardMemberRepo}
+func NewCardUsecase(listRepo list.Repository, cardRepo card.Repository, boardMemberRepo board_member.Repository, unsplashRepo unsplash.Repository, userRepo user.Repository, notificationRepo notification.Repository, eventRepo event.Repository, storage storage.Storage) card.Usecase {
+	return &cardUsecase{listRepo: listRepo, cardRepo: cardRepo, boardMemberRepo: boardMemberRepo, unsplashRepo: unsplashRepo, userRepo: userRepo, notificationRepo: notificationRepo, eventRepo: eventRepo, storage: storage}
 }
 
 func (usecase *cardUsecase) Create(requesterID, boardID, listID primitive.ObjectID, title string) error {
@@ -39,25 +56,737 @@ func (usecase *cardUsecase) Create(requesterID, boardID, listID primitive.Object
 		return custom_errors.ErrRecordNotFound
 	}
 
-	cards, err := usecase.cardRepo.GetListCards(list.ID)
+	if list.Archived {
+		return custom_errors.ErrListArchived
+	}
+
+	newCard := &models.Card{
+		Title:  title,
+		ListID: list.ID,
+	}
+
+	// the rank is picked from the cards read under the list's position lock, so concurrent
+	// creations can't end up with the same rank and a rebalance is written together with the card
+	err = utils.RetryOnConflict(func() error {
+		return usecase.cardRepo.Create(newCard, func(cards []*models.Card) ([]*models.Card, error) {
+			return card.Append(cards, newCard), nil
+		}, &models.Activity{
+			BoardID: boardID,
+			ActorID: requesterID,
+			Verb:    models.ActivityVerbCreated,
+			Target:  models.ActivityTargetCard,
+			After:   newCard,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardCreated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    newCard,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) UpdateTitle(requesterID, boardID, listID, cardID primitive.ObjectID, title string) error {
+	if title == "" {
+		return custom_errors.ErrCardTitleEmpty
+	}
+
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_activity := activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"title": card.Title}, map[string]interface{}{"title": title})
+
+	card.Title = title
+	card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"title", "updated_at"}, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) UpdateDescription(requesterID, boardID, listID, cardID primitive.ObjectID, description string) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	// only usernames that were not in the description before the edit are notified
+	isMentioned := make(map[string]bool)
+	for _, username := range utils.ParseMentions(card.Description) {
+		isMentioned[username] = true
+	}
+
+	newlyMentionedUsernames := []string{}
+	for _, username := range utils.ParseMentions(description) {
+		if !isMentioned[username] {
+			newlyMentionedUsernames = append(newlyMentionedUsernames, username)
+		}
+	}
+
+	_activity := activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"description": card.Description}, map[string]interface{}{"description": description})
+
+	card.Description = description
+	card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"description", "updated_at"}, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	err = usecase.notifyMentionedMembers(requesterID, boardID, card, newlyMentionedUsernames)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (usecase *cardUsecase) UpdateCover(requesterID, boardID, listID, cardID primitive.ObjectID, cover map[string]interface{}) error {
+	source := cover["source"].(string)
+	focalPointY := cover["fp_y"].(float64)
+	photoID := cover["photo_id"].(string)
+
+	focalPointY, errors := board.VerifyCover(source, focalPointY)
+	if len(errors) > 0 {
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	cardCover, err := board.UploadCover(usecase.unsplashRepo, usecase.storage, source, photoID, focalPointY, fmt.Sprintf("cover picture of card %s", card.Title))
+	if err != nil {
+		return err
+	}
+
+	prevCover := card.Cover
+	card.Cover = cardCover
+	card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"cover", "updated_at"}, activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"cover": prevCover}, map[string]interface{}{"cover": cardCover}))
+	if err != nil {
+		return err
+	}
+
+	if prevCover != nil {
+		board.DeleteCoverImages(usecase.storage, prevCover)
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) DeleteCover(requesterID, boardID, listID, cardID primitive.ObjectID) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.Cover == nil {
+		return nil
+	}
+
+	prevCover := card.Cover
+	card.Cover = nil
+	card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"cover", "updated_at"}, activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"cover": prevCover}, map[string]interface{}{"cover": nil}))
+	if err != nil {
+		return err
+	}
+
+	board.DeleteCoverImages(usecase.storage, prevCover)
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) UpdateDates(requesterID, boardID, listID, cardID primitive.ObjectID, dates map[string]*time.Time) error {
+	_card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	startDate, isExist := dates[card.StartDate]
+	if !isExist {
+		startDate = _card.StartDate
+	}
+
+	dueDate, isExist := dates[card.DueDate]
+	if !isExist {
+		dueDate = _card.DueDate
+	}
+
+	// checked against the dates the card ends up with so only one of them can be changed
+	if startDate != nil && dueDate != nil && startDate.After(*dueDate) {
+		return custom_errors.ErrCardStartAfterDue
+	}
+
+	_activity := activity.NewCardActivity(requesterID, boardID, _card.ID, models.ActivityVerbUpdated,
+		map[string]interface{}{card.StartDate: _card.StartDate, card.DueDate: _card.DueDate},
+		map[string]interface{}{card.StartDate: startDate, card.DueDate: dueDate},
+	)
+
+	_card.StartDate = startDate
+	_card.DueDate = dueDate
+	_card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(_card.ID, _card, []string{card.StartDate, card.DueDate, "updated_at"}, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) UpdateDone(requesterID, boardID, listID, cardID primitive.ObjectID, done bool) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.Done == done {
+		return nil
+	}
+
+	card.Done = done
+	card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"done", "updated_at"}, activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"done": !done}, map[string]interface{}{"done": done}))
 	if err != nil {
 		return err
 	}
 
-	card := &models.Card{
-		Title:    title,
-		ListID:   list.ID,
-		Position: len(cards),
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) UpdatePosition(requesterID, boardID, listID, cardID primitive.ObjectID, newPosition int) error {
+	_, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
 	}
 
-	err = usecase.cardRepo.Create(card)
+	// the positions are recalculated from the cards read while holding the list's
+	// position lock, a reorder that runs into another one is retried
+	var updatedCards []*models.Card
+	_activity := activity.NewCardActivity(requesterID, boardID, cardID, models.ActivityVerbMoved, nil, map[string]interface{}{"list_id": listID, "position": newPosition})
+	err = utils.RetryOnConflict(func() error {
+		return usecase.cardRepo.ReorderListCards(listID, func(cards []*models.Card) ([]*models.Card, error) {
+			_activity.Before = map[string]interface{}{"list_id": listID, "position": cardPosition(cards, cardID)}
+
+			updatedCards, err = usecase.reorderCards(cards, cardID, newPosition)
+			return updatedCards, err
+		}, _activity)
+	})
 	if err != nil {
 		return err
 	}
 
+	// only the cards that were given a new rank are sent
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardMoved,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    updatedCards,
+	})
+
 	return nil
 }
 
+func (usecase *cardUsecase) reorderCards(cards []*models.Card, cardID primitive.ObjectID, newPosition int) ([]*models.Card, error) {
+	var updatedCard *models.Card
+	otherCards := []*models.Card{}
+	activeCardCount := 0
+
+	for _, card := range cards {
+		if !card.Archived {
+			activeCardCount++
+		}
+
+		if card.ID == cardID {
+			updatedCard = card
+		} else {
+			otherCards = append(otherCards, card)
+		}
+	}
+
+	// the card has been moved to another list in the meantime
+	if updatedCard == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	if updatedCard.Archived {
+		return nil, custom_errors.ErrCardArchived
+	}
+
+	if newPosition < 0 {
+		return nil, custom_errors.ErrCardPositionTooLow
+	}
+
+	if newPosition >= activeCardCount {
+		return nil, custom_errors.ErrCardPositionTooHigh
+	}
+
+	card.SortByRank(otherCards)
+
+	return card.Place(otherCards, updatedCard, newPosition), nil
+}
+
+func (usecase *cardUsecase) Move(requesterID, boardID, listID, cardID, targetListID primitive.ObjectID, targetPosition int) error {
+	if listID == targetListID {
+		return usecase.UpdatePosition(requesterID, boardID, listID, cardID, targetPosition)
+	}
+
+	movedCard, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if movedCard.Archived {
+		return custom_errors.ErrCardArchived
+	}
+
+	targetList, err := usecase.listRepo.GetListByID(targetListID)
+	if err != nil {
+		return err
+	}
+
+	// the requester also needs to be a member of the board that the target list belongs to
+	var targetBoardMemberIDs map[primitive.ObjectID]bool
+	if targetList.BoardID != boardID {
+		err = usecase.checkIfRequesterIsMemberOfBoard(requesterID, targetList.BoardID)
+		if err != nil {
+			return err
+		}
+
+		targetBoardMemberIDs, err = usecase.getBoardMemberIDs(targetList.BoardID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if targetList.Archived {
+		return custom_errors.ErrListArchived
+	}
+
+	// the labels, assignees and watchers of a card that leaves the board are written along with its new position
+	after := map[string]interface{}{"list_id": targetList.ID, "position": targetPosition}
+	var movedFields []string
+	if targetList.BoardID != boardID {
+		after["board_id"] = targetList.BoardID
+		movedFields = []string{"label_ids", "assignee_ids", "watcher_ids"}
+	}
+
+	var updatedCards []*models.Card
+	_activity := activity.NewCardActivity(requesterID, boardID, cardID, models.ActivityVerbMoved, nil, after)
+	err = utils.RetryOnConflict(func() error {
+		return usecase.cardRepo.MoveCard(listID, targetList.ID, func(sourceCards, targetCards []*models.Card) ([]*models.Card, error) {
+			_activity.Before = map[string]interface{}{"list_id": listID, "position": cardPosition(sourceCards, cardID)}
+
+			updatedCards, err = usecase.moveCard(sourceCards, targetCards, cardID, targetList.ID, targetPosition, targetBoardMemberIDs)
+			return updatedCards, err
+		}, movedFields, _activity)
+	})
+	if err != nil {
+		return err
+	}
+
+	// a card moved to another board leaves this board and shows up on the other one
+	boardIDs := []primitive.ObjectID{boardID}
+	if targetList.BoardID != boardID {
+		boardIDs = append(boardIDs, targetList.BoardID)
+	}
+
+	for _, _boardID := range boardIDs {
+		usecase.eventRepo.Publish(&models.BoardEvent{
+			Type:    models.BoardEventCardMoved,
+			BoardID: _boardID,
+			ActorID: requesterID,
+			Data:    updatedCards,
+		})
+	}
+
+	return nil
+}
+
+// moveCard places the card in the target list, targetBoardMemberIDs is only given
+// when the target list belongs to another board
+func (usecase *cardUsecase) moveCard(sourceCards, targetCards []*models.Card, cardID, targetListID primitive.ObjectID, targetPosition int, targetBoardMemberIDs map[primitive.ObjectID]bool) ([]*models.Card, error) {
+	var movedCard *models.Card
+	for _, card := range sourceCards {
+		if card.ID == cardID {
+			movedCard = card
+		}
+	}
+
+	// the card has been moved to another list in the meantime
+	if movedCard == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	if movedCard.Archived {
+		return nil, custom_errors.ErrCardArchived
+	}
+
+	activeTargetCardCount := 0
+	for _, card := range targetCards {
+		if !card.Archived {
+			activeTargetCardCount++
+		}
+	}
+
+	if targetPosition < 0 {
+		return nil, custom_errors.ErrCardPositionTooLow
+	}
+
+	if targetPosition > activeTargetCardCount {
+		return nil, custom_errors.ErrCardPositionTooHigh
+	}
+
+	// comments reference the card by its ID so they follow the card to the target list,
+	// the cards left behind in the source list keep their ranks
+	movedCard.ListID = targetListID
+	movedCard.UpdatedAt = time.Now()
+
+	// labels belong to the board the card leaves, and only the members of the
+	// target board can stay assigned to or keep watching the card
+	if targetBoardMemberIDs != nil {
+		movedCard.LabelIDs = nil
+		movedCard.AssigneeIDs = filterBoardMembers(movedCard.AssigneeIDs, targetBoardMemberIDs)
+		movedCard.WatcherIDs = filterBoardMembers(movedCard.WatcherIDs, targetBoardMemberIDs)
+	}
+
+	card.SortByRank(targetCards)
+
+	return card.Place(targetCards, movedCard, targetPosition), nil
+}
+
+func (usecase *cardUsecase) Assign(requesterID, boardID, listID, cardID, assigneeID primitive.ObjectID) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	isBoardMember := false
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == assigneeID {
+			isBoardMember = true
+			break
+		}
+	}
+
+	if !isBoardMember {
+		return custom_errors.ErrCardAssigneeNotMember
+	}
+
+	if card.IsAssigned(assigneeID) {
+		return nil
+	}
+
+	_activity := activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"assignee_ids": card.AssigneeIDs}, nil)
+
+	card.AssigneeIDs = append(card.AssigneeIDs, assigneeID)
+	card.UpdatedAt = time.Now()
+	_activity.After = map[string]interface{}{"assignee_ids": card.AssigneeIDs}
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"assignee_ids", "updated_at"}, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) Unassign(requesterID, boardID, listID, cardID, assigneeID primitive.ObjectID) error {
+	// the assignee is not checked against the board members so users
+	// that are no longer a member can still be unassigned
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_activity := activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"assignee_ids": append([]primitive.ObjectID{}, card.AssigneeIDs...)}, nil)
+
+	if !card.Unassign(assigneeID) {
+		return nil
+	}
+
+	card.UpdatedAt = time.Now()
+	_activity.After = map[string]interface{}{"assignee_ids": card.AssigneeIDs}
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"assignee_ids", "updated_at"}, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) Watch(requesterID, boardID, listID, cardID primitive.ObjectID) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if card.IsWatched(requesterID) {
+		return nil
+	}
+
+	_activity := activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"watcher_ids": card.WatcherIDs}, nil)
+
+	card.WatcherIDs = append(card.WatcherIDs, requesterID)
+	_activity.After = map[string]interface{}{"watcher_ids": card.WatcherIDs}
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"watcher_ids"}, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) Unwatch(requesterID, boardID, listID, cardID primitive.ObjectID) error {
+	card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_activity := activity.NewCardActivity(requesterID, boardID, card.ID, models.ActivityVerbUpdated, map[string]interface{}{"watcher_ids": append([]primitive.ObjectID{}, card.WatcherIDs...)}, nil)
+
+	if !card.Unwatch(requesterID) {
+		return nil
+	}
+
+	_activity.After = map[string]interface{}{"watcher_ids": card.WatcherIDs}
+
+	err = usecase.cardRepo.UpdateCard(card.ID, card, []string{"watcher_ids"}, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    card,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) Archive(requesterID, boardID, listID, cardID primitive.ObjectID) error {
+	archivedCard, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if archivedCard.Archived {
+		return custom_errors.ErrCardArchived
+	}
+
+	// the archived card keeps its rank so it is put back
+	// in the same place between the other cards when restored
+	archivedCard.Archived = true
+	archivedCard.UpdatedAt = time.Now()
+	archivedCard.ArchivedAt = &archivedCard.UpdatedAt
+
+	err = usecase.cardRepo.UpdateCard(archivedCard.ID, archivedCard, []string{"archived", "archived_at", "updated_at"}, activity.NewCardActivity(requesterID, boardID, archivedCard.ID, models.ActivityVerbArchived, nil, nil))
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardArchived,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    archivedCard,
+	})
+
+	return nil
+}
+
+func (usecase *cardUsecase) Unarchive(requesterID, boardID, listID, cardID primitive.ObjectID) error {
+	restoredCard, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if !restoredCard.Archived {
+		return custom_errors.ErrCardNotArchived
+	}
+
+	restoredCard.Archived = false
+	restoredCard.ArchivedAt = nil
+	restoredCard.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(restoredCard.ID, restoredCard, []string{"archived", "archived_at", "updated_at"}, activity.NewCardActivity(requesterID, boardID, restoredCard.ID, models.ActivityVerbUnarchived, nil, nil))
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUnarchived,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    restoredCard,
+	})
+
+	return nil
+}
+
+// notifyMentionedMembers notifies the board members that have the mentioned usernames except for the requester,
+// usernames that nobody or a non member has are ignored
+func (usecase *cardUsecase) notifyMentionedMembers(requesterID, boardID primitive.ObjectID, _card *models.Card, usernames []string) error {
+	if len(usernames) == 0 {
+		return nil
+	}
+
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	isBoardMember := make(map[primitive.ObjectID]bool)
+	for _, boardMember := range boardMembers {
+		isBoardMember[boardMember.UserID] = true
+	}
+
+	notifications := []*models.Notification{}
+	for _, username := range usernames {
+		mentionedUser, err := usecase.userRepo.GetByUsername(username)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if !isBoardMember[mentionedUser.ID] || mentionedUser.ID == requesterID {
+			continue
+		}
+
+		notifications = append(notifications, &models.Notification{
+			UserID:  mentionedUser.ID,
+			ActorID: requesterID,
+			Type:    models.NotificationTypeCardMention,
+			BoardID: boardID,
+			ListID:  &_card.ListID,
+			CardID:  &_card.ID,
+		})
+	}
+
+	return usecase.notificationRepo.Create(notifications)
+}
+
+// getBoardCard makes sure the requester is a board member and that the card
+// actually belongs to the list and board in the path before returning it
+func (usecase *cardUsecase) getBoardCard(requesterID, boardID, listID, cardID primitive.ObjectID) (*models.Card, error) {
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.BoardID != boardID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	card, err := usecase.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if card.ListID != listID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return card, nil
+}
+
 func (usecase *cardUsecase) checkIfRequesterIsMemberOfBoard(requesterID, boardID primitive.ObjectID) error {
 	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
 	if err != nil {
@@ -84,3 +813,49 @@ func (usecase *cardUsecase) checkIfRequesterIsMemberOfBoard(requesterID, boardID
 
 	return nil
 }
+
+func (usecase *cardUsecase) getBoardMemberIDs(boardID primitive.ObjectID) (map[primitive.ObjectID]bool, error) {
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	boardMemberIDs := make(map[primitive.ObjectID]bool)
+	for _, boardMember := range boardMembers {
+		boardMemberIDs[boardMember.UserID] = true
+	}
+
+	return boardMemberIDs, nil
+}
+
+// filterBoardMembers returns the users that are in boardMemberIDs
+func filterBoardMembers(userIDs []primitive.ObjectID, boardMemberIDs map[primitive.ObjectID]bool) []primitive.ObjectID {
+	var memberIDs []primitive.ObjectID
+	for _, userID := range userIDs {
+		if boardMemberIDs[userID] {
+			memberIDs = append(memberIDs, userID)
+		}
+	}
+
+	return memberIDs
+}
+
+// cardPosition returns the position of the card between the active cards of the list, -1 when it is not one of them
+func cardPosition(cards []*models.Card, cardID primitive.ObjectID) int {
+	activeCards := []*models.Card{}
+	for _, card := range cards {
+		if !card.Archived {
+			activeCards = append(activeCards, card)
+		}
+	}
+
+	card.SortByRank(activeCards)
+
+	for i, card := range activeCards {
+		if card.ID == cardID {
+			return i
+		}
+	}
+
+	return -1
+}
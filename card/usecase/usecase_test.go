@@ -1,19 +1,30 @@
 package usecase_test
 
 import (
+	"os"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
 	"github.com/jordyf15/thullo-api/card"
 	cr "github.com/jordyf15/thullo-api/card/mocks"
 	"github.com/jordyf15/thullo-api/card/usecase"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
 	lr "github.com/jordyf15/thullo-api/list/mocks"
 	"github.com/jordyf15/thullo-api/models"
+	nr "github.com/jordyf15/thullo-api/notification/mocks"
+	"github.com/jordyf15/thullo-api/rank"
+	sr "github.com/jordyf15/thullo-api/storage/mocks"
+	unr "github.com/jordyf15/thullo-api/unsplash/mocks"
+	ur "github.com/jordyf15/thullo-api/user/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestCardUsecase(t *testing.T) {
@@ -23,19 +34,37 @@ func TestCardUsecase(t *testing.T) {
 type cardUsecaseSuite struct {
 	suite.Suite
 
-	usecase         card.Usecase
-	listRepo        *lr.Repository
-	cardRepo        *cr.Repository
-	boardMemberRepo *bmr.Repository
+	usecase          card.Usecase
+	listRepo         *lr.Repository
+	cardRepo         *cr.Repository
+	boardMemberRepo  *bmr.Repository
+	unsplashRepo     *unr.Repository
+	userRepo         *ur.Repository
+	notificationRepo *nr.Repository
+	eventRepo        *er.Repository
+	storage          *sr.Storage
+
+	createdCard   *models.Card
+	updatedCards  []*models.Card
+	notifications []*models.Notification
+	events        []*models.BoardEvent
+	activities    []*models.Activity
 }
 
 var (
+	img1 *os.File
+	img2 *os.File
+	img3 *os.File
+
 	board1 = &models.Board{
 		ID: primitive.NewObjectID(),
 	}
 	board2 = &models.Board{
 		ID: primitive.NewObjectID(),
 	}
+	board3 = &models.Board{
+		ID: primitive.NewObjectID(),
+	}
 
 	boardMember1 = &models.BoardMember{
 		ID:      primitive.NewObjectID(),
@@ -49,35 +78,53 @@ var (
 	}
 
 	list1 = &models.List{
-		ID:       primitive.NewObjectID(),
-		Title:    "list 1",
-		Position: 0,
-		BoardID:  board1.ID,
+		ID:      primitive.NewObjectID(),
+		Title:   "list 1",
+		Rank:    "a",
+		BoardID: board1.ID,
 	}
 	list2 = &models.List{
-		ID:       primitive.NewObjectID(),
-		Title:    "list 2",
-		Position: 0,
-		BoardID:  primitive.NewObjectID(),
+		ID:      primitive.NewObjectID(),
+		Title:   "list 2",
+		Rank:    "a",
+		BoardID: primitive.NewObjectID(),
+	}
+	list3 = &models.List{
+		ID:      primitive.NewObjectID(),
+		Title:   "list 3",
+		Rank:    "i",
+		BoardID: board1.ID,
+	}
+	list4 = &models.List{
+		ID:      primitive.NewObjectID(),
+		Title:   "list 4",
+		Rank:    "a",
+		BoardID: board3.ID,
 	}
 
 	card1 = &models.Card{
-		ID:       primitive.NewObjectID(),
-		Title:    "card 1",
-		ListID:   list1.ID,
-		Position: 0,
+		ID:     primitive.NewObjectID(),
+		Title:  "card 1",
+		ListID: list1.ID,
+		Rank:   "a",
 	}
 	card2 = &models.Card{
-		ID:       primitive.NewObjectID(),
-		Title:    "card 2",
-		ListID:   list1.ID,
-		Position: 1,
+		ID:     primitive.NewObjectID(),
+		Title:  "card 2",
+		ListID: list1.ID,
+		Rank:   "i",
 	}
 	card3 = &models.Card{
-		ID:       primitive.NewObjectID(),
-		Title:    "card 3",
-		ListID:   list1.ID,
-		Position: 2,
+		ID:     primitive.NewObjectID(),
+		Title:  "card 3",
+		ListID: list1.ID,
+		Rank:   "r",
+	}
+	card4 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		Title:  "card 4",
+		ListID: list3.ID,
+		Rank:   "i",
 	}
 )
 
@@ -85,29 +132,182 @@ func (s *cardUsecaseSuite) SetupTest() {
 	s.listRepo = new(lr.Repository)
 	s.cardRepo = new(cr.Repository)
 	s.boardMemberRepo = new(bmr.Repository)
+	s.unsplashRepo = new(unr.Repository)
+	s.userRepo = new(ur.Repository)
+	s.notificationRepo = new(nr.Repository)
+	s.eventRepo = new(er.Repository)
+	s.storage = new(sr.Storage)
+
+	img1, _ = os.Create("image1.jpg")
+	img2, _ = os.Create("image2.jpg")
+	img3, _ = os.Create("image3.jpg")
 
 	getBoardMembers := func(boardID primitive.ObjectID) []*models.BoardMember {
 		if boardID == board1.ID {
 			return []*models.BoardMember{boardMember1, boardMember2}
 		}
 
+		if boardID == board3.ID {
+			return []*models.BoardMember{boardMember2}
+		}
+
 		return []*models.BoardMember{}
 	}
 
+	card1.AssigneeIDs = nil
+	card1.WatcherIDs = nil
+	card2.LabelIDs = nil
+	card2.AssigneeIDs = nil
+	card2.WatcherIDs = nil
+
+	// need to reset card dates
+	card1.StartDate = nil
+	card1.DueDate = nil
+	card1.Done = false
+
+	// need to reset card position
+	card1.Rank = "a"
+	card2.Rank = "i"
+	card3.Rank = "r"
+	card1.Archived = false
+	card2.Archived = false
+	card3.Archived = false
+	card4.Rank = "i"
+	card1.ListID = list1.ID
+	card2.ListID = list1.ID
+	card4.ListID = list3.ID
+	card1.Title = "card 1"
+	card1.Description = ""
+	card1.Cover = nil
+
+	getCardByID := func(cardID primitive.ObjectID) *models.Card {
+		switch cardID {
+		case card1.ID:
+			return card1
+		case card2.ID:
+			return card2
+		case card3.ID:
+			return card3
+		case card4.ID:
+			return card4
+		}
+
+		return &models.Card{ID: cardID, ListID: primitive.NewObjectID()}
+	}
+
 	getListByID := func(listID primitive.ObjectID) *models.List {
-		if listID == list1.ID {
+		switch listID {
+		case list1.ID:
 			return list1
+		case list3.ID:
+			return list3
+		case list4.ID:
+			return list4
 		}
 
 		return list2
 	}
 
+	getListCards := func(listID primitive.ObjectID) []*models.Card {
+		switch listID {
+		case list1.ID:
+			return []*models.Card{card1, card2, card3}
+		case list3.ID:
+			return []*models.Card{card4}
+		}
+
+		return []*models.Card{}
+	}
+
+	s.activities = []*models.Activity{}
+	recordActivity := func(args mock.Arguments) {
+		if _activity, _ := args.Get(len(args) - 1).(*models.Activity); _activity != nil {
+			s.activities = append(s.activities, _activity)
+		}
+	}
+
 	s.listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(getListByID, nil)
-	s.cardRepo.On("GetListCards", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Card{card1, card2, card3}, nil)
-	s.cardRepo.On("Create", mock.AnythingOfType("*models.Card")).Return(nil)
+	s.cardRepo.On("GetListCards", mock.AnythingOfType("primitive.ObjectID")).Return(getListCards, nil)
+	s.cardRepo.On("Create", mock.AnythingOfType("*models.Card"), mock.Anything, mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		recordActivity(args)
+		s.createdCard = args[0].(*models.Card)
+	}).Return(func(card *models.Card, place func([]*models.Card) ([]*models.Card, error), _activity *models.Activity) error {
+		updatedCards, err := place(getListCards(card.ListID))
+		s.updatedCards = updatedCards
+		return err
+	})
+	s.cardRepo.On("GetCardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getCardByID, nil)
+	s.cardRepo.On("UpdateCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.Card"), mock.AnythingOfType("[]string"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.updatedCards = nil
+	s.cardRepo.On("ReorderListCards", mock.AnythingOfType("primitive.ObjectID"), mock.Anything, mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(func(listID primitive.ObjectID, reorder func([]*models.Card) ([]*models.Card, error), _activity *models.Activity) error {
+		updatedCards, err := reorder(getListCards(listID))
+		s.updatedCards = updatedCards
+		return err
+	})
+	s.cardRepo.On("MoveCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.Anything, mock.AnythingOfType("[]string"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(func(sourceListID, targetListID primitive.ObjectID, move func([]*models.Card, []*models.Card) ([]*models.Card, error), movedFields []string, _activity *models.Activity) error {
+		updatedCards, err := move(getListCards(sourceListID), getListCards(targetListID))
+		s.updatedCards = updatedCards
+		return err
+	})
 	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardMembers, nil)
 
-	s.usecase = usecase.NewCardUsecase(s.listRepo, s.cardRepo, s.boardMemberRepo)
+	s.unsplashRepo.On("GetImagesForID", mock.AnythingOfType("string"), mock.AnythingOfType("float64")).Return([]*os.File{img1, img2, img3}, nil)
+	s.storage.On("UploadFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image"), mock.AnythingOfType("*os.File"), mock.AnythingOfType("map[string]string")).Run(func(args mock.Arguments) {
+		arg1 := args[0].(chan<- error)
+		arg1 <- nil
+		arg2 := args[1].(*sync.WaitGroup)
+		arg2.Done()
+	})
+	s.storage.On("DeleteFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image")).Run(func(args mock.Arguments) {
+		arg1 := args[0].(chan<- error)
+		arg1 <- nil
+		arg2 := args[1].(*sync.WaitGroup)
+		arg2.Done()
+	})
+
+	getByUsername := func(username string) *models.User {
+		switch username {
+		case "member1":
+			return &models.User{ID: boardMember1.UserID, Username: username}
+		case "member2":
+			return &models.User{ID: boardMember2.UserID, Username: username}
+		case "outsider":
+			return &models.User{ID: primitive.NewObjectID(), Username: username}
+		}
+
+		return nil
+	}
+
+	getByUsernameErr := func(username string) error {
+		if getByUsername(username) == nil {
+			return mongo.ErrNoDocuments
+		}
+
+		return nil
+	}
+
+	s.userRepo.On("GetByUsername", mock.AnythingOfType("string")).Return(getByUsername, getByUsernameErr)
+	s.notifications = nil
+	s.notificationRepo.On("Create", mock.AnythingOfType("[]*models.Notification")).Run(func(args mock.Arguments) {
+		s.notifications = append(s.notifications, args[0].([]*models.Notification)...)
+	}).Return(nil)
+
+	s.events = []*models.BoardEvent{}
+	s.eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Run(func(args mock.Arguments) {
+		s.events = append(s.events, args[0].(*models.BoardEvent))
+	}).Return()
+
+	s.usecase = usecase.NewCardUsecase(s.listRepo, s.cardRepo, s.boardMemberRepo, s.unsplashRepo, s.userRepo, s.notificationRepo, s.eventRepo, s.storage)
+}
+
+func (s *cardUsecaseSuite) AfterTest(suiteName, testName string) {
+	img1.Close()
+	img2.Close()
+	img3.Close()
+
+	os.Remove("image1.jpg")
+	os.Remove("image2.jpg")
+	os.Remove("image3.jpg")
 }
 
 func (s *cardUsecaseSuite) TestCreateCardEmptyTitle() {
@@ -142,4 +342,462 @@ func (s *cardUsecaseSuite) TestCreateCardSuccessful() {
 	err := s.usecase.Create(boardMember1.UserID, board1.ID, list1.ID, "card 1")
 
 	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	assert.Equal(s.T(), []*models.Card{s.createdCard}, s.updatedCards)
+	assert.Greater(s.T(), s.createdCard.Rank, card3.Rank)
+	assert.Len(s.T(), s.events, 1)
+	assert.Equal(s.T(), models.BoardEventCardCreated, s.events[0].Type)
+	assert.Equal(s.T(), s.createdCard, s.events[0].Data)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbCreated, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetCard, s.activities[0].Target)
+	assert.Equal(s.T(), s.createdCard, s.activities[0].After)
+}
+
+func (s *cardUsecaseSuite) TestArchiveNotAuthorized() {
+	err := s.usecase.Archive(primitive.NewObjectID(), board1.ID, list1.ID, card1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	assert.Empty(s.T(), s.events)
+}
+
+func (s *cardUsecaseSuite) TestArchiveCardNotBelongToList() {
+	err := s.usecase.Archive(boardMember1.UserID, board1.ID, list1.ID, primitive.NewObjectID())
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestArchiveSuccessful() {
+	err := s.usecase.Archive(boardMember1.UserID, board1.ID, list1.ID, card2.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.True(s.T(), card2.Archived)
+	assert.Equal(s.T(), card2.UpdatedAt, *card2.ArchivedAt)
+	assert.Equal(s.T(), "i", card2.Rank)
+}
+
+func (s *cardUsecaseSuite) TestUnarchiveNotArchived() {
+	err := s.usecase.Unarchive(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrCardNotArchived.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUnarchiveSuccessful() {
+	card1.Archived = true
+
+	err := s.usecase.Unarchive(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.False(s.T(), card1.Archived)
+	assert.Nil(s.T(), card1.ArchivedAt)
+	assert.Equal(s.T(), "a", card1.Rank)
+}
+
+func (s *cardUsecaseSuite) TestUpdateTitleEmpty() {
+	err := s.usecase.UpdateTitle(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrCardTitleEmpty.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateTitleNotAuthorized() {
+	err := s.usecase.UpdateTitle(primitive.NewObjectID(), board1.ID, list1.ID, card1.ID, "new title")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateTitleSuccessful() {
+	err := s.usecase.UpdateTitle(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "new title")
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), "new title", card1.Title)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), card1.ID, *s.activities[0].CardID)
+	assert.Equal(s.T(), map[string]interface{}{"title": "new title"}, s.activities[0].After)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDescriptionSuccessful() {
+	err := s.usecase.UpdateDescription(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "new description")
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), "new description", card1.Description)
+	assert.Len(s.T(), s.notifications, 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDescriptionNotifyMentionedMembers() {
+	err := s.usecase.UpdateDescription(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "@member2 please review with @member1, @outsider and @nobody")
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Len(s.T(), s.notifications, 1)
+	assert.Equal(s.T(), boardMember2.UserID, s.notifications[0].UserID)
+	assert.Equal(s.T(), boardMember1.UserID, s.notifications[0].ActorID)
+	assert.Equal(s.T(), models.NotificationTypeCardMention, s.notifications[0].Type)
+	assert.Equal(s.T(), card1.ID, *s.notifications[0].CardID)
+	assert.Equal(s.T(), list1.ID, *s.notifications[0].ListID)
+	assert.Equal(s.T(), board1.ID, s.notifications[0].BoardID)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDescriptionDoNotNotifyPreviouslyMentionedMembers() {
+	card1.Description = "waiting on @member2"
+
+	err := s.usecase.UpdateDescription(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "still waiting on @Member2")
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Len(s.T(), s.notifications, 0)
+}
+
+func (s *cardUsecaseSuite) TestWatchAsNonMember() {
+	err := s.usecase.Watch(primitive.NewObjectID(), board1.ID, list1.ID, card1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestWatchSuccessful() {
+	err := s.usecase.Watch(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember1.UserID}, card1.WatcherIDs)
+}
+
+func (s *cardUsecaseSuite) TestWatchAlreadyWatched() {
+	card1.WatcherIDs = []primitive.ObjectID{boardMember1.UserID}
+
+	err := s.usecase.Watch(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUnwatchSuccessful() {
+	card1.WatcherIDs = []primitive.ObjectID{boardMember2.UserID, boardMember1.UserID}
+
+	err := s.usecase.Unwatch(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember2.UserID}, card1.WatcherIDs)
+}
+
+func (s *cardUsecaseSuite) TestUnwatchNotWatched() {
+	err := s.usecase.Unwatch(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateCoverInvalidCover() {
+	err := s.usecase.UpdateCover(boardMember1.UserID, board1.ID, list1.ID, card1.ID, map[string]interface{}{
+		"source":   "imgur",
+		"fp_y":     float64(100),
+		"photo_id": "picture-1",
+	})
+
+	expectedErrors := &custom_errors.MultipleErrors{Errors: []error{custom_errors.ErrInvalidCoverSource, custom_errors.ErrUnsplashFocalPointYTooHigh}}
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), expectedErrors.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	s.storage.AssertNumberOfCalls(s.T(), "UploadFile", 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateCoverSuccessful() {
+	prevCover := &models.BoardCover{Images: []*models.Image{{ID: "old-image"}}}
+	card1.Cover = prevCover
+
+	err := s.usecase.UpdateCover(boardMember1.UserID, board1.ID, list1.ID, card1.ID, map[string]interface{}{
+		"source":   "unsplash",
+		"fp_y":     float64(0.5),
+		"photo_id": "picture-1",
+	})
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 1)
+	assert.NotNil(s.T(), card1.Cover)
+	assert.NotEqual(s.T(), prevCover, card1.Cover)
+	assert.Equal(s.T(), "picture-1", card1.Cover.PhotoID)
+}
+
+func (s *cardUsecaseSuite) TestDeleteCoverNoCover() {
+	err := s.usecase.DeleteCover(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 0)
+}
+
+func (s *cardUsecaseSuite) TestDeleteCoverSuccessful() {
+	card1.Cover = &models.BoardCover{Images: []*models.Image{{ID: "image-1"}, {ID: "image-2"}}}
+
+	err := s.usecase.DeleteCover(boardMember1.UserID, board1.ID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), card1.Cover)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 2)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDatesStartAfterDue() {
+	startDate := time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC)
+
+	err := s.usecase.UpdateDates(boardMember1.UserID, board1.ID, list1.ID, card1.ID, map[string]*time.Time{
+		card.StartDate: &startDate,
+		card.DueDate:   &dueDate,
+	})
+
+	assert.Equal(s.T(), custom_errors.ErrCardStartAfterDue.Error(), err.Error())
+	assert.Nil(s.T(), card1.StartDate)
+	assert.Nil(s.T(), card1.DueDate)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDatesDueBeforeExistingStart() {
+	startDate := time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC)
+	card1.StartDate = &startDate
+	dueDate := time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC)
+
+	err := s.usecase.UpdateDates(boardMember1.UserID, board1.ID, list1.ID, card1.ID, map[string]*time.Time{
+		card.DueDate: &dueDate,
+	})
+
+	assert.Equal(s.T(), custom_errors.ErrCardStartAfterDue.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDatesOnlyDueKeepsStart() {
+	startDate := time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC)
+	card1.StartDate = &startDate
+	dueDate := time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC)
+
+	err := s.usecase.UpdateDates(boardMember1.UserID, board1.ID, list1.ID, card1.ID, map[string]*time.Time{
+		card.DueDate: &dueDate,
+	})
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), startDate, *card1.StartDate)
+	assert.Equal(s.T(), dueDate, *card1.DueDate)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDatesClearDue() {
+	dueDate := time.Date(2022, 10, 20, 0, 0, 0, 0, time.UTC)
+	card1.DueDate = &dueDate
+
+	err := s.usecase.UpdateDates(boardMember1.UserID, board1.ID, list1.ID, card1.ID, map[string]*time.Time{
+		card.DueDate: nil,
+	})
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Nil(s.T(), card1.DueDate)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDoneUnchanged() {
+	err := s.usecase.UpdateDone(boardMember1.UserID, board1.ID, list1.ID, card1.ID, false)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	assert.Len(s.T(), s.activities, 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdateDoneSuccessful() {
+	err := s.usecase.UpdateDone(boardMember1.UserID, board1.ID, list1.ID, card1.ID, true)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.True(s.T(), card1.Done)
+}
+
+func (s *cardUsecaseSuite) TestAssignNotBoardMember() {
+	err := s.usecase.Assign(boardMember1.UserID, board1.ID, list1.ID, card1.ID, primitive.NewObjectID())
+
+	assert.Equal(s.T(), custom_errors.ErrCardAssigneeNotMember.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestAssignNotAuthorized() {
+	err := s.usecase.Assign(primitive.NewObjectID(), board1.ID, list1.ID, card1.ID, boardMember2.UserID)
+
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestAssignAlreadyAssigned() {
+	card1.AssigneeIDs = []primitive.ObjectID{boardMember2.UserID}
+
+	err := s.usecase.Assign(boardMember1.UserID, board1.ID, list1.ID, card1.ID, boardMember2.UserID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	assert.Len(s.T(), card1.AssigneeIDs, 1)
+}
+
+func (s *cardUsecaseSuite) TestAssignSuccessful() {
+	err := s.usecase.Assign(boardMember1.UserID, board1.ID, list1.ID, card1.ID, boardMember2.UserID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember2.UserID}, card1.AssigneeIDs)
+}
+
+func (s *cardUsecaseSuite) TestUnassignNotAssigned() {
+	err := s.usecase.Unassign(boardMember1.UserID, board1.ID, list1.ID, card1.ID, boardMember2.UserID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *cardUsecaseSuite) TestUnassignSuccessful() {
+	formerMemberID := primitive.NewObjectID()
+	card1.AssigneeIDs = []primitive.ObjectID{boardMember2.UserID, formerMemberID}
+
+	err := s.usecase.Unassign(boardMember1.UserID, board1.ID, list1.ID, card1.ID, formerMemberID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember2.UserID}, card1.AssigneeIDs)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), map[string]interface{}{"assignee_ids": []primitive.ObjectID{boardMember2.UserID, formerMemberID}}, s.activities[0].Before)
+	assert.Equal(s.T(), map[string]interface{}{"assignee_ids": []primitive.ObjectID{boardMember2.UserID}}, s.activities[0].After)
+}
+
+func (s *cardUsecaseSuite) TestUpdatePositionTooLow() {
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, card1.ID, -1)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrCardPositionTooLow.Error(), err.Error())
+	assert.Len(s.T(), s.updatedCards, 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdatePositionTooHigh() {
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, card1.ID, 3)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrCardPositionTooHigh.Error(), err.Error())
+	assert.Len(s.T(), s.updatedCards, 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdatePositionArchivedCard() {
+	card1.Archived = true
+
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, card1.ID, 1)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrCardArchived.Error(), err.Error())
+	assert.Len(s.T(), s.updatedCards, 0)
+}
+
+func (s *cardUsecaseSuite) TestUpdatePositionToHigherPosition() {
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, card1.ID, 2)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedCards, 1)
+	assert.Greater(s.T(), card1.Rank, card3.Rank)
+	assert.Equal(s.T(), "i", card2.Rank)
+	assert.Equal(s.T(), "r", card3.Rank)
+}
+
+func (s *cardUsecaseSuite) TestUpdatePositionToLowerPosition() {
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, card3.ID, 1)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedCards, 1)
+	assert.Greater(s.T(), card3.Rank, card1.Rank)
+	assert.Less(s.T(), card3.Rank, card2.Rank)
+}
+
+func (s *cardUsecaseSuite) TestMoveTargetListArchived() {
+	list3.Archived = true
+	defer func() { list3.Archived = false }()
+
+	err := s.usecase.Move(boardMember1.UserID, board1.ID, list1.ID, card1.ID, list3.ID, 0)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrListArchived.Error(), err.Error())
+	assert.Len(s.T(), s.updatedCards, 0)
+}
+
+func (s *cardUsecaseSuite) TestMovePositionTooHigh() {
+	err := s.usecase.Move(boardMember1.UserID, board1.ID, list1.ID, card1.ID, list3.ID, 2)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrCardPositionTooHigh.Error(), err.Error())
+	assert.Len(s.T(), s.updatedCards, 0)
+}
+
+func (s *cardUsecaseSuite) TestMoveSuccessful() {
+	err := s.usecase.Move(boardMember1.UserID, board1.ID, list1.ID, card1.ID, list3.ID, 0)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedCards, 1)
+	assert.Equal(s.T(), list3.ID, card1.ListID)
+	assert.Less(s.T(), card1.Rank, card4.Rank)
+	assert.Equal(s.T(), "i", card4.Rank)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbMoved, s.activities[0].Verb)
+	assert.Equal(s.T(), map[string]interface{}{"list_id": list1.ID, "position": 0}, s.activities[0].Before)
+	assert.Equal(s.T(), map[string]interface{}{"list_id": list3.ID, "position": 0}, s.activities[0].After)
+}
+
+func (s *cardUsecaseSuite) TestMoveToOtherBoardNotAuthorized() {
+	err := s.usecase.Move(boardMember1.UserID, board1.ID, list1.ID, card1.ID, list4.ID, 0)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	assert.Len(s.T(), s.updatedCards, 0)
+}
+
+func (s *cardUsecaseSuite) TestMoveToOtherBoardSuccessful() {
+	card2.LabelIDs = []primitive.ObjectID{primitive.NewObjectID()}
+	card2.AssigneeIDs = []primitive.ObjectID{boardMember1.UserID, boardMember2.UserID}
+	card2.WatcherIDs = []primitive.ObjectID{boardMember1.UserID}
+
+	err := s.usecase.Move(boardMember2.UserID, board1.ID, list1.ID, card2.ID, list4.ID, 0)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedCards, 1)
+	assert.Equal(s.T(), list4.ID, card2.ListID)
+	// the labels stay on the old board and only the members of the other board stay on the card
+	assert.Empty(s.T(), card2.LabelIDs)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember2.UserID}, card2.AssigneeIDs)
+	assert.Empty(s.T(), card2.WatcherIDs)
+	assert.Len(s.T(), s.events, 2)
+	assert.Equal(s.T(), board1.ID, s.events[0].BoardID)
+	assert.Equal(s.T(), list4.BoardID, s.events[1].BoardID)
+	assert.Equal(s.T(), models.BoardEventCardMoved, s.events[1].Type)
+}
+
+func (s *cardUsecaseSuite) TestUpdatePositionRebalancesRanks() {
+	card1.Rank = "a" + strings.Repeat("0", rank.MaxLength-2) + "1"
+	card2.Rank = "a" + strings.Repeat("0", rank.MaxLength-2) + "2"
+
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, card3.ID, 1)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedCards, 3)
+	assert.Less(s.T(), card1.Rank, card3.Rank)
+	assert.Less(s.T(), card3.Rank, card2.Rank)
+	assert.False(s.T(), rank.NeedsRebalance(card1.Rank))
+	assert.False(s.T(), rank.NeedsRebalance(card2.Rank))
+	assert.False(s.T(), rank.NeedsRebalance(card3.Rank))
 }
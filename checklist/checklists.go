@@ -0,0 +1,27 @@
+package checklist
+
+import (
+	"fmt"
+
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// LockPath is where the lock that guards the checklists of the card is kept
+func LockPath(cardID primitive.ObjectID) string {
+	return fmt.Sprintf("checklist_locks/%s", cardID.Hex())
+}
+
+// AddToUpdates adds the checklists of the card to the multi-path updates of a repository together with the
+// new progress of the card, the checklists that are in checklists but not in updatedChecklists are deleted
+func AddToUpdates(updates map[string]interface{}, cardID primitive.ObjectID, checklists, updatedChecklists []*models.Checklist) {
+	updates[fmt.Sprintf("cards/%s/checklist_progress", cardID.Hex())] = models.GetChecklistProgress(updatedChecklists)
+
+	for _, _checklist := range checklists {
+		updates[fmt.Sprintf("checklists/%s/%s", cardID.Hex(), _checklist.ID.Hex())] = nil
+	}
+
+	for _, _checklist := range updatedChecklists {
+		updates[fmt.Sprintf("checklists/%s/%s", cardID.Hex(), _checklist.ID.Hex())] = _checklist
+	}
+}
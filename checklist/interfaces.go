@@ -0,0 +1,26 @@
+package checklist
+
+import (
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Repository interface {
+	Create(checklist *models.Checklist, activity *models.Activity) error
+	GetChecklistByID(cardID, checklistID primitive.ObjectID) (*models.Checklist, error)
+	GetCardChecklists(cardID primitive.ObjectID) ([]*models.Checklist, error)
+	UpdateCardChecklists(cardID primitive.ObjectID, update func(checklists []*models.Checklist) ([]*models.Checklist, error), activity *models.Activity) error
+}
+
+type Usecase interface {
+	Create(requesterID, boardID, listID, cardID primitive.ObjectID, title string) error
+	GetCardChecklists(requesterID, boardID, listID, cardID primitive.ObjectID) ([]*models.Checklist, error)
+	UpdateTitle(requesterID, boardID, listID, cardID, checklistID primitive.ObjectID, title string) error
+	Delete(requesterID, boardID, listID, cardID, checklistID primitive.ObjectID) error
+	AddItem(requesterID, boardID, listID, cardID, checklistID primitive.ObjectID, title string) error
+	UpdateItemTitle(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID, title string) error
+	UpdateItemDone(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID, done bool) error
+	UpdateItemPosition(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID, newPosition int) error
+	DeleteItem(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID) error
+	ConvertItemToCard(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID) error
+}
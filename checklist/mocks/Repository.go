@@ -0,0 +1,104 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: _a0, activity
+func (_m *Repository) Create(_a0 *models.Checklist, activity *models.Activity) error {
+	ret := _m.Called(_a0, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Checklist, *models.Activity) error); ok {
+		r0 = rf(_a0, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCardChecklists provides a mock function with given fields: cardID
+func (_m *Repository) GetCardChecklists(cardID primitive.ObjectID) ([]*models.Checklist, error) {
+	ret := _m.Called(cardID)
+
+	var r0 []*models.Checklist
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.Checklist); ok {
+		r0 = rf(cardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Checklist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(cardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChecklistByID provides a mock function with given fields: cardID, checklistID
+func (_m *Repository) GetChecklistByID(cardID primitive.ObjectID, checklistID primitive.ObjectID) (*models.Checklist, error) {
+	ret := _m.Called(cardID, checklistID)
+
+	var r0 *models.Checklist
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) *models.Checklist); ok {
+		r0 = rf(cardID, checklistID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Checklist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(cardID, checklistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCardChecklists provides a mock function with given fields: cardID, update, activity
+func (_m *Repository) UpdateCardChecklists(cardID primitive.ObjectID, update func([]*models.Checklist) ([]*models.Checklist, error), activity *models.Activity) error {
+	ret := _m.Called(cardID, update, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, func([]*models.Checklist) ([]*models.Checklist, error), *models.Activity) error); ok {
+		r0 = rf(cardID, update, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,179 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// AddItem provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID, title
+func (_m *Usecase) AddItem(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID, title string) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID, title)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID, title)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ConvertItemToCard provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID, itemID
+func (_m *Usecase) ConvertItemToCard(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID, itemID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID, itemID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: requesterID, boardID, listID, cardID, title
+func (_m *Usecase) Create(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, title string) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, title)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, title)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID
+func (_m *Usecase) Delete(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteItem provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID, itemID
+func (_m *Usecase) DeleteItem(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID, itemID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID, itemID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID, itemID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetCardChecklists provides a mock function with given fields: requesterID, boardID, listID, cardID
+func (_m *Usecase) GetCardChecklists(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID) ([]*models.Checklist, error) {
+	ret := _m.Called(requesterID, boardID, listID, cardID)
+
+	var r0 []*models.Checklist
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) []*models.Checklist); ok {
+		r0 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Checklist)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(requesterID, boardID, listID, cardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateItemDone provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID, itemID, done
+func (_m *Usecase) UpdateItemDone(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID, itemID primitive.ObjectID, done bool) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID, itemID, done)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, bool) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID, itemID, done)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateItemPosition provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID, itemID, newPosition
+func (_m *Usecase) UpdateItemPosition(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID, itemID primitive.ObjectID, newPosition int) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID, itemID, newPosition)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, int) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID, itemID, newPosition)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateItemTitle provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID, itemID, title
+func (_m *Usecase) UpdateItemTitle(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID, itemID primitive.ObjectID, title string) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID, itemID, title)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID, itemID, title)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateTitle provides a mock function with given fields: requesterID, boardID, listID, cardID, checklistID, title
+func (_m *Usecase) UpdateTitle(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, checklistID primitive.ObjectID, title string) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, checklistID, title)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, checklistID, title)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsecase creates a new instance of Usecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsecase(t mockConstructorTestingTNewUsecase) *Usecase {
+	mock := &Usecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
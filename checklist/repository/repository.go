@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/checklist"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type checklistRepository struct {
+	dbClient *db.Client
+}
+
+func NewChecklistRepository(dbClient *db.Client) checklist.Repository {
+	return &checklistRepository{dbClient: dbClient}
+}
+
+// Create stores the checklist under its card, the progress of the
+// card stays the same since a new checklist has no items yet
+func (repo *checklistRepository) Create(checklist *models.Checklist, _activity *models.Activity) error {
+	checklist.ID = primitive.NewObjectID()
+	checklist.CreatedAt = time.Now()
+	checklist.UpdatedAt = checklist.CreatedAt
+
+	updates := map[string]interface{}{
+		fmt.Sprintf("checklists/%s/%s", checklist.CardID.Hex(), checklist.ID.Hex()): checklist,
+	}
+
+	if _activity != nil {
+		_activity.TargetID = checklist.ID
+	}
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *checklistRepository) GetChecklistByID(cardID, checklistID primitive.ObjectID) (*models.Checklist, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("checklists/%s/%s", cardID.Hex(), checklistID.Hex()))
+
+	checklist := &models.Checklist{}
+
+	err := ref.Get(ctx, &checklist)
+	if err != nil {
+		return nil, err
+	}
+
+	if checklist == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return checklist, nil
+}
+
+func (repo *checklistRepository) GetCardChecklists(cardID primitive.ObjectID) ([]*models.Checklist, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("checklists/%s", cardID.Hex()))
+
+	checklistsMap := make(map[string]*models.Checklist)
+
+	err := ref.Get(ctx, &checklistsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	checklists := []*models.Checklist{}
+
+	for _, checklist := range checklistsMap {
+		checklists = append(checklists, checklist)
+	}
+
+	return checklists, nil
+}
+
+// UpdateCardChecklists hands the checklists of the card to update while holding the card's checklist lock,
+// the checklists that update leaves out are deleted. The checklists are written together with the new
+// progress of the card and the release of the lock so the progress always matches the items.
+// The activity is written after update returns so update can still fill it in.
+func (repo *checklistRepository) UpdateCardChecklists(cardID primitive.ObjectID, update func(checklists []*models.Checklist) ([]*models.Checklist, error), _activity *models.Activity) error {
+	ctx := context.Background()
+	lockPath := checklist.LockPath(cardID)
+	lockRef := repo.dbClient.NewRef(lockPath)
+
+	lease, err := utils.AcquireLock(ctx, lockRef)
+	if err != nil {
+		return err
+	}
+
+	isReleased := false
+	defer func() {
+		if !isReleased {
+			utils.ReleaseLock(ctx, lockRef, lease)
+		}
+	}()
+
+	checklists, err := repo.GetCardChecklists(cardID)
+	if err != nil {
+		return err
+	}
+
+	updatedChecklists, err := update(checklists)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		lockPath: nil,
+	}
+
+	checklist.AddToUpdates(updates, cardID, checklists, updatedChecklists)
+	activity.AddToUpdates(updates, _activity)
+
+	ref := repo.dbClient.NewRef("")
+
+	err = ref.Update(ctx, updates)
+	if err != nil {
+		return err
+	}
+
+	isReleased = true
+
+	return nil
+}
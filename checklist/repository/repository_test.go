@@ -0,0 +1,49 @@
+package repository_test
+
+import (
+	"testing"
+
+	acr "github.com/jordyf15/thullo-api/activity/repository"
+	"github.com/jordyf15/thullo-api/checklist/repository"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChecklistChangesRecordActivities(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	assert.NoError(t, err)
+
+	checklistRepo := repository.NewChecklistRepository(dbClient)
+	activityRepo := acr.NewActivityRepository(dbClient)
+
+	boardID, cardID := primitive.NewObjectID(), primitive.NewObjectID()
+	_checklist := &models.Checklist{CardID: cardID, Title: "Todo", Items: []*models.ChecklistItem{}}
+
+	err = checklistRepo.Create(_checklist, &models.Activity{BoardID: boardID, CardID: &cardID, Verb: models.ActivityVerbCreated, Target: models.ActivityTargetChecklist})
+	assert.NoError(t, err)
+
+	err = checklistRepo.UpdateCardChecklists(cardID, func(checklists []*models.Checklist) ([]*models.Checklist, error) {
+		checklists[0].Title = "Done"
+		return checklists, nil
+	}, &models.Activity{BoardID: boardID, CardID: &cardID, Verb: models.ActivityVerbUpdated, Target: models.ActivityTargetChecklist, TargetID: _checklist.ID})
+	assert.NoError(t, err)
+
+	// each change is written together with its activity
+	assert.Equal(t, 2, server.Requests("PATCH", "/"))
+
+	activities, err := activityRepo.GetCardActivities(cardID)
+	assert.NoError(t, err)
+	assert.Len(t, activities, 2)
+	for _, _activity := range activities {
+		assert.Equal(t, _checklist.ID, _activity.TargetID)
+	}
+
+	storedChecklist, err := checklistRepo.GetChecklistByID(cardID, _checklist.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Done", storedChecklist.Title)
+}
@@ -0,0 +1,422 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/checklist"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/list"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type checklistUsecase struct {
+	checklistRepo   checklist.Repository
+	boardMemberRepo board_member.Repository
+	listRepo        list.Repository
+	cardRepo        card.Repository
+	eventRepo       event.Repository
+}
+
+func NewChecklistUsecase(checklistRepo checklist.Repository, boardMemberRepo board_member.Repository, listRepo list.Repository, cardRepo card.Repository, eventRepo event.Repository) checklist.Usecase {
+	return &checklistUsecase{checklistRepo: checklistRepo, boardMemberRepo: boardMemberRepo, listRepo: listRepo, cardRepo: cardRepo, eventRepo: eventRepo}
+}
+
+func (usecase *checklistUsecase) Create(requesterID, boardID, listID, cardID primitive.ObjectID, title string) error {
+	if title == "" {
+		return custom_errors.ErrChecklistTitleEmpty
+	}
+
+	_, card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_checklist := &models.Checklist{
+		CardID: card.ID,
+		Title:  title,
+		Items:  []*models.ChecklistItem{},
+	}
+
+	err = usecase.checklistRepo.Create(_checklist, &models.Activity{
+		BoardID: boardID,
+		CardID:  &card.ID,
+		ActorID: requesterID,
+		Verb:    models.ActivityVerbCreated,
+		Target:  models.ActivityTargetChecklist,
+		After:   _checklist,
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventChecklistCreated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _checklist,
+	})
+
+	return nil
+}
+
+func (usecase *checklistUsecase) GetCardChecklists(requesterID, boardID, listID, cardID primitive.ObjectID) ([]*models.Checklist, error) {
+	_, card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	checklists, err := usecase.checklistRepo.GetCardChecklists(card.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(checklists, func(i, j int) bool {
+		return checklists[i].CreatedAt.Before(checklists[j].CreatedAt)
+	})
+
+	return checklists, nil
+}
+
+func (usecase *checklistUsecase) UpdateTitle(requesterID, boardID, listID, cardID, checklistID primitive.ObjectID, title string) error {
+	if title == "" {
+		return custom_errors.ErrChecklistTitleEmpty
+	}
+
+	return usecase.updateChecklist(requesterID, boardID, listID, cardID, checklistID, func(_checklist *models.Checklist) error {
+		_checklist.Title = title
+		return nil
+	})
+}
+
+func (usecase *checklistUsecase) Delete(requesterID, boardID, listID, cardID, checklistID primitive.ObjectID) error {
+	_, _, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_activity := newChecklistActivity(requesterID, boardID, cardID, checklistID, models.ActivityVerbDeleted)
+
+	var deletedChecklist *models.Checklist
+	err = utils.RetryOnConflict(func() error {
+		return usecase.checklistRepo.UpdateCardChecklists(cardID, func(checklists []*models.Checklist) ([]*models.Checklist, error) {
+			remainingChecklists := []*models.Checklist{}
+			for _, _checklist := range checklists {
+				if _checklist.ID != checklistID {
+					remainingChecklists = append(remainingChecklists, _checklist)
+				} else {
+					deletedChecklist = _checklist
+				}
+			}
+
+			if len(remainingChecklists) == len(checklists) {
+				return nil, custom_errors.ErrRecordNotFound
+			}
+
+			_activity.Before = deletedChecklist
+
+			return remainingChecklists, nil
+		}, _activity)
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventChecklistDeleted,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    deletedChecklist,
+	})
+
+	return nil
+}
+
+func (usecase *checklistUsecase) AddItem(requesterID, boardID, listID, cardID, checklistID primitive.ObjectID, title string) error {
+	if title == "" {
+		return custom_errors.ErrChecklistItemTitleEmpty
+	}
+
+	return usecase.updateChecklist(requesterID, boardID, listID, cardID, checklistID, func(_checklist *models.Checklist) error {
+		_checklist.Items = append(_checklist.Items, &models.ChecklistItem{
+			ID:    primitive.NewObjectID(),
+			Title: title,
+		})
+
+		return nil
+	})
+}
+
+func (usecase *checklistUsecase) UpdateItemTitle(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID, title string) error {
+	if title == "" {
+		return custom_errors.ErrChecklistItemTitleEmpty
+	}
+
+	return usecase.updateChecklist(requesterID, boardID, listID, cardID, checklistID, func(_checklist *models.Checklist) error {
+		index := _checklist.ItemIndex(itemID)
+		if index < 0 {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		_checklist.Items[index].Title = title
+
+		return nil
+	})
+}
+
+func (usecase *checklistUsecase) UpdateItemDone(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID, done bool) error {
+	return usecase.updateChecklist(requesterID, boardID, listID, cardID, checklistID, func(_checklist *models.Checklist) error {
+		index := _checklist.ItemIndex(itemID)
+		if index < 0 {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		_checklist.Items[index].Done = done
+
+		return nil
+	})
+}
+
+func (usecase *checklistUsecase) UpdateItemPosition(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID, newPosition int) error {
+	if newPosition < 0 {
+		return custom_errors.ErrChecklistItemPositionTooLow
+	}
+
+	return usecase.updateChecklist(requesterID, boardID, listID, cardID, checklistID, func(_checklist *models.Checklist) error {
+		index := _checklist.ItemIndex(itemID)
+		if index < 0 {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		if newPosition >= len(_checklist.Items) {
+			return custom_errors.ErrChecklistItemPositionTooHigh
+		}
+
+		item := _checklist.Items[index]
+		items := append([]*models.ChecklistItem{}, _checklist.Items[:index]...)
+		items = append(items, _checklist.Items[index+1:]...)
+
+		_checklist.Items = append([]*models.ChecklistItem{}, items[:newPosition]...)
+		_checklist.Items = append(_checklist.Items, item)
+		_checklist.Items = append(_checklist.Items, items[newPosition:]...)
+
+		return nil
+	})
+}
+
+func (usecase *checklistUsecase) DeleteItem(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID) error {
+	return usecase.updateChecklist(requesterID, boardID, listID, cardID, checklistID, func(_checklist *models.Checklist) error {
+		index := _checklist.ItemIndex(itemID)
+		if index < 0 {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		_checklist.Items = append(_checklist.Items[:index], _checklist.Items[index+1:]...)
+
+		return nil
+	})
+}
+
+// ConvertItemToCard creates a card at the end of the card's list out of the item and removes the item
+// in the same update, so the item ends up either as a card or on its checklist but never both or neither
+func (usecase *checklistUsecase) ConvertItemToCard(requesterID, boardID, listID, cardID, checklistID, itemID primitive.ObjectID) error {
+	list, _card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if list.Archived {
+		return custom_errors.ErrListArchived
+	}
+
+	newCard := &models.Card{ListID: list.ID}
+	cardActivity := &models.Activity{
+		BoardID: boardID,
+		ActorID: requesterID,
+		Verb:    models.ActivityVerbCreated,
+		Target:  models.ActivityTargetCard,
+		After:   newCard,
+	}
+	checklistActivity := newChecklistActivity(requesterID, boardID, _card.ID, checklistID, models.ActivityVerbUpdated)
+
+	var updatedChecklist *models.Checklist
+	err = utils.RetryOnConflict(func() error {
+		return usecase.cardRepo.CreateFromChecklistItem(newCard, func(cards []*models.Card) ([]*models.Card, error) {
+			return card.Append(cards, newCard), nil
+		}, _card.ID, func(checklists []*models.Checklist) ([]*models.Checklist, error) {
+			for _, _checklist := range checklists {
+				if _checklist.ID != checklistID {
+					continue
+				}
+
+				index := _checklist.ItemIndex(itemID)
+				if index < 0 {
+					return nil, custom_errors.ErrRecordNotFound
+				}
+
+				item := _checklist.Items[index]
+				newCard.Title = item.Title
+				newCard.Done = item.Done
+
+				checklistActivity.Before = cloneChecklist(_checklist)
+				_checklist.Items = append(_checklist.Items[:index], _checklist.Items[index+1:]...)
+				_checklist.UpdatedAt = time.Now()
+				checklistActivity.After = _checklist
+				updatedChecklist = _checklist
+
+				return checklists, nil
+			}
+
+			return nil, custom_errors.ErrRecordNotFound
+		}, cardActivity, checklistActivity)
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardCreated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    newCard,
+	})
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventChecklistUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    updatedChecklist,
+	})
+
+	return nil
+}
+
+// updateChecklist applies update to the checklist while the checklists of the card are locked
+func (usecase *checklistUsecase) updateChecklist(requesterID, boardID, listID, cardID, checklistID primitive.ObjectID, update func(_checklist *models.Checklist) error) error {
+	_, card, err := usecase.getBoardCard(requesterID, boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	_activity := newChecklistActivity(requesterID, boardID, card.ID, checklistID, models.ActivityVerbUpdated)
+
+	var updatedChecklist *models.Checklist
+	err = utils.RetryOnConflict(func() error {
+		return usecase.checklistRepo.UpdateCardChecklists(card.ID, func(checklists []*models.Checklist) ([]*models.Checklist, error) {
+			for _, _checklist := range checklists {
+				if _checklist.ID != checklistID {
+					continue
+				}
+
+				_activity.Before = cloneChecklist(_checklist)
+
+				err := update(_checklist)
+				if err != nil {
+					return nil, err
+				}
+
+				_checklist.UpdatedAt = time.Now()
+				_activity.After = _checklist
+				updatedChecklist = _checklist
+
+				return checklists, nil
+			}
+
+			return nil, custom_errors.ErrRecordNotFound
+		}, _activity)
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventChecklistUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    updatedChecklist,
+	})
+
+	return nil
+}
+
+// newChecklistActivity returns the activity of a change to the checklist, its before and after are
+// filled in while the checklists are locked since that is when the checklist is read
+func newChecklistActivity(requesterID, boardID, cardID, checklistID primitive.ObjectID, verb string) *models.Activity {
+	return &models.Activity{
+		BoardID:  boardID,
+		CardID:   &cardID,
+		ActorID:  requesterID,
+		Verb:     verb,
+		Target:   models.ActivityTargetChecklist,
+		TargetID: checklistID,
+	}
+}
+
+// cloneChecklist copies the checklist along with its items so it keeps its state when the items are changed
+func cloneChecklist(_checklist *models.Checklist) *models.Checklist {
+	clone := *_checklist
+	clone.Items = make([]*models.ChecklistItem, len(_checklist.Items))
+	for i, item := range _checklist.Items {
+		_item := *item
+		clone.Items[i] = &_item
+	}
+
+	return &clone
+}
+
+func (usecase *checklistUsecase) getBoardCard(requesterID, boardID, listID, cardID primitive.ObjectID) (*models.List, *models.Card, error) {
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	list, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if list.BoardID != boardID {
+		return nil, nil, custom_errors.ErrRecordNotFound
+	}
+
+	card, err := usecase.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if card.ListID != listID {
+		return nil, nil, custom_errors.ErrRecordNotFound
+	}
+
+	return list, card, nil
+}
+
+func (usecase *checklistUsecase) checkIfRequesterIsMemberOfBoard(requesterID, boardID primitive.ObjectID) error {
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	// if there are no board members it means there are no board
+	// a board will always atleast have 1 member
+	if len(boardMembers) == 0 {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID {
+			return nil
+		}
+	}
+
+	return custom_errors.ErrNotAuthorized
+}
+
+// appendCard gives the card a rank after every card of the list, including the archived ones so
+// the card does not end up in front of them when they are restored. Only the new card has to be
+// written unless the ranks of the list had to be rebalanced, then every card is returned.
@@ -0,0 +1,393 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	cr "github.com/jordyf15/thullo-api/card/mocks"
+	"github.com/jordyf15/thullo-api/checklist"
+	chr "github.com/jordyf15/thullo-api/checklist/mocks"
+	"github.com/jordyf15/thullo-api/checklist/usecase"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	lr "github.com/jordyf15/thullo-api/list/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChecklistUsecase(t *testing.T) {
+	suite.Run(t, new(checklistUsecaseSuite))
+}
+
+var (
+	boardID = primitive.NewObjectID()
+
+	boardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		BoardID: boardID,
+	}
+
+	list1 = &models.List{
+		ID:      primitive.NewObjectID(),
+		BoardID: boardID,
+	}
+	list2 = &models.List{
+		ID:       primitive.NewObjectID(),
+		BoardID:  boardID,
+		Archived: true,
+	}
+
+	card1 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list1.ID,
+		Rank:   "i",
+	}
+	card2 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list2.ID,
+		Rank:   "i",
+	}
+
+	checklist1ID = primitive.NewObjectID()
+	checklist2ID = primitive.NewObjectID()
+	item1ID      = primitive.NewObjectID()
+	item2ID      = primitive.NewObjectID()
+	item3ID      = primitive.NewObjectID()
+)
+
+type checklistUsecaseSuite struct {
+	suite.Suite
+
+	usecase         checklist.Usecase
+	checklistRepo   *chr.Repository
+	boardMemberRepo *bmr.Repository
+	listRepo        *lr.Repository
+	cardRepo        *cr.Repository
+	eventRepo       *er.Repository
+
+	checklists        []*models.Checklist
+	createdChecklist  *models.Checklist
+	updatedChecklists []*models.Checklist
+	createdCard       *models.Card
+	activities        []*models.Activity
+	events            []*models.BoardEvent
+}
+
+func (s *checklistUsecaseSuite) SetupTest() {
+	s.checklistRepo = new(chr.Repository)
+	s.boardMemberRepo = new(bmr.Repository)
+	s.listRepo = new(lr.Repository)
+	s.cardRepo = new(cr.Repository)
+	s.eventRepo = new(er.Repository)
+
+	s.createdChecklist = nil
+	s.updatedChecklists = nil
+	s.createdCard = nil
+	s.activities = nil
+
+	now := time.Now()
+	s.checklists = []*models.Checklist{
+		{
+			ID:        checklist2ID,
+			CardID:    card1.ID,
+			Title:     "Review",
+			Items:     []*models.ChecklistItem{},
+			CreatedAt: now,
+		},
+		{
+			ID:     checklist1ID,
+			CardID: card1.ID,
+			Title:  "Todo",
+			Items: []*models.ChecklistItem{
+				{ID: item1ID, Title: "first", Done: true},
+				{ID: item2ID, Title: "second"},
+				{ID: item3ID, Title: "third"},
+			},
+			CreatedAt: now.Add(-time.Hour),
+		},
+	}
+
+	getBoardMembers := func(_boardID primitive.ObjectID) []*models.BoardMember {
+		if _boardID == boardID {
+			return []*models.BoardMember{boardMember}
+		}
+
+		return []*models.BoardMember{}
+	}
+
+	getListByID := func(listID primitive.ObjectID) *models.List {
+		if listID == list2.ID {
+			return list2
+		}
+
+		return list1
+	}
+
+	getCardByID := func(cardID primitive.ObjectID) *models.Card {
+		if cardID == card2.ID {
+			return card2
+		}
+
+		return card1
+	}
+
+	getChecklistByID := func(cardID, checklistID primitive.ObjectID) *models.Checklist {
+		for _, _checklist := range s.checklists {
+			if _checklist.ID == checklistID {
+				return _checklist
+			}
+		}
+
+		return nil
+	}
+
+	updateCardChecklists := func(cardID primitive.ObjectID, update func(checklists []*models.Checklist) ([]*models.Checklist, error), _activity *models.Activity) error {
+		updatedChecklists, err := update(s.checklists)
+		if err != nil {
+			return err
+		}
+
+		s.updatedChecklists = updatedChecklists
+		s.activities = append(s.activities, _activity)
+
+		return nil
+	}
+
+	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardMembers, nil)
+	s.listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(getListByID, nil)
+	s.cardRepo.On("GetCardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getCardByID, nil)
+	s.cardRepo.On("CreateFromChecklistItem", mock.AnythingOfType("*models.Card"), mock.Anything, mock.AnythingOfType("primitive.ObjectID"), mock.Anything, mock.AnythingOfType("*models.Activity"), mock.AnythingOfType("*models.Activity")).Return(func(card *models.Card, place func([]*models.Card) ([]*models.Card, error), itemCardID primitive.ObjectID, update func([]*models.Checklist) ([]*models.Checklist, error), cardActivity, checklistActivity *models.Activity) error {
+		updatedChecklists, err := update(s.checklists)
+		if err != nil {
+			return err
+		}
+
+		_, err = place([]*models.Card{card1})
+		if err != nil {
+			return err
+		}
+
+		s.createdCard = card
+		s.updatedChecklists = updatedChecklists
+		s.activities = append(s.activities, cardActivity, checklistActivity)
+
+		return nil
+	})
+	s.checklistRepo.On("Create", mock.AnythingOfType("*models.Checklist"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.createdChecklist = args[0].(*models.Checklist)
+		s.activities = append(s.activities, args[1].(*models.Activity))
+	}).Return(nil)
+	s.checklistRepo.On("GetChecklistByID", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(getChecklistByID, nil)
+	s.checklistRepo.On("GetCardChecklists", mock.AnythingOfType("primitive.ObjectID")).Return(func(cardID primitive.ObjectID) []*models.Checklist {
+		return s.checklists
+	}, nil)
+	s.checklistRepo.On("UpdateCardChecklists", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("func([]*models.Checklist) ([]*models.Checklist, error)"), mock.AnythingOfType("*models.Activity")).Return(updateCardChecklists)
+
+	s.events = []*models.BoardEvent{}
+	s.eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Run(func(args mock.Arguments) {
+		s.events = append(s.events, args[0].(*models.BoardEvent))
+	}).Return()
+
+	s.usecase = usecase.NewChecklistUsecase(s.checklistRepo, s.boardMemberRepo, s.listRepo, s.cardRepo, s.eventRepo)
+}
+
+func (s *checklistUsecaseSuite) TestCreateEmptyTitle() {
+	err := s.usecase.Create(boardMember.UserID, boardID, list1.ID, card1.ID, "")
+
+	assert.Equal(s.T(), custom_errors.ErrChecklistTitleEmpty.Error(), err.Error())
+	s.checklistRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *checklistUsecaseSuite) TestCreateNotAuthorized() {
+	err := s.usecase.Create(primitive.NewObjectID(), boardID, list1.ID, card1.ID, "Todo")
+
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.checklistRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *checklistUsecaseSuite) TestCreateCardNotInList() {
+	err := s.usecase.Create(boardMember.UserID, boardID, list1.ID, card2.ID, "Todo")
+
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.checklistRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *checklistUsecaseSuite) TestCreateSuccessful() {
+	err := s.usecase.Create(boardMember.UserID, boardID, list1.ID, card1.ID, "Todo")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), card1.ID, s.createdChecklist.CardID)
+	assert.Equal(s.T(), "Todo", s.createdChecklist.Title)
+	assert.Empty(s.T(), s.createdChecklist.Items)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbCreated, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetChecklist, s.activities[0].Target)
+	assert.Equal(s.T(), card1.ID, *s.activities[0].CardID)
+}
+
+func (s *checklistUsecaseSuite) TestGetCardChecklistsSortedByCreation() {
+	checklists, err := s.usecase.GetCardChecklists(boardMember.UserID, boardID, list1.ID, card1.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), checklists, 2)
+	assert.Equal(s.T(), checklist1ID, checklists[0].ID)
+	assert.Equal(s.T(), checklist2ID, checklists[1].ID)
+}
+
+func (s *checklistUsecaseSuite) TestUpdateTitleNotFound() {
+	err := s.usecase.UpdateTitle(boardMember.UserID, boardID, list1.ID, card1.ID, primitive.NewObjectID(), "Done")
+
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	assert.Nil(s.T(), s.updatedChecklists)
+}
+
+func (s *checklistUsecaseSuite) TestUpdateTitleSuccessful() {
+	err := s.usecase.UpdateTitle(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, "Done")
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedChecklists, 2)
+	assert.Equal(s.T(), "Done", s.updatedChecklists[1].Title)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbUpdated, s.activities[0].Verb)
+	assert.Equal(s.T(), checklist1ID, s.activities[0].TargetID)
+	assert.Equal(s.T(), "Todo", s.activities[0].Before.(*models.Checklist).Title)
+	assert.Equal(s.T(), "Done", s.activities[0].After.(*models.Checklist).Title)
+}
+
+func (s *checklistUsecaseSuite) TestDeleteNotFound() {
+	err := s.usecase.Delete(boardMember.UserID, boardID, list1.ID, card1.ID, primitive.NewObjectID())
+
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	assert.Nil(s.T(), s.updatedChecklists)
+}
+
+func (s *checklistUsecaseSuite) TestDeleteSuccessful() {
+	err := s.usecase.Delete(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedChecklists, 1)
+	assert.Equal(s.T(), checklist2ID, s.updatedChecklists[0].ID)
+	assert.Nil(s.T(), models.GetChecklistProgress(s.updatedChecklists))
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbDeleted, s.activities[0].Verb)
+	assert.Equal(s.T(), checklist1ID, s.activities[0].Before.(*models.Checklist).ID)
+}
+
+func (s *checklistUsecaseSuite) TestAddItemEmptyTitle() {
+	err := s.usecase.AddItem(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, "")
+
+	assert.Equal(s.T(), custom_errors.ErrChecklistItemTitleEmpty.Error(), err.Error())
+	s.checklistRepo.AssertNumberOfCalls(s.T(), "UpdateCardChecklists", 0)
+}
+
+func (s *checklistUsecaseSuite) TestAddItemSuccessful() {
+	err := s.usecase.AddItem(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, "fourth")
+
+	assert.NoError(s.T(), err)
+	items := s.updatedChecklists[1].Items
+	assert.Len(s.T(), items, 4)
+	assert.Equal(s.T(), "fourth", items[3].Title)
+	assert.False(s.T(), items[3].Done)
+	assert.Equal(s.T(), &models.ChecklistProgress{Done: 1, Total: 4}, models.GetChecklistProgress(s.updatedChecklists))
+}
+
+func (s *checklistUsecaseSuite) TestUpdateItemDoneItemNotFound() {
+	err := s.usecase.UpdateItemDone(boardMember.UserID, boardID, list1.ID, card1.ID, checklist2ID, item1ID, true)
+
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	assert.Nil(s.T(), s.updatedChecklists)
+}
+
+func (s *checklistUsecaseSuite) TestUpdateItemDoneSuccessful() {
+	err := s.usecase.UpdateItemDone(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item2ID, true)
+
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), s.updatedChecklists[1].Items[1].Done)
+	assert.Equal(s.T(), &models.ChecklistProgress{Done: 2, Total: 3}, models.GetChecklistProgress(s.updatedChecklists))
+	// the activity keeps the item as it was before it was checked off
+	assert.Len(s.T(), s.activities, 1)
+	assert.False(s.T(), s.activities[0].Before.(*models.Checklist).Items[1].Done)
+	assert.True(s.T(), s.activities[0].After.(*models.Checklist).Items[1].Done)
+
+	err = s.usecase.UpdateItemDone(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item1ID, false)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), &models.ChecklistProgress{Done: 1, Total: 3}, models.GetChecklistProgress(s.updatedChecklists))
+}
+
+func (s *checklistUsecaseSuite) TestUpdateItemPositionTooLow() {
+	err := s.usecase.UpdateItemPosition(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item3ID, -1)
+
+	assert.Equal(s.T(), custom_errors.ErrChecklistItemPositionTooLow.Error(), err.Error())
+}
+
+func (s *checklistUsecaseSuite) TestUpdateItemPositionTooHigh() {
+	err := s.usecase.UpdateItemPosition(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item3ID, 3)
+
+	assert.Equal(s.T(), custom_errors.ErrChecklistItemPositionTooHigh.Error(), err.Error())
+	assert.Nil(s.T(), s.updatedChecklists)
+}
+
+func (s *checklistUsecaseSuite) TestUpdateItemPositionSuccessful() {
+	err := s.usecase.UpdateItemPosition(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item3ID, 0)
+
+	assert.NoError(s.T(), err)
+	items := s.updatedChecklists[1].Items
+	assert.Equal(s.T(), []primitive.ObjectID{item3ID, item1ID, item2ID}, []primitive.ObjectID{items[0].ID, items[1].ID, items[2].ID})
+
+	err = s.usecase.UpdateItemPosition(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item3ID, 2)
+
+	assert.NoError(s.T(), err)
+	items = s.updatedChecklists[1].Items
+	assert.Equal(s.T(), []primitive.ObjectID{item1ID, item2ID, item3ID}, []primitive.ObjectID{items[0].ID, items[1].ID, items[2].ID})
+}
+
+func (s *checklistUsecaseSuite) TestDeleteItemSuccessful() {
+	err := s.usecase.DeleteItem(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item1ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedChecklists[1].Items, 2)
+	assert.Equal(s.T(), &models.ChecklistProgress{Done: 0, Total: 2}, models.GetChecklistProgress(s.updatedChecklists))
+}
+
+func (s *checklistUsecaseSuite) TestConvertItemToCardArchivedList() {
+	err := s.usecase.ConvertItemToCard(boardMember.UserID, boardID, list2.ID, card2.ID, checklist1ID, item2ID)
+
+	assert.Equal(s.T(), custom_errors.ErrListArchived.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "CreateFromChecklistItem", 0)
+}
+
+func (s *checklistUsecaseSuite) TestConvertItemToCardItemNotFound() {
+	err := s.usecase.ConvertItemToCard(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, primitive.NewObjectID())
+
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	assert.Nil(s.T(), s.createdCard)
+	assert.Nil(s.T(), s.updatedChecklists)
+}
+
+func (s *checklistUsecaseSuite) TestConvertItemToCardSuccessful() {
+	err := s.usecase.ConvertItemToCard(boardMember.UserID, boardID, list1.ID, card1.ID, checklist1ID, item2ID)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "second", s.createdCard.Title)
+	assert.Equal(s.T(), list1.ID, s.createdCard.ListID)
+	assert.Greater(s.T(), s.createdCard.Rank, card1.Rank)
+
+	items := s.updatedChecklists[1].Items
+	assert.Len(s.T(), items, 2)
+	assert.Equal(s.T(), -1, s.updatedChecklists[1].ItemIndex(item2ID))
+	// the card and the checklist without the item are written together
+	s.cardRepo.AssertNumberOfCalls(s.T(), "CreateFromChecklistItem", 1)
+	s.checklistRepo.AssertNumberOfCalls(s.T(), "UpdateCardChecklists", 0)
+	assert.Len(s.T(), s.activities, 2)
+	assert.Equal(s.T(), models.ActivityTargetCard, s.activities[0].Target)
+	assert.Equal(s.T(), models.ActivityTargetChecklist, s.activities[1].Target)
+	assert.Len(s.T(), s.events, 2)
+	assert.Equal(s.T(), models.BoardEventCardCreated, s.events[0].Type)
+	assert.Equal(s.T(), models.BoardEventChecklistUpdated, s.events[1].Type)
+}
@@ -5,15 +5,22 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	DefaultCardCommentsLimit = 20
+	MaxCardCommentsLimit     = 50
+)
+
 type Repository interface {
-	Create(comment *models.Comment) error
+	Create(comment *models.Comment, activity *models.Activity) error
 	GetCommentByID(commentID primitive.ObjectID) (*models.Comment, error)
-	Update(comment *models.Comment) error
-	DeleteCommentByID(commentID primitive.ObjectID) error
+	GetCardComments(cardID primitive.ObjectID) ([]*models.Comment, error)
+	Update(comment *models.Comment, activity *models.Activity) error
+	DeleteCommentByID(commentID primitive.ObjectID, activity *models.Activity) error
 }
 
 type Usecase interface {
 	Create(requesterID, boardID, listID, cardID primitive.ObjectID, comment string) error
+	GetCardComments(requesterID, boardID, listID, cardID primitive.ObjectID, cursor string, limit int) ([]*models.Comment, string, error)
 	Update(requesterID, boardID, listID, cardID, commentID primitive.ObjectID, comment string) error
 	Delete(requesterID, boardID, listID, cardID, commentID primitive.ObjectID) error
 }
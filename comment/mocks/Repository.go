@@ -14,13 +14,13 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: _a0
-func (_m *Repository) Create(_a0 *models.Comment) error {
-	ret := _m.Called(_a0)
+// Create provides a mock function with given fields: _a0, activity
+func (_m *Repository) Create(_a0 *models.Comment, activity *models.Activity) error {
+	ret := _m.Called(_a0, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Comment) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*models.Comment, *models.Activity) error); ok {
+		r0 = rf(_a0, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -28,13 +28,13 @@ func (_m *Repository) Create(_a0 *models.Comment) error {
 	return r0
 }
 
-// DeleteCommentByID provides a mock function with given fields: commentID
-func (_m *Repository) DeleteCommentByID(commentID primitive.ObjectID) error {
-	ret := _m.Called(commentID)
+// DeleteCommentByID provides a mock function with given fields: commentID, activity
+func (_m *Repository) DeleteCommentByID(commentID primitive.ObjectID, activity *models.Activity) error {
+	ret := _m.Called(commentID, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
-		r0 = rf(commentID)
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *models.Activity) error); ok {
+		r0 = rf(commentID, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -42,6 +42,29 @@ func (_m *Repository) DeleteCommentByID(commentID primitive.ObjectID) error {
 	return r0
 }
 
+// GetCardComments provides a mock function with given fields: cardID
+func (_m *Repository) GetCardComments(cardID primitive.ObjectID) ([]*models.Comment, error) {
+	ret := _m.Called(cardID)
+
+	var r0 []*models.Comment
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.Comment); ok {
+		r0 = rf(cardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Comment)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(cardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCommentByID provides a mock function with given fields: commentID
 func (_m *Repository) GetCommentByID(commentID primitive.ObjectID) (*models.Comment, error) {
 	ret := _m.Called(commentID)
@@ -65,13 +88,13 @@ func (_m *Repository) GetCommentByID(commentID primitive.ObjectID) (*models.Comm
 	return r0, r1
 }
 
-// Update provides a mock function with given fields: _a0
-func (_m *Repository) Update(_a0 *models.Comment) error {
-	ret := _m.Called(_a0)
+// Update provides a mock function with given fields: _a0, activity
+func (_m *Repository) Update(_a0 *models.Comment, activity *models.Activity) error {
+	ret := _m.Called(_a0, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.Comment) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*models.Comment, *models.Activity) error); ok {
+		r0 = rf(_a0, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
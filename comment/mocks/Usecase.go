@@ -3,7 +3,9 @@
 package mocks
 
 import (
+	models "github.com/jordyf15/thullo-api/models"
 	mock "github.com/stretchr/testify/mock"
+
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -40,6 +42,36 @@ func (_m *Usecase) Delete(requesterID primitive.ObjectID, boardID primitive.Obje
 	return r0
 }
 
+// GetCardComments provides a mock function with given fields: requesterID, boardID, listID, cardID, cursor, limit
+func (_m *Usecase) GetCardComments(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, cursor string, limit int) ([]*models.Comment, string, error) {
+	ret := _m.Called(requesterID, boardID, listID, cardID, cursor, limit)
+
+	var r0 []*models.Comment
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string, int) []*models.Comment); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, cursor, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Comment)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string, int) string); ok {
+		r1 = rf(requesterID, boardID, listID, cardID, cursor, limit)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string, int) error); ok {
+		r2 = rf(requesterID, boardID, listID, cardID, cursor, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: requesterID, boardID, listID, cardID, commentID, _a5
 func (_m *Usecase) Update(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, commentID primitive.ObjectID, _a5 string) error {
 	ret := _m.Called(requesterID, boardID, listID, cardID, commentID, _a5)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
 	"github.com/jordyf15/thullo-api/comment"
 	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/models"
@@ -20,15 +21,24 @@ func NewCommentRepository(dbClient *db.Client) comment.Repository {
 	return &commentRepository{dbClient: dbClient}
 }
 
-func (repo *commentRepository) Create(comment *models.Comment) error {
+func (repo *commentRepository) Create(comment *models.Comment, _activity *models.Activity) error {
 	comment.ID = primitive.NewObjectID()
 	comment.CreatedAt = time.Now()
 	comment.UpdatedAt = comment.CreatedAt
 
+	updates := map[string]interface{}{
+		fmt.Sprintf("comments/%s", comment.ID.Hex()): comment,
+	}
+
+	if _activity != nil {
+		_activity.TargetID = comment.ID
+	}
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("comments/%s", comment.ID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Set(ctx, comment)
+	return ref.Update(ctx, updates)
 }
 
 func (repo *commentRepository) GetCommentByID(commentID primitive.ObjectID) (*models.Comment, error) {
@@ -49,18 +59,50 @@ func (repo *commentRepository) GetCommentByID(commentID primitive.ObjectID) (*mo
 	return comment, nil
 }
 
-func (repo *commentRepository) Update(comment *models.Comment) error {
+func (repo *commentRepository) GetCardComments(cardID primitive.ObjectID) ([]*models.Comment, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("comments").OrderByChild("card_id").EqualTo(cardID.Hex())
+
+	commentsMap := make(map[string]*models.Comment)
+
+	err := ref.Get(ctx, &commentsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	comments := []*models.Comment{}
+
+	for _, comment := range commentsMap {
+		comments = append(comments, comment)
+	}
+
+	return comments, nil
+}
+
+func (repo *commentRepository) Update(comment *models.Comment, _activity *models.Activity) error {
 	comment.UpdatedAt = time.Now()
 
+	updates := map[string]interface{}{
+		fmt.Sprintf("comments/%s", comment.ID.Hex()): comment,
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("comments/%s", comment.ID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Set(ctx, comment)
+	return ref.Update(ctx, updates)
 }
 
-func (repo *commentRepository) DeleteCommentByID(commentID primitive.ObjectID) error {
+func (repo *commentRepository) DeleteCommentByID(commentID primitive.ObjectID, _activity *models.Activity) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("comments/%s", commentID.Hex()): nil,
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("comments/%s", commentID.Hex()))
+	ref := repo.dbClient.NewRef("")
 
-	return ref.Delete(ctx)
+	return ref.Update(ctx, updates)
 }
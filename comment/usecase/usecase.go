@@ -1,26 +1,38 @@
 package usecase
 
 import (
+	"sort"
+
 	"github.com/jordyf15/thullo-api/board"
 	"github.com/jordyf15/thullo-api/board_member"
 	"github.com/jordyf15/thullo-api/card"
 	"github.com/jordyf15/thullo-api/comment"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
 	"github.com/jordyf15/thullo-api/list"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/notification"
+	"github.com/jordyf15/thullo-api/storage"
+	"github.com/jordyf15/thullo-api/user"
+	"github.com/jordyf15/thullo-api/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 type commentUsecase struct {
-	commentRepo     comment.Repository
-	boardMemberRepo board_member.Repository
-	boardRepo       board.Repository
-	cardRepo        card.Repository
-	listRepo        list.Repository
+	commentRepo      comment.Repository
+	boardMemberRepo  board_member.Repository
+	boardRepo        board.Repository
+	cardRepo         card.Repository
+	listRepo         list.Repository
+	userRepo         user.Repository
+	notificationRepo notification.Repository
+	eventRepo        event.Repository
+	storage          storage.Storage
 }
 
-func NewCommentUsecase(boardMemberRepo board_member.Repository, cardRepo card.Repository, commentRepo comment.Repository, boardRepo board.Repository, listRepo list.Repository) comment.Usecase {
-	return &commentUsecase{boardMemberRepo: boardMemberRepo, cardRepo: cardRepo, commentRepo: commentRepo, boardRepo: boardRepo, listRepo: listRepo}
+func NewCommentUsecase(boardMemberRepo board_member.Repository, cardRepo card.Repository, commentRepo comment.Repository, boardRepo board.Repository, listRepo list.Repository, userRepo user.Repository, notificationRepo notification.Repository, eventRepo event.Repository, storage storage.Storage) comment.Usecase {
+	return &commentUsecase{boardMemberRepo: boardMemberRepo, cardRepo: cardRepo, commentRepo: commentRepo, boardRepo: boardRepo, listRepo: listRepo, userRepo: userRepo, notificationRepo: notificationRepo, eventRepo: eventRepo, storage: storage}
 }
 
 func (usecase *commentUsecase) Create(requesterID, boardID, listID, cardID primitive.ObjectID, comment string) error {
@@ -70,13 +82,68 @@ func (usecase *commentUsecase) Create(requesterID, boardID, listID, cardID primi
 		return custom_errors.ErrRecordNotFound
 	}
 
+	mentionedUserIDs, err := usecase.getMentionedMembers(boardID, comment)
+	if err != nil {
+		return err
+	}
+
 	_comment := &models.Comment{
-		AuthorID: requesterID,
-		CardID:   cardID,
-		Comment:  comment,
+		AuthorID:         requesterID,
+		CardID:           cardID,
+		Comment:          comment,
+		MentionedUserIDs: mentionedUserIDs,
+	}
+
+	err = usecase.commentRepo.Create(_comment, &models.Activity{
+		BoardID: boardID,
+		CardID:  &cardID,
+		ActorID: requesterID,
+		Verb:    models.ActivityVerbCreated,
+		Target:  models.ActivityTargetComment,
+		After:   _comment,
+	})
+	if err != nil {
+		return err
 	}
 
-	err = usecase.commentRepo.Create(_comment)
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCommentCreated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _comment,
+	})
+
+	notifications := getMentionNotifications(boardID, listID, _comment, nil)
+
+	// members that are mentioned already get a mention notification for the comment
+	isNotified := map[primitive.ObjectID]bool{
+		requesterID: true,
+	}
+	for _, userID := range mentionedUserIDs {
+		isNotified[userID] = true
+	}
+
+	// assignees and watchers are unassigned and unwatched when they are removed from the board
+	// so they do not have to be checked against the board members
+	followerIDs := append(append([]primitive.ObjectID{}, card.AssigneeIDs...), card.WatcherIDs...)
+	for _, followerID := range followerIDs {
+		if isNotified[followerID] {
+			continue
+		}
+
+		isNotified[followerID] = true
+		notifications = append(notifications, &models.Notification{
+			UserID:    followerID,
+			ActorID:   requesterID,
+			Type:      models.NotificationTypeCardComment,
+			BoardID:   boardID,
+			ListID:    &listID,
+			CardID:    &cardID,
+			CommentID: &_comment.ID,
+		})
+	}
+
+	err = usecase.notificationRepo.Create(notifications)
 	if err != nil {
 		return err
 	}
@@ -84,6 +151,117 @@ func (usecase *commentUsecase) Create(requesterID, boardID, listID, cardID primi
 	return nil
 }
 
+func (usecase *commentUsecase) GetCardComments(requesterID, boardID, listID, cardID primitive.ObjectID, cursor string, limit int) ([]*models.Comment, string, error) {
+	if limit <= 0 {
+		limit = comment.DefaultCardCommentsLimit
+	} else if limit > comment.MaxCardCommentsLimit {
+		limit = comment.MaxCardCommentsLimit
+	}
+
+	var cursorComment *models.Comment
+	if cursor != "" {
+		cursorID, cursorCreatedAt, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", err
+		}
+
+		cursorComment = &models.Comment{ID: cursorID, CreatedAt: cursorCreatedAt}
+	}
+
+	board, err := usecase.boardRepo.GetBoardByID(boardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if board.Visibility == models.BoardVisibilityPrivate {
+		boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+		if err != nil {
+			return nil, "", err
+		}
+
+		isRequesterBoardMember := false
+		for _, boardMember := range boardMembers {
+			if boardMember.UserID == requesterID {
+				isRequesterBoardMember = true
+				break
+			}
+		}
+
+		if !isRequesterBoardMember {
+			return nil, "", custom_errors.ErrNotAuthorized
+		}
+	}
+
+	list, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if list.BoardID != boardID {
+		return nil, "", custom_errors.ErrRecordNotFound
+	}
+
+	card, err := usecase.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if card.ListID != listID {
+		return nil, "", custom_errors.ErrRecordNotFound
+	}
+
+	comments, err := usecase.commentRepo.GetCardComments(cardID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		return utils.IsNewerRecord(comments[i].ID, comments[i].CreatedAt, comments[j].ID, comments[j].CreatedAt)
+	})
+
+	start := 0
+	if cursorComment != nil {
+		start = len(comments)
+		for i, _comment := range comments {
+			if utils.IsNewerRecord(cursorComment.ID, cursorComment.CreatedAt, _comment.ID, _comment.CreatedAt) {
+				start = i
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end > len(comments) {
+		end = len(comments)
+	}
+
+	page := comments[start:end]
+
+	// a thread usually has a handful of authors, each of them is only fetched once
+	authors := make(map[primitive.ObjectID]*models.CommentAuthor)
+	for _, _comment := range page {
+		author, isExist := authors[_comment.AuthorID]
+		if !isExist {
+			author, err = usecase.getCommentAuthor(_comment.AuthorID)
+			if err != nil {
+				return nil, "", err
+			}
+
+			authors[_comment.AuthorID] = author
+		}
+
+		_comment.Author = author
+	}
+
+	nextCursor := ""
+	if end < len(comments) {
+		last := page[len(page)-1]
+		nextCursor = utils.EncodeCursor(last.ID, last.CreatedAt)
+	}
+
+	return page, nextCursor, nil
+}
+
 func (usecase *commentUsecase) Update(requesterID, boardID, listID, cardID, commentID primitive.ObjectID, comment string) error {
 	if comment == "" {
 		return custom_errors.ErrCommentEmpty
@@ -144,9 +322,40 @@ func (usecase *commentUsecase) Update(requesterID, boardID, listID, cardID, comm
 		return custom_errors.ErrNotAuthorized
 	}
 
+	mentionedUserIDs, err := usecase.getMentionedMembers(boardID, comment)
+	if err != nil {
+		return err
+	}
+
+	_activity := &models.Activity{
+		BoardID:  boardID,
+		CardID:   &cardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUpdated,
+		Target:   models.ActivityTargetComment,
+		TargetID: commentID,
+		Before:   map[string]interface{}{"comment": commentObj.Comment},
+		After:    map[string]interface{}{"comment": comment},
+	}
+
+	previousMentionedUserIDs := commentObj.MentionedUserIDs
 	commentObj.Comment = comment
+	commentObj.MentionedUserIDs = mentionedUserIDs
+
+	err = usecase.commentRepo.Update(commentObj, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCommentUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    commentObj,
+	})
 
-	err = usecase.commentRepo.Update(commentObj)
+	// members that were already mentioned before the edit have been notified already
+	err = usecase.notificationRepo.Create(getMentionNotifications(boardID, listID, commentObj, previousMentionedUserIDs))
 	if err != nil {
 		return err
 	}
@@ -209,10 +418,119 @@ func (usecase *commentUsecase) Delete(requesterID, boardID, listID, cardID, comm
 		return custom_errors.ErrNotAuthorized
 	}
 
-	err = usecase.commentRepo.DeleteCommentByID(commentID)
+	err = usecase.commentRepo.DeleteCommentByID(commentID, &models.Activity{
+		BoardID:  boardID,
+		CardID:   &cardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbDeleted,
+		Target:   models.ActivityTargetComment,
+		TargetID: commentID,
+		Before:   comment,
+	})
 	if err != nil {
 		return err
 	}
 
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCommentDeleted,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    comment,
+	})
+
 	return nil
 }
+
+// getMentionedMembers resolves the usernames mentioned in the text to the board members that have them,
+// users that are not a member are ignored the same way as usernames that nobody has
+func (usecase *commentUsecase) getMentionedMembers(boardID primitive.ObjectID, text string) ([]primitive.ObjectID, error) {
+	mentionedUserIDs := []primitive.ObjectID{}
+
+	usernames := utils.ParseMentions(text)
+	if len(usernames) == 0 {
+		return mentionedUserIDs, nil
+	}
+
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	isBoardMember := make(map[primitive.ObjectID]bool)
+	for _, boardMember := range boardMembers {
+		isBoardMember[boardMember.UserID] = true
+	}
+
+	for _, username := range usernames {
+		mentionedUser, err := usecase.userRepo.GetByUsername(username)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if isBoardMember[mentionedUser.ID] {
+			mentionedUserIDs = append(mentionedUserIDs, mentionedUser.ID)
+		}
+	}
+
+	return mentionedUserIDs, nil
+}
+
+// getMentionNotifications returns the notifications for the members mentioned in the comment except for its author
+// and the members that are in previousMentionedUserIDs
+func getMentionNotifications(boardID, listID primitive.ObjectID, _comment *models.Comment, previousMentionedUserIDs []primitive.ObjectID) []*models.Notification {
+	isNotified := map[primitive.ObjectID]bool{
+		_comment.AuthorID: true,
+	}
+	for _, userID := range previousMentionedUserIDs {
+		isNotified[userID] = true
+	}
+
+	notifications := []*models.Notification{}
+	for _, userID := range _comment.MentionedUserIDs {
+		if isNotified[userID] {
+			continue
+		}
+
+		notifications = append(notifications, &models.Notification{
+			UserID:    userID,
+			ActorID:   _comment.AuthorID,
+			Type:      models.NotificationTypeCommentMention,
+			BoardID:   boardID,
+			ListID:    &listID,
+			CardID:    &_comment.CardID,
+			CommentID: &_comment.ID,
+		})
+	}
+
+	return notifications
+}
+
+// getCommentAuthor returns nil for authors whose account no longer exists so their comments can still be read
+func (usecase *commentUsecase) getCommentAuthor(authorID primitive.ObjectID) (*models.CommentAuthor, error) {
+	author, err := usecase.userRepo.GetByID(authorID)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	err = usecase.storage.AssignImageURLToUser(author)
+	if err != nil {
+		return nil, err
+	}
+
+	author.EmptyImageIDs()
+
+	return &models.CommentAuthor{
+		ID:       author.ID,
+		Username: author.Username,
+		Name:     author.Name,
+		Images:   author.Images,
+	}, nil
+}
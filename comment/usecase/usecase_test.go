@@ -2,6 +2,7 @@ package usecase_test
 
 import (
 	"testing"
+	"time"
 
 	br "github.com/jordyf15/thullo-api/board/mocks"
 	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
@@ -10,12 +11,17 @@ import (
 	cmr "github.com/jordyf15/thullo-api/comment/mocks"
 	"github.com/jordyf15/thullo-api/comment/usecase"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
 	lr "github.com/jordyf15/thullo-api/list/mocks"
 	"github.com/jordyf15/thullo-api/models"
+	nr "github.com/jordyf15/thullo-api/notification/mocks"
+	sr "github.com/jordyf15/thullo-api/storage/mocks"
+	ur "github.com/jordyf15/thullo-api/user/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 )
 
 func TestCommentUsecase(t *testing.T) {
@@ -98,11 +104,20 @@ type commentUsecaseSuite struct {
 
 	usecase comment.Usecase
 
-	commentRepo     *cmr.Repository
-	boardMemberRepo *bmr.Repository
-	boardRepo       *br.Repository
-	cardRepo        *cr.Repository
-	listRepo        *lr.Repository
+	commentRepo      *cmr.Repository
+	boardMemberRepo  *bmr.Repository
+	boardRepo        *br.Repository
+	cardRepo         *cr.Repository
+	listRepo         *lr.Repository
+	userRepo         *ur.Repository
+	notificationRepo *nr.Repository
+	eventRepo        *er.Repository
+	storage          *sr.Storage
+
+	createdComment *models.Comment
+	notifications  []*models.Notification
+	events         []*models.BoardEvent
+	activities     []*models.Activity
 }
 
 func (s *commentUsecaseSuite) SetupTest() {
@@ -111,6 +126,26 @@ func (s *commentUsecaseSuite) SetupTest() {
 	s.boardRepo = new(br.Repository)
 	s.cardRepo = new(cr.Repository)
 	s.listRepo = new(lr.Repository)
+	s.userRepo = new(ur.Repository)
+	s.notificationRepo = new(nr.Repository)
+	s.eventRepo = new(er.Repository)
+	s.storage = new(sr.Storage)
+
+	// need to reset previous mentions and card followers
+	comment1.Comment = ""
+	comment1.MentionedUserIDs = nil
+	card1.AssigneeIDs = nil
+	card1.WatcherIDs = nil
+
+	now := time.Now()
+	comment1.CreatedAt = now.Add(-2 * time.Hour)
+	comment4.CreatedAt = now.Add(-time.Hour)
+	comment5 := &models.Comment{
+		ID:        primitive.NewObjectID(),
+		AuthorID:  boardMember1.UserID,
+		CardID:    card1.ID,
+		CreatedAt: now,
+	}
 
 	getBoardByID := func(boardID primitive.ObjectID) *models.Board {
 		if boardID == board1.ID {
@@ -152,16 +187,76 @@ func (s *commentUsecaseSuite) SetupTest() {
 		return comment3
 	}
 
+	s.activities = []*models.Activity{}
+	recordActivity := func(args mock.Arguments) {
+		if _activity, _ := args.Get(len(args) - 1).(*models.Activity); _activity != nil {
+			s.activities = append(s.activities, _activity)
+		}
+	}
+
 	s.boardRepo.On("GetBoardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardByID, nil)
 	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.BoardMember{boardMember1, boardMember2}, nil)
 	s.cardRepo.On("GetCardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getCardByID, nil)
-	s.commentRepo.On("Create", mock.AnythingOfType("*models.Comment")).Return(nil)
+	s.createdComment = nil
+	s.commentRepo.On("Create", mock.AnythingOfType("*models.Comment"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		recordActivity(args)
+		s.createdComment = args[0].(*models.Comment)
+	}).Return(nil)
 	s.commentRepo.On("GetCommentByID", mock.AnythingOfType("primitive.ObjectID")).Return(getCommentByID, nil)
-	s.commentRepo.On("Update", mock.AnythingOfType("*models.Comment")).Return(nil)
-	s.commentRepo.On("DeleteCommentByID", mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.commentRepo.On("Update", mock.AnythingOfType("*models.Comment"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.commentRepo.On("DeleteCommentByID", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
 	s.listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(getListByID, nil)
+	s.commentRepo.On("GetCardComments", mock.AnythingOfType("primitive.ObjectID")).Return(func(cardID primitive.ObjectID) []*models.Comment {
+		if cardID == card1.ID {
+			return []*models.Comment{comment1, comment5, comment4}
+		}
+
+		return []*models.Comment{comment2}
+	}, nil)
+	s.userRepo.On("GetByID", mock.AnythingOfType("primitive.ObjectID")).Return(func(userID primitive.ObjectID) *models.User {
+		if userID == boardMember2.UserID {
+			return nil
+		}
 
-	s.usecase = usecase.NewCommentUsecase(s.boardMemberRepo, s.cardRepo, s.commentRepo, s.boardRepo, s.listRepo)
+		return &models.User{ID: userID, Username: "author", Name: "Author", Images: models.Images{{ID: "image-1"}}}
+	}, func(userID primitive.ObjectID) error {
+		if userID == boardMember2.UserID {
+			return mongo.ErrNoDocuments
+		}
+
+		return nil
+	})
+	s.userRepo.On("GetByUsername", mock.AnythingOfType("string")).Return(func(username string) *models.User {
+		switch username {
+		case "member1":
+			return &models.User{ID: boardMember1.UserID, Username: username}
+		case "member2":
+			return &models.User{ID: boardMember2.UserID, Username: username}
+		case "outsider":
+			return &models.User{ID: primitive.NewObjectID(), Username: username}
+		}
+
+		return nil
+	}, func(username string) error {
+		switch username {
+		case "member1", "member2", "outsider":
+			return nil
+		}
+
+		return mongo.ErrNoDocuments
+	})
+	s.storage.On("AssignImageURLToUser", mock.AnythingOfType("*models.User")).Return(nil)
+	s.notifications = nil
+	s.notificationRepo.On("Create", mock.AnythingOfType("[]*models.Notification")).Run(func(args mock.Arguments) {
+		s.notifications = append(s.notifications, args[0].([]*models.Notification)...)
+	}).Return(nil)
+
+	s.events = []*models.BoardEvent{}
+	s.eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Run(func(args mock.Arguments) {
+		s.events = append(s.events, args[0].(*models.BoardEvent))
+	}).Return()
+
+	s.usecase = usecase.NewCommentUsecase(s.boardMemberRepo, s.cardRepo, s.commentRepo, s.boardRepo, s.listRepo, s.userRepo, s.notificationRepo, s.eventRepo, s.storage)
 }
 
 func (s *commentUsecaseSuite) TestCreateEmptyComment() {
@@ -226,6 +321,61 @@ func (s *commentUsecaseSuite) TestCreateOnPublicBoardSuccessful() {
 	s.boardMemberRepo.AssertNumberOfCalls(s.T(), "GetBoardMembers", 0)
 	s.listRepo.AssertNumberOfCalls(s.T(), "GetListByID", 1)
 	s.cardRepo.AssertNumberOfCalls(s.T(), "GetCardByID", 1)
+	assert.Len(s.T(), s.events, 1)
+	assert.Equal(s.T(), models.BoardEventCommentCreated, s.events[0].Type)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbCreated, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetComment, s.activities[0].Target)
+	assert.Equal(s.T(), card2.ID, *s.activities[0].CardID)
+	assert.Equal(s.T(), s.createdComment, s.activities[0].After)
+}
+
+func (s *commentUsecaseSuite) TestCreateWithMentions() {
+	err := s.usecase.Create(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "@member2 can you check this? cc @member1 @outsider @nobody")
+
+	assert.NoError(s.T(), err)
+	s.commentRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember2.UserID, boardMember1.UserID}, s.createdComment.MentionedUserIDs)
+	assert.Len(s.T(), s.notifications, 1)
+	assert.Equal(s.T(), boardMember2.UserID, s.notifications[0].UserID)
+	assert.Equal(s.T(), boardMember1.UserID, s.notifications[0].ActorID)
+	assert.Equal(s.T(), models.NotificationTypeCommentMention, s.notifications[0].Type)
+	assert.Equal(s.T(), card1.ID, *s.notifications[0].CardID)
+	assert.Equal(s.T(), s.createdComment.ID, *s.notifications[0].CommentID)
+}
+
+func (s *commentUsecaseSuite) TestCreateWithoutMentions() {
+	err := s.usecase.Create(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "mail me at member2@example.com")
+
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), s.createdComment.MentionedUserIDs)
+	assert.Len(s.T(), s.notifications, 0)
+	s.userRepo.AssertNumberOfCalls(s.T(), "GetByUsername", 0)
+}
+
+func (s *commentUsecaseSuite) TestCreateNotifyAssigneesAndWatchers() {
+	assigneeID := primitive.NewObjectID()
+	watcherID := primitive.NewObjectID()
+	card1.AssigneeIDs = []primitive.ObjectID{assigneeID, boardMember1.UserID, boardMember2.UserID}
+	card1.WatcherIDs = []primitive.ObjectID{watcherID, assigneeID}
+
+	err := s.usecase.Create(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "@member2 is this done?")
+
+	assert.NoError(s.T(), err)
+	s.notificationRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	assert.Len(s.T(), s.notifications, 3)
+
+	notificationTypes := make(map[primitive.ObjectID]string)
+	for _, notification := range s.notifications {
+		notificationTypes[notification.UserID] = notification.Type
+		assert.Equal(s.T(), s.createdComment.ID, *notification.CommentID)
+	}
+
+	assert.Equal(s.T(), map[primitive.ObjectID]string{
+		boardMember2.UserID: models.NotificationTypeCommentMention,
+		assigneeID:          models.NotificationTypeCardComment,
+		watcherID:           models.NotificationTypeCardComment,
+	}, notificationTypes)
 }
 
 func (s *commentUsecaseSuite) TestUpdateEmptyComment() {
@@ -318,6 +468,33 @@ func (s *commentUsecaseSuite) TestUpdateCommentOnPrivateBoardSuccessful() {
 	s.commentRepo.AssertNumberOfCalls(s.T(), "Update", 1)
 }
 
+func (s *commentUsecaseSuite) TestUpdateCommentOnlyNotifyNewMentions() {
+	comment1.Comment = "@member2 please check"
+	comment1.MentionedUserIDs = []primitive.ObjectID{boardMember2.UserID}
+
+	err := s.usecase.Update(boardMember1.UserID, board1.ID, list1.ID, card1.ID, comment1.ID, "@member2 please check this again")
+
+	assert.NoError(s.T(), err)
+	s.commentRepo.AssertNumberOfCalls(s.T(), "Update", 1)
+	assert.Equal(s.T(), []primitive.ObjectID{boardMember2.UserID}, comment1.MentionedUserIDs)
+	assert.Len(s.T(), s.notifications, 0)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), comment1.ID, s.activities[0].TargetID)
+	assert.Equal(s.T(), map[string]interface{}{"comment": "@member2 please check"}, s.activities[0].Before)
+	assert.Equal(s.T(), map[string]interface{}{"comment": "@member2 please check this again"}, s.activities[0].After)
+}
+
+func (s *commentUsecaseSuite) TestUpdateCommentRemovedMentions() {
+	comment1.Comment = "@member2 please check"
+	comment1.MentionedUserIDs = []primitive.ObjectID{boardMember2.UserID}
+
+	err := s.usecase.Update(boardMember1.UserID, board1.ID, list1.ID, card1.ID, comment1.ID, "never mind")
+
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), comment1.MentionedUserIDs)
+	assert.Len(s.T(), s.notifications, 0)
+}
+
 func (s *commentUsecaseSuite) TestUpdateCommentOnPublicBoardSuccessful() {
 	err := s.usecase.Update(boardMember1.UserID, board2.ID, list2.ID, card2.ID, comment2.ID, "updated comment 1")
 
@@ -416,4 +593,61 @@ func (s *commentUsecaseSuite) TestDeleteCommentAsAdmin() {
 	s.cardRepo.AssertNumberOfCalls(s.T(), "GetCardByID", 1)
 	s.commentRepo.AssertNumberOfCalls(s.T(), "GetCommentByID", 1)
 	s.commentRepo.AssertNumberOfCalls(s.T(), "DeleteCommentByID", 1)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbDeleted, s.activities[0].Verb)
+	assert.Equal(s.T(), boardMember1.UserID, s.activities[0].ActorID)
+	assert.Equal(s.T(), comment4, s.activities[0].Before)
+}
+
+func (s *commentUsecaseSuite) TestGetCardCommentsNotAuthorized() {
+	comments, _, err := s.usecase.GetCardComments(primitive.NewObjectID(), board1.ID, list1.ID, card1.ID, "", 0)
+
+	assert.Nil(s.T(), comments)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.commentRepo.AssertNumberOfCalls(s.T(), "GetCardComments", 0)
+}
+
+func (s *commentUsecaseSuite) TestGetCardCommentsPublicBoard() {
+	comments, nextCursor, err := s.usecase.GetCardComments(primitive.NewObjectID(), board2.ID, list2.ID, card2.ID, "", 0)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), comments, 1)
+	assert.Empty(s.T(), nextCursor)
+}
+
+func (s *commentUsecaseSuite) TestGetCardCommentsInvalidCursor() {
+	comments, _, err := s.usecase.GetCardComments(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "not-a-cursor", 0)
+
+	assert.Nil(s.T(), comments)
+	assert.Equal(s.T(), custom_errors.ErrInvalidCursor.Error(), err.Error())
+}
+
+func (s *commentUsecaseSuite) TestGetCardCommentsPaginated() {
+	comments, nextCursor, err := s.usecase.GetCardComments(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "", 2)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), comments, 2)
+	assert.Equal(s.T(), comment4.ID, comments[1].ID)
+	assert.NotEmpty(s.T(), nextCursor)
+
+	assert.Equal(s.T(), "author", comments[0].Author.Username)
+	assert.Empty(s.T(), comments[0].Author.Images[0].ID)
+	// the author of comment4 no longer exists
+	assert.Nil(s.T(), comments[1].Author)
+
+	comments, nextCursor, err = s.usecase.GetCardComments(boardMember1.UserID, board1.ID, list1.ID, card1.ID, nextCursor, 2)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), comments, 1)
+	assert.Equal(s.T(), comment1.ID, comments[0].ID)
+	assert.Empty(s.T(), nextCursor)
+}
+
+func (s *commentUsecaseSuite) TestGetCardCommentsFetchesEachAuthorOnce() {
+	comments, _, err := s.usecase.GetCardComments(boardMember1.UserID, board1.ID, list1.ID, card1.ID, "", 0)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), comments, 3)
+	s.userRepo.AssertNumberOfCalls(s.T(), "GetByID", 2)
+	s.storage.AssertNumberOfCalls(s.T(), "AssignImageURLToUser", 1)
 }
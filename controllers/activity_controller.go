@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ActivityController interface {
+	GetBoardActivities(c *gin.Context)
+	GetCardActivities(c *gin.Context)
+}
+
+type activityController struct {
+	usecase activity.Usecase
+}
+
+func NewActivityController(usecase activity.Usecase) ActivityController {
+	return &activityController{usecase: usecase}
+}
+
+func (controller *activityController) GetBoardActivities(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	limit := 0
+	if limitStr, isExist := c.GetQuery("limit"); isExist {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	activities, nextCursor, err := controller.usecase.GetBoardActivities(requesterID, boardID, c.Query("cursor"), limit)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(activities, map[string]interface{}{
+		"next_cursor": nextCursor,
+	}))
+}
+
+func (controller *activityController) GetCardActivities(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	cardIDStr := c.Param("card_id")
+	listIDStr := c.Param("list_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	limit := 0
+	if limitStr, isExist := c.GetQuery("limit"); isExist {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	activities, nextCursor, err := controller.usecase.GetCardActivities(requesterID, boardID, listID, cardID, c.Query("cursor"), limit)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(activities, map[string]interface{}{
+		"next_cursor": nextCursor,
+	}))
+}
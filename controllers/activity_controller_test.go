@@ -0,0 +1,104 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/activity/mocks"
+	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestActivityController(t *testing.T) {
+	suite.Run(t, new(activityControllerSuite))
+}
+
+type activityControllerSuite struct {
+	suite.Suite
+
+	router     *gin.Engine
+	controller controllers.ActivityController
+	response   *httptest.ResponseRecorder
+	context    *gin.Context
+	usecase    *mocks.Usecase
+}
+
+func (s *activityControllerSuite) SetupTest() {
+	s.usecase = new(mocks.Usecase)
+
+	s.usecase.On("GetBoardActivities", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*models.Activity{{ID: primitive.NewObjectID()}}, "next", nil)
+	s.usecase.On("GetCardActivities", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*models.Activity{{ID: primitive.NewObjectID()}}, "", nil)
+
+	s.controller = controllers.NewActivityController(s.usecase)
+	s.response = httptest.NewRecorder()
+	s.context, s.router = gin.CreateTestContext(s.response)
+
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}
+
+	s.router.GET("/boards/:board_id/activities", setCurrentUser, s.controller.GetBoardActivities)
+	s.router.GET("/boards/:board_id/lists/:list_id/cards/:card_id/activities", setCurrentUser, s.controller.GetCardActivities)
+}
+
+func (s *activityControllerSuite) TestGetBoardActivitiesInvalidBoardID() {
+	s.context.Request, _ = http.NewRequest("GET", "/boards/invalid/activities", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetBoardActivities", 0)
+}
+
+func (s *activityControllerSuite) TestGetBoardActivitiesInvalidLimit() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/activities?limit=many", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetBoardActivities", 0)
+}
+
+func (s *activityControllerSuite) TestGetBoardActivitiesSuccessful() {
+	boardID := primitive.NewObjectID()
+
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/activities?limit=5&cursor=abc", boardID.Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	var body map[string]interface{}
+	json.NewDecoder(s.response.Body).Decode(&body)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetBoardActivities", mock.AnythingOfType("primitive.ObjectID"), boardID, "abc", 5)
+	assert.Len(s.T(), body["data"], 1)
+	assert.Equal(s.T(), map[string]interface{}{"next_cursor": "next"}, body["meta"])
+}
+
+func (s *activityControllerSuite) TestGetCardActivitiesInvalidCardID() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/lists/%s/cards/invalid/activities", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetCardActivities", 0)
+}
+
+func (s *activityControllerSuite) TestGetCardActivitiesSuccessful() {
+	boardID, listID, cardID := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/activities", boardID.Hex(), listID.Hex(), cardID.Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	var body map[string]interface{}
+	json.NewDecoder(s.response.Body).Decode(&body)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetCardActivities", mock.AnythingOfType("primitive.ObjectID"), boardID, listID, cardID, "", 0)
+	assert.Equal(s.T(), map[string]interface{}{"next_cursor": ""}, body["meta"])
+}
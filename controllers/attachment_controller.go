@@ -0,0 +1,185 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/attachment"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AttachmentController interface {
+	Upload(c *gin.Context)
+	GetCardAttachments(c *gin.Context)
+	SetAsCover(c *gin.Context)
+	Delete(c *gin.Context)
+}
+
+type attachmentController struct {
+	usecase attachment.Usecase
+}
+
+func NewAttachmentController(usecase attachment.Usecase) AttachmentController {
+	return &attachmentController{usecase: usecase}
+}
+
+func (controller *attachmentController) Upload(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		respondBasedOnError(c, custom_errors.ErrAttachmentEmpty)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+	defer file.Close()
+
+	err = controller.usecase.Upload(requesterID, boardID, listID, cardID, utils.NewNamedFileReader(file, fileHeader.Filename))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *attachmentController) GetCardAttachments(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	attachments, err := controller.usecase.GetCardAttachments(requesterID, boardID, listID, cardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(attachments, nil))
+}
+
+func (controller *attachmentController) SetAsCover(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	attachmentIDStr := c.Param("attachment_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	attachmentID, err := primitive.ObjectIDFromHex(attachmentIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.SetAsCover(requesterID, boardID, listID, cardID, attachmentID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *attachmentController) Delete(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	attachmentIDStr := c.Param("attachment_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	attachmentID, err := primitive.ObjectIDFromHex(attachmentIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Delete(requesterID, boardID, listID, cardID, attachmentID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,108 @@
+package controllers_test
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/attachment/mocks"
+	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestAttachmentController(t *testing.T) {
+	suite.Run(t, new(attachmentControllerSuite))
+}
+
+type attachmentControllerSuite struct {
+	suite.Suite
+
+	router     *gin.Engine
+	controller controllers.AttachmentController
+	response   *httptest.ResponseRecorder
+	context    *gin.Context
+	usecase    *mocks.Usecase
+}
+
+func (s *attachmentControllerSuite) SetupTest() {
+	s.usecase = new(mocks.Usecase)
+
+	s.usecase.On("Upload", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.Anything).Return(nil)
+	s.usecase.On("GetCardAttachments", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Attachment{{ID: primitive.NewObjectID(), File: &models.Image{ID: "file"}}}, nil)
+	s.usecase.On("SetAsCover", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Delete", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+
+	s.controller = controllers.NewAttachmentController(s.usecase)
+	s.response = httptest.NewRecorder()
+	s.context, s.router = gin.CreateTestContext(s.response)
+
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}
+
+	s.router.POST("/boards/:board_id/lists/:list_id/cards/:card_id/attachments", setCurrentUser, s.controller.Upload)
+	s.router.GET("/boards/:board_id/lists/:list_id/cards/:card_id/attachments", setCurrentUser, s.controller.GetCardAttachments)
+	s.router.PATCH("/boards/:board_id/lists/:list_id/cards/:card_id/attachments/:attachment_id/cover", setCurrentUser, s.controller.SetAsCover)
+	s.router.DELETE("/boards/:board_id/lists/:list_id/cards/:card_id/attachments/:attachment_id", setCurrentUser, s.controller.Delete)
+}
+
+func (s *attachmentControllerSuite) TestUploadWithoutFile() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/attachments", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Upload", 0)
+}
+
+func (s *attachmentControllerSuite) TestUpload() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	file, _ := writer.CreateFormFile("file", "notes.txt")
+	file.Write([]byte("meeting notes"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/attachments", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Upload", 1)
+}
+
+func (s *attachmentControllerSuite) TestGetCardAttachments() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/attachments", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetCardAttachments", 1)
+}
+
+func (s *attachmentControllerSuite) TestSetAsCover() {
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/attachments/%s/cover", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "SetAsCover", 1)
+}
+
+func (s *attachmentControllerSuite) TestDelete() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/attachments/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 1)
+}
@@ -2,6 +2,8 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/custom_errors"
@@ -43,6 +45,8 @@ func getStatusCodeForError(err error) int {
 		switch modelError {
 		case custom_errors.ErrMalformedRefreshToken, custom_errors.ErrInvalidRefreshToken:
 			return http.StatusForbidden
+		case custom_errors.ErrConflictingUpdate:
+			return http.StatusConflict
 		default:
 			return http.StatusBadRequest
 		}
@@ -55,3 +59,23 @@ func getStatusCodeForError(err error) int {
 		return http.StatusInternalServerError
 	}
 }
+
+func parseCover(coverString string) (map[string]interface{}, error) {
+	coverSlice := strings.Split(coverString, ":")
+	if len(coverSlice) != 3 {
+		return nil, custom_errors.ErrMalformedCover
+	}
+
+	fpY, err := strconv.ParseFloat(coverSlice[2], 64)
+	if err != nil {
+		return nil, custom_errors.ErrMalformedCover
+	}
+
+	cover := map[string]interface{}{
+		"source":   coverSlice[0],
+		"photo_id": coverSlice[1],
+		"fp_y":     fpY,
+	}
+
+	return cover, nil
+}
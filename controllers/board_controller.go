@@ -8,15 +8,20 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/board"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type BoardController interface {
 	Create(c *gin.Context)
+	Get(c *gin.Context)
+	GetUserBoards(c *gin.Context)
+	GetArchivedItems(c *gin.Context)
 	Update(c *gin.Context)
 	AddMember(c *gin.Context)
 	UpdateMemberRole(c *gin.Context)
 	DeleteMember(c *gin.Context)
+	Delete(c *gin.Context)
 }
 
 type boardController struct {
@@ -33,34 +38,133 @@ func (controller *boardController) Create(c *gin.Context) {
 	title := strings.TrimSpace(c.PostForm("title"))
 	visibility := c.PostForm("visibility")
 
-	boardCover := map[string]interface{}{}
-	var err error
-	if len(coverString) > 0 {
-		coverSlice := strings.Split(coverString, ":")
-		if len(coverSlice) != 3 {
-			respondBasedOnError(c, custom_errors.ErrMalformedCover)
+	if len(coverString) == 0 {
+		respondBasedOnError(c, custom_errors.ErrBoardCoverEmpty)
+		return
+	}
+
+	boardCover, err := parseCover(coverString)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Create(userID, title, visibility, boardCover)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *boardController) Get(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	filter, err := parseCardFilter(c)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	board, err := controller.usecase.GetBoardByID(requesterID, boardID, filter)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(board, nil))
+}
+
+// parseCardFilter reads the filter of the board's cards from the query, labels is a comma
+// separated list of label IDs and due a comma separated list of due statuses
+func parseCardFilter(c *gin.Context) (board.CardFilter, error) {
+	filter := board.CardFilter{}
+
+	if labelsStr := c.Query("labels"); labelsStr != "" {
+		for _, labelIDStr := range strings.Split(labelsStr, ",") {
+			labelID, err := primitive.ObjectIDFromHex(strings.TrimSpace(labelIDStr))
+			if err != nil {
+				return filter, custom_errors.ErrLabelFilterInvalid
+			}
+
+			filter.LabelIDs = append(filter.LabelIDs, labelID)
+		}
+	}
+
+	if dueStr := c.Query("due"); dueStr != "" {
+		for _, dueStatus := range strings.Split(dueStr, ",") {
+			dueStatus = strings.TrimSpace(dueStatus)
+			if !board.DueStatuses[dueStatus] {
+				return filter, custom_errors.ErrBoardInvalidDueFilter
+			}
+
+			filter.DueStatuses = append(filter.DueStatuses, dueStatus)
+		}
+	}
+
+	return filter, nil
+}
+
+func (controller *boardController) GetUserBoards(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+
+	limit := 0
+	if limitStr, isExist := c.GetQuery("limit"); isExist {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondBasedOnError(c, err)
 			return
 		}
+	}
 
-		boardCover["source"] = coverSlice[0]
-		boardCover["photo_id"] = coverSlice[1]
-		boardCover["fp_y"], err = strconv.ParseFloat(coverSlice[2], 64)
+	// the archived boards are listed separately from the active ones
+	archived := false
+	if archivedStr, isExist := c.GetQuery("archived"); isExist {
+		var err error
+		archived, err = strconv.ParseBool(archivedStr)
 		if err != nil {
-			respondBasedOnError(c, custom_errors.ErrMalformedCover)
+			respondBasedOnError(c, err)
 			return
 		}
-	} else {
-		respondBasedOnError(c, custom_errors.ErrBoardCoverEmpty)
+	}
+
+	boards, nextCursor, err := controller.usecase.GetUserBoards(requesterID, c.Query("sort"), c.Query("cursor"), limit, archived)
+	if err != nil {
+		respondBasedOnError(c, err)
 		return
 	}
 
-	err = controller.usecase.Create(userID, title, visibility, boardCover)
+	c.JSON(http.StatusOK, utils.DataResponse(boards, map[string]interface{}{
+		"next_cursor": nextCursor,
+	}))
+}
+
+func (controller *boardController) GetArchivedItems(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
 	if err != nil {
 		respondBasedOnError(c, err)
 		return
 	}
 
-	c.Status(http.StatusNoContent)
+	archivedItems, err := controller.usecase.GetArchivedItems(requesterID, boardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(archivedItems, nil))
 }
 
 func (controller *boardController) Update(c *gin.Context) {
@@ -100,6 +204,26 @@ func (controller *boardController) Update(c *gin.Context) {
 		}
 	}
 
+	archivedStr, isExist := c.GetPostForm("archived")
+	if isExist {
+		archived, err := strconv.ParseBool(archivedStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		if archived {
+			err = controller.usecase.Archive(requesterID, boardID)
+		} else {
+			err = controller.usecase.Unarchive(requesterID, boardID)
+		}
+
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
 	c.Status(http.StatusNoContent)
 }
 
@@ -181,3 +305,22 @@ func (controller *boardController) DeleteMember(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+func (controller *boardController) Delete(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Delete(requesterID, boardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -10,9 +10,11 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/board"
 	"github.com/jordyf15/thullo-api/board/mocks"
 	"github.com/jordyf15/thullo-api/controllers"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -37,9 +39,15 @@ func (s *boardControllerSuite) SetupTest() {
 	s.usecase = new(mocks.Usecase)
 
 	s.usecase.On("Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("map[string]interface {}")).Return(nil)
+	s.usecase.On("GetBoardByID", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("board.CardFilter")).Return(&models.Board{}, nil)
+	s.usecase.On("GetUserBoards", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("bool")).Return([]*models.UserBoard{{BoardID: primitive.NewObjectID()}}, "next-cursor", nil)
 	s.usecase.On("AddMember", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
 	s.usecase.On("UpdateMemberRole", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
 	s.usecase.On("DeleteMember", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Archive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Unarchive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("GetArchivedItems", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(&models.ArchivedItems{}, nil)
+	s.usecase.On("Delete", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
 	s.usecase.On("UpdateVisibility", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
 	s.usecase.On("UpdateTitle", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
 	s.usecase.On("UpdateDescription", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
@@ -52,6 +60,18 @@ func (s *boardControllerSuite) SetupTest() {
 		c.Set("current_user_id", primitive.NewObjectID())
 		c.Next()
 	}, s.controller.Create)
+	s.router.GET("/boards", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.GetUserBoards)
+	s.router.GET("/boards/:board_id", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Get)
+	s.router.GET("/boards/:board_id/archived", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.GetArchivedItems)
 	s.router.PATCH("/boards/:board_id", func(c *gin.Context) {
 		c.Set("current_user_id", primitive.NewObjectID())
 		c.Next()
@@ -68,6 +88,10 @@ func (s *boardControllerSuite) SetupTest() {
 		c.Set("current_user_id", primitive.NewObjectID())
 		c.Next()
 	}, s.controller.DeleteMember)
+	s.router.DELETE("/boards/:board_id", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Delete)
 }
 
 func (s *boardControllerSuite) TestCreateEmptyCover() {
@@ -153,6 +177,80 @@ func (s *boardControllerSuite) TestCreate() {
 	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
 }
 
+func (s *boardControllerSuite) TestGet() {
+	var receivedResponse map[string]interface{}
+
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+
+	_, isExist := receivedResponse["data"].(map[string]interface{})
+	assert.True(s.T(), isExist)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetBoardByID", 1)
+}
+
+func (s *boardControllerSuite) TestGetFilteredByLabels() {
+	labelID1, labelID2 := primitive.NewObjectID(), primitive.NewObjectID()
+
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s?labels=%s,%s", primitive.NewObjectID().Hex(), labelID1.Hex(), labelID2.Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetBoardByID", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), board.CardFilter{LabelIDs: []primitive.ObjectID{labelID1, labelID2}})
+}
+
+func (s *boardControllerSuite) TestGetInvalidLabelFilter() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s?labels=not-a-label", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetBoardByID", 0)
+}
+
+func (s *boardControllerSuite) TestGetFilteredByDueStatus() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s?due=overdue,due_soon", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetBoardByID", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), board.CardFilter{DueStatuses: []string{board.DueStatusOverdue, board.DueStatusDueSoon}})
+}
+
+func (s *boardControllerSuite) TestGetInvalidDueFilter() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s?due=someday", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetBoardByID", 0)
+}
+
+func (s *boardControllerSuite) TestGetUserBoards() {
+	var receivedResponse map[string]interface{}
+
+	s.context.Request, _ = http.NewRequest("GET", "/boards?sort=title&limit=10", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+
+	boards, isExist := receivedResponse["data"].([]interface{})
+	assert.True(s.T(), isExist)
+	assert.Len(s.T(), boards, 1)
+
+	meta := receivedResponse["meta"].(map[string]interface{})
+	assert.Equal(s.T(), "next-cursor", meta["next_cursor"])
+	s.usecase.AssertCalled(s.T(), "GetUserBoards", mock.AnythingOfType("primitive.ObjectID"), "title", "", 10, false)
+}
+
+func (s *boardControllerSuite) TestGetArchivedUserBoards() {
+	s.context.Request, _ = http.NewRequest("GET", "/boards?archived=true", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetUserBoards", mock.AnythingOfType("primitive.ObjectID"), "", "", 0, true)
+}
+
 func (s *boardControllerSuite) TestUpdateBoardVisibility() {
 	buf := new(bytes.Buffer)
 	writer := multipart.NewWriter(buf)
@@ -204,6 +302,35 @@ func (s *boardControllerSuite) TestUpdateBoardDescription() {
 	s.usecase.AssertNumberOfCalls(s.T(), "UpdateDescription", 1)
 }
 
+func (s *boardControllerSuite) TestUpdateBoardArchived() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	archived, _ := writer.CreateFormField("archived")
+	archived.Write([]byte("true"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s", primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Archive", 1)
+	s.usecase.AssertNumberOfCalls(s.T(), "Unarchive", 0)
+}
+
+func (s *boardControllerSuite) TestGetArchivedItems() {
+	var receivedResponse map[string]interface{}
+
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/archived", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+
+	_, isExist := receivedResponse["data"].(map[string]interface{})
+	assert.True(s.T(), isExist)
+}
+
 func (s *boardControllerSuite) TestAddMember() {
 	buf := new(bytes.Buffer)
 	writer := multipart.NewWriter(buf)
@@ -236,3 +363,11 @@ func (s *boardControllerSuite) TestDeleteMember() {
 
 	s.router.ServeHTTP(s.response, s.context.Request)
 }
+
+func (s *boardControllerSuite) TestDelete() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 1)
+}
@@ -2,15 +2,24 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/custom_errors"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type CardController interface {
 	Create(c *gin.Context)
+	Update(c *gin.Context)
+	Move(c *gin.Context)
+	Assign(c *gin.Context)
+	Unassign(c *gin.Context)
+	Watch(c *gin.Context)
+	Unwatch(c *gin.Context)
 }
 
 type cardController struct {
@@ -48,3 +57,355 @@ func (controller *cardController) Create(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+func (controller *cardController) Update(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	title, isExist := c.GetPostForm("title")
+	if isExist {
+		err = controller.usecase.UpdateTitle(userID, boardID, listID, cardID, strings.TrimSpace(title))
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	description, isExist := c.GetPostForm("description")
+	if isExist {
+		err = controller.usecase.UpdateDescription(userID, boardID, listID, cardID, description)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	coverString, isExist := c.GetPostForm("cover")
+	if isExist {
+		if len(coverString) > 0 {
+			cover, err := parseCover(coverString)
+			if err != nil {
+				respondBasedOnError(c, err)
+				return
+			}
+
+			err = controller.usecase.UpdateCover(userID, boardID, listID, cardID, cover)
+			if err != nil {
+				respondBasedOnError(c, err)
+				return
+			}
+		} else {
+			err = controller.usecase.DeleteCover(userID, boardID, listID, cardID)
+			if err != nil {
+				respondBasedOnError(c, err)
+				return
+			}
+		}
+	}
+
+	positionStr, isExist := c.GetPostForm("position")
+	if isExist {
+		position, err := strconv.Atoi(positionStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		err = controller.usecase.UpdatePosition(userID, boardID, listID, cardID, position)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	archivedStr, isExist := c.GetPostForm("archived")
+	if isExist {
+		archived, err := strconv.ParseBool(archivedStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		if archived {
+			err = controller.usecase.Archive(userID, boardID, listID, cardID)
+		} else {
+			err = controller.usecase.Unarchive(userID, boardID, listID, cardID)
+		}
+
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	// both dates are updated together so a start date and due date that are only
+	// valid in combination with each other can be sent in the same request
+	dates := map[string]*time.Time{}
+	for _, field := range []string{card.StartDate, card.DueDate} {
+		dateStr, isExist := c.GetPostForm(field)
+		if !isExist {
+			continue
+		}
+
+		date, err := parseCardDate(dateStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		dates[field] = date
+	}
+
+	if len(dates) > 0 {
+		err = controller.usecase.UpdateDates(userID, boardID, listID, cardID, dates)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	doneStr, isExist := c.GetPostForm("done")
+	if isExist {
+		done, err := strconv.ParseBool(doneStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		err = controller.usecase.UpdateDone(userID, boardID, listID, cardID, done)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *cardController) Move(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	targetListIDStr := c.PostForm("list_id")
+	positionStr := c.PostForm("position")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	targetListID, err := primitive.ObjectIDFromHex(targetListIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	position, err := strconv.Atoi(positionStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Move(userID, boardID, listID, cardID, targetListID, position)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *cardController) Assign(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	assigneeIDStr := c.PostForm("user_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	assigneeID, err := primitive.ObjectIDFromHex(assigneeIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Assign(userID, boardID, listID, cardID, assigneeID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *cardController) Unassign(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	assigneeIDStr := c.Param("user_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	assigneeID, err := primitive.ObjectIDFromHex(assigneeIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Unassign(userID, boardID, listID, cardID, assigneeID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *cardController) Watch(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Watch(userID, boardID, listID, cardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *cardController) Unwatch(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Unwatch(userID, boardID, listID, cardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseCardDate parses an RFC 3339 date of a card, an empty string clears the date
+func parseCardDate(dateStr string) (*time.Time, error) {
+	dateStr = strings.TrimSpace(dateStr)
+	if dateStr == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		return nil, custom_errors.ErrCardDateInvalid
+	}
+
+	return &date, nil
+}
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/card"
 	"github.com/jordyf15/thullo-api/card/mocks"
 	"github.com/jordyf15/thullo-api/controllers"
 	"github.com/stretchr/testify/assert"
@@ -36,6 +38,21 @@ func (s *cardControllerSuite) SetupTest() {
 
 	s.usecase.On("Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
 
+	s.usecase.On("Archive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("UpdateTitle", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("UpdateDescription", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("UpdateCover", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("map[string]interface {}")).Return(nil)
+	s.usecase.On("DeleteCover", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("UpdatePosition", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("int")).Return(nil)
+	s.usecase.On("Move", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("int")).Return(nil)
+	s.usecase.On("UpdateDates", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("map[string]*time.Time")).Return(nil)
+	s.usecase.On("UpdateDone", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("bool")).Return(nil)
+	s.usecase.On("Assign", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Unassign", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Watch", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Unwatch", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Unarchive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+
 	s.controller = controllers.NewCardController(s.usecase)
 	s.response = httptest.NewRecorder()
 	s.context, s.router = gin.CreateTestContext(s.response)
@@ -44,6 +61,30 @@ func (s *cardControllerSuite) SetupTest() {
 		c.Set("current_user_id", primitive.NewObjectID())
 		c.Next()
 	}, s.controller.Create)
+	s.router.PATCH("/boards/:board_id/lists/:list_id/cards/:card_id", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Update)
+	s.router.PATCH("/boards/:board_id/lists/:list_id/cards/:card_id/move", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Move)
+	s.router.POST("/boards/:board_id/lists/:list_id/cards/:card_id/assignees", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Assign)
+	s.router.DELETE("/boards/:board_id/lists/:list_id/cards/:card_id/assignees/:user_id", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Unassign)
+	s.router.POST("/boards/:board_id/lists/:list_id/cards/:card_id/watch", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Watch)
+	s.router.DELETE("/boards/:board_id/lists/:list_id/cards/:card_id/watch", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Unwatch)
 }
 
 func (s *cardControllerSuite) TestCreate() {
@@ -59,3 +100,190 @@ func (s *cardControllerSuite) TestCreate() {
 
 	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
 }
+
+func (s *cardControllerSuite) TestUpdateArchived() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	archived, _ := writer.CreateFormField("archived")
+	archived.Write([]byte("true"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Archive", 1)
+	s.usecase.AssertNumberOfCalls(s.T(), "Unarchive", 0)
+}
+
+func (s *cardControllerSuite) TestUpdateFields() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	title, _ := writer.CreateFormField("title")
+	title.Write([]byte("new title"))
+	description, _ := writer.CreateFormField("description")
+	description.Write([]byte("new description"))
+	cover, _ := writer.CreateFormField("cover")
+	cover.Write([]byte("unsplash:picture-1:0.5"))
+	position, _ := writer.CreateFormField("position")
+	position.Write([]byte("1"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateTitle", 1)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateDescription", 1)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateCover", 1)
+	s.usecase.AssertNumberOfCalls(s.T(), "DeleteCover", 0)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdatePosition", 1)
+}
+
+func (s *cardControllerSuite) TestUpdateRemoveCover() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	cover, _ := writer.CreateFormField("cover")
+	cover.Write([]byte(""))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateCover", 0)
+	s.usecase.AssertNumberOfCalls(s.T(), "DeleteCover", 1)
+}
+
+func (s *cardControllerSuite) TestUpdateMalformedCover() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	cover, _ := writer.CreateFormField("cover")
+	cover.Write([]byte("unsplash:picture-1"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateCover", 0)
+}
+
+func (s *cardControllerSuite) TestUpdateDatesAndDone() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	startDate, _ := writer.CreateFormField("start_date")
+	startDate.Write([]byte(""))
+	dueDate, _ := writer.CreateFormField("due_date")
+	dueDate.Write([]byte("2022-10-20T17:00:00+07:00"))
+	done, _ := writer.CreateFormField("done")
+	done.Write([]byte("true"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	expectedDueDate := time.Date(2022, 10, 20, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateDates", 1)
+	s.usecase.AssertCalled(s.T(), "UpdateDone", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), true)
+
+	dates := s.usecase.Calls[0].Arguments.Get(4).(map[string]*time.Time)
+	assert.Len(s.T(), dates, 2)
+	assert.Nil(s.T(), dates[card.StartDate])
+	assert.True(s.T(), expectedDueDate.Equal(*dates[card.DueDate]))
+}
+
+func (s *cardControllerSuite) TestUpdateMalformedDate() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	dueDate, _ := writer.CreateFormField("due_date")
+	dueDate.Write([]byte("20-10-2022"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateDates", 0)
+}
+
+func (s *cardControllerSuite) TestMove() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	listID, _ := writer.CreateFormField("list_id")
+	listID.Write([]byte(primitive.NewObjectID().Hex()))
+	position, _ := writer.CreateFormField("position")
+	position.Write([]byte("0"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/move", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Move", 1)
+}
+
+func (s *cardControllerSuite) TestAssign() {
+	assigneeID := primitive.NewObjectID()
+
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	userID, _ := writer.CreateFormField("user_id")
+	userID.Write([]byte(assigneeID.Hex()))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/assignees", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "Assign", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), assigneeID)
+}
+
+func (s *cardControllerSuite) TestAssignMalformedUserID() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	userID, _ := writer.CreateFormField("user_id")
+	userID.Write([]byte("someone"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/assignees", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Assign", 0)
+}
+
+func (s *cardControllerSuite) TestUnassign() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/assignees/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Unassign", 1)
+}
+
+func (s *cardControllerSuite) TestWatch() {
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/watch", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Watch", 1)
+}
+
+func (s *cardControllerSuite) TestUnwatch() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/watch", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Unwatch", 1)
+}
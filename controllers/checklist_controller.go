@@ -0,0 +1,396 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/checklist"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ChecklistController interface {
+	Create(c *gin.Context)
+	GetCardChecklists(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	AddItem(c *gin.Context)
+	UpdateItem(c *gin.Context)
+	DeleteItem(c *gin.Context)
+	ConvertItemToCard(c *gin.Context)
+}
+
+type checklistController struct {
+	usecase checklist.Usecase
+}
+
+func NewChecklistController(usecase checklist.Usecase) ChecklistController {
+	return &checklistController{usecase: usecase}
+}
+
+func (controller *checklistController) Create(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	title := strings.TrimSpace(c.PostForm("title"))
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Create(requesterID, boardID, listID, cardID, title)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *checklistController) GetCardChecklists(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	checklists, err := controller.usecase.GetCardChecklists(requesterID, boardID, listID, cardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(checklists, nil))
+}
+
+func (controller *checklistController) Update(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	checklistIDStr := c.Param("checklist_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	checklistID, err := primitive.ObjectIDFromHex(checklistIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	title, isExist := c.GetPostForm("title")
+	if isExist {
+		err = controller.usecase.UpdateTitle(requesterID, boardID, listID, cardID, checklistID, strings.TrimSpace(title))
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *checklistController) Delete(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	checklistIDStr := c.Param("checklist_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	checklistID, err := primitive.ObjectIDFromHex(checklistIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Delete(requesterID, boardID, listID, cardID, checklistID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *checklistController) AddItem(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	checklistIDStr := c.Param("checklist_id")
+	title := strings.TrimSpace(c.PostForm("title"))
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	checklistID, err := primitive.ObjectIDFromHex(checklistIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.AddItem(requesterID, boardID, listID, cardID, checklistID, title)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *checklistController) UpdateItem(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	checklistIDStr := c.Param("checklist_id")
+	itemIDStr := c.Param("item_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	checklistID, err := primitive.ObjectIDFromHex(checklistIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	itemID, err := primitive.ObjectIDFromHex(itemIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	title, isExist := c.GetPostForm("title")
+	if isExist {
+		err = controller.usecase.UpdateItemTitle(requesterID, boardID, listID, cardID, checklistID, itemID, strings.TrimSpace(title))
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	doneStr, isExist := c.GetPostForm("done")
+	if isExist {
+		done, err := strconv.ParseBool(doneStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		err = controller.usecase.UpdateItemDone(requesterID, boardID, listID, cardID, checklistID, itemID, done)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	positionStr, isExist := c.GetPostForm("position")
+	if isExist {
+		position, err := strconv.Atoi(positionStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		err = controller.usecase.UpdateItemPosition(requesterID, boardID, listID, cardID, checklistID, itemID, position)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *checklistController) DeleteItem(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	checklistIDStr := c.Param("checklist_id")
+	itemIDStr := c.Param("item_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	checklistID, err := primitive.ObjectIDFromHex(checklistIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	itemID, err := primitive.ObjectIDFromHex(itemIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.DeleteItem(requesterID, boardID, listID, cardID, checklistID, itemID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *checklistController) ConvertItemToCard(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	checklistIDStr := c.Param("checklist_id")
+	itemIDStr := c.Param("item_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	checklistID, err := primitive.ObjectIDFromHex(checklistIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	itemID, err := primitive.ObjectIDFromHex(itemIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.ConvertItemToCard(requesterID, boardID, listID, cardID, checklistID, itemID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,143 @@
+package controllers_test
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/checklist/mocks"
+	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestChecklistController(t *testing.T) {
+	suite.Run(t, new(checklistControllerSuite))
+}
+
+type checklistControllerSuite struct {
+	suite.Suite
+
+	router     *gin.Engine
+	controller controllers.ChecklistController
+	response   *httptest.ResponseRecorder
+	context    *gin.Context
+	usecase    *mocks.Usecase
+}
+
+func (s *checklistControllerSuite) SetupTest() {
+	s.usecase = new(mocks.Usecase)
+
+	s.usecase.On("Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("GetCardChecklists", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Checklist{{ID: primitive.NewObjectID()}}, nil)
+	s.usecase.On("UpdateTitle", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("Delete", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("AddItem", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("UpdateItemTitle", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("UpdateItemDone", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("bool")).Return(nil)
+	s.usecase.On("UpdateItemPosition", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("int")).Return(nil)
+	s.usecase.On("DeleteItem", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("ConvertItemToCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+
+	s.controller = controllers.NewChecklistController(s.usecase)
+	s.response = httptest.NewRecorder()
+	s.context, s.router = gin.CreateTestContext(s.response)
+
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}
+
+	s.router.GET("/boards/:board_id/lists/:list_id/cards/:card_id/checklists", setCurrentUser, s.controller.GetCardChecklists)
+	s.router.POST("/boards/:board_id/lists/:list_id/cards/:card_id/checklists", setCurrentUser, s.controller.Create)
+	s.router.PATCH("/boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id", setCurrentUser, s.controller.Update)
+	s.router.DELETE("/boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id", setCurrentUser, s.controller.Delete)
+	s.router.POST("/boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items", setCurrentUser, s.controller.AddItem)
+	s.router.PATCH("/boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items/:item_id", setCurrentUser, s.controller.UpdateItem)
+	s.router.DELETE("/boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items/:item_id", setCurrentUser, s.controller.DeleteItem)
+	s.router.POST("/boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items/:item_id/card", setCurrentUser, s.controller.ConvertItemToCard)
+}
+
+func (s *checklistControllerSuite) cardURL() string {
+	return fmt.Sprintf("/boards/%s/lists/%s/cards/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex())
+}
+
+func (s *checklistControllerSuite) TestCreate() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	title, _ := writer.CreateFormField("title")
+	title.Write([]byte(" Todo "))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", s.cardURL()+"/checklists", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), "Todo")
+}
+
+func (s *checklistControllerSuite) TestGetCardChecklists() {
+	s.context.Request, _ = http.NewRequest("GET", s.cardURL()+"/checklists", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetCardChecklists", 1)
+}
+
+func (s *checklistControllerSuite) TestDelete() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("%s/checklists/%s", s.cardURL(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 1)
+}
+
+func (s *checklistControllerSuite) TestUpdateItem() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	done, _ := writer.CreateFormField("done")
+	done.Write([]byte("true"))
+	position, _ := writer.CreateFormField("position")
+	position.Write([]byte("2"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("%s/checklists/%s/items/%s", s.cardURL(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateItemTitle", 0)
+	s.usecase.AssertCalled(s.T(), "UpdateItemDone", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), true)
+	s.usecase.AssertCalled(s.T(), "UpdateItemPosition", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), 2)
+}
+
+func (s *checklistControllerSuite) TestUpdateItemMalformedDone() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	done, _ := writer.CreateFormField("done")
+	done.Write([]byte("maybe"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("%s/checklists/%s/items/%s", s.cardURL(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateItemDone", 0)
+}
+
+func (s *checklistControllerSuite) TestConvertItemToCard() {
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("%s/checklists/%s/items/%s/card", s.cardURL(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "ConvertItemToCard", 1)
+}
@@ -2,15 +2,18 @@ package controllers
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/comment"
+	"github.com/jordyf15/thullo-api/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type CommentController interface {
 	Create(c *gin.Context)
+	GetCardComments(c *gin.Context)
 	Update(c *gin.Context)
 	Delete(c *gin.Context)
 }
@@ -57,6 +60,50 @@ func (controller *commentController) Create(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+func (controller *commentController) GetCardComments(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	cardIDStr := c.Param("card_id")
+	listIDStr := c.Param("list_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	limit := 0
+	if limitStr, isExist := c.GetQuery("limit"); isExist {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	comments, nextCursor, err := controller.usecase.GetCardComments(requesterID, boardID, listID, cardID, c.Query("cursor"), limit)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(comments, map[string]interface{}{
+		"next_cursor": nextCursor,
+	}))
+}
+
 func (controller *commentController) Update(c *gin.Context) {
 	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
 	boardIDStr := c.Param("board_id")
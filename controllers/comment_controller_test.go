@@ -2,6 +2,7 @@ package controllers_test
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"net/http"
@@ -11,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/comment/mocks"
 	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -35,6 +37,7 @@ func (s *commentControllerSuite) SetupTest() {
 	s.usecase = new(mocks.Usecase)
 
 	s.usecase.On("Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("GetCardComments", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("int")).Return([]*models.Comment{{ID: primitive.NewObjectID()}}, "next-cursor", nil)
 	s.usecase.On("Update", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
 	s.usecase.On("Delete", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
 
@@ -46,6 +49,10 @@ func (s *commentControllerSuite) SetupTest() {
 		c.Set("current_user_id", primitive.NewObjectID())
 		c.Next()
 	}, s.controller.Create)
+	s.router.GET("/boards/:board_id/lists/:list_id/cards/:card_id/comments", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.GetCardComments)
 	s.router.PATCH("/boards/:board_id/lists/:list_id/cards/:card_id/comments/:comment_id", func(c *gin.Context) {
 		c.Set("current_user_id", primitive.NewObjectID())
 		c.Next()
@@ -90,3 +97,24 @@ func (s *commentControllerSuite) TestDelete() {
 
 	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
 }
+
+func (s *commentControllerSuite) TestGetCardComments() {
+	var receivedResponse map[string]interface{}
+
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/comments?cursor=abc&limit=10", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	assert.Equal(s.T(), "next-cursor", receivedResponse["meta"].(map[string]interface{})["next_cursor"])
+	s.usecase.AssertCalled(s.T(), "GetCardComments", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), "abc", 10)
+}
+
+func (s *commentControllerSuite) TestGetCardCommentsMalformedLimit() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/comments?limit=ten", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetCardComments", 0)
+}
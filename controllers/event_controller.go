@@ -0,0 +1,65 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/event"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EventController interface {
+	Stream(c *gin.Context)
+}
+
+type eventController struct {
+	usecase event.Usecase
+}
+
+func NewEventController(usecase event.Usecase) EventController {
+	return &eventController{usecase: usecase}
+}
+
+// Stream pushes the events of the board to the requester as server-sent events
+// named after the event type until the client disconnects
+func (controller *eventController) Stream(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	events, err := controller.usecase.Subscribe(c.Request.Context(), requesterID, boardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	heartbeat := time.NewTicker(event.StreamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case _event, isOpen := <-events:
+			if !isOpen {
+				return false
+			}
+
+			c.SSEvent(_event.Type, _event)
+			return true
+		case <-heartbeat.C:
+			// comment lines are ignored by clients
+			fmt.Fprint(w, ": heartbeat\n\n")
+			return true
+		}
+	})
+}
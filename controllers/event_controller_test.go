@@ -0,0 +1,109 @@
+package controllers_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEventController(t *testing.T) {
+	suite.Run(t, new(eventControllerSuite))
+}
+
+// closeNotifyingRecorder lets gin stream into a recorder, which does not implement http.CloseNotifier
+type closeNotifyingRecorder struct {
+	*httptest.ResponseRecorder
+	closed chan bool
+}
+
+func (recorder *closeNotifyingRecorder) CloseNotify() <-chan bool {
+	return recorder.closed
+}
+
+type eventControllerSuite struct {
+	suite.Suite
+
+	router     *gin.Engine
+	controller controllers.EventController
+	response   *closeNotifyingRecorder
+	context    *gin.Context
+	usecase    *mocks.Usecase
+
+	boardID primitive.ObjectID
+}
+
+func (s *eventControllerSuite) SetupTest() {
+	s.usecase = new(mocks.Usecase)
+	s.boardID = primitive.NewObjectID()
+
+	s.usecase.On("Subscribe", mock.Anything, mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(func(_ context.Context, requesterID, boardID primitive.ObjectID) <-chan *models.BoardEvent {
+		if boardID != s.boardID {
+			return nil
+		}
+
+		events := make(chan *models.BoardEvent, 2)
+		events <- &models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID, ActorID: requesterID}
+		events <- &models.BoardEvent{Type: models.BoardEventListMoved, BoardID: boardID, ActorID: requesterID}
+		close(events)
+
+		return events
+	}, func(_ context.Context, requesterID, boardID primitive.ObjectID) error {
+		if boardID != s.boardID {
+			return custom_errors.ErrNotAuthorized
+		}
+
+		return nil
+	})
+
+	s.controller = controllers.NewEventController(s.usecase)
+	s.response = &closeNotifyingRecorder{ResponseRecorder: httptest.NewRecorder(), closed: make(chan bool)}
+	s.context, s.router = gin.CreateTestContext(s.response)
+
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}
+
+	s.router.GET("/boards/:board_id/events", setCurrentUser, s.controller.Stream)
+}
+
+func (s *eventControllerSuite) TestStreamInvalidBoardID() {
+	s.context.Request, _ = http.NewRequest("GET", "/boards/invalid/events", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Subscribe", 0)
+}
+
+func (s *eventControllerSuite) TestStreamNotAuthorized() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/events", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	assert.NotContains(s.T(), s.response.Header().Get("Content-Type"), "text/event-stream")
+}
+
+func (s *eventControllerSuite) TestStreamSuccessful() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/events", s.boardID.Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	body := s.response.Body.String()
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	assert.Equal(s.T(), "text/event-stream", s.response.Header().Get("Content-Type"))
+	assert.Contains(s.T(), body, "event:card.created\n")
+	assert.Contains(s.T(), body, "event:list.moved\n")
+	assert.Contains(s.T(), body, fmt.Sprintf(`"board_id":"%s"`, s.boardID.Hex()))
+}
@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/label"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type LabelController interface {
+	Create(c *gin.Context)
+	GetBoardLabels(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	AttachToCard(c *gin.Context)
+	DetachFromCard(c *gin.Context)
+}
+
+type labelController struct {
+	usecase label.Usecase
+}
+
+func NewLabelController(usecase label.Usecase) LabelController {
+	return &labelController{usecase: usecase}
+}
+
+func (controller *labelController) Create(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	name := strings.TrimSpace(c.PostForm("name"))
+	color := strings.TrimSpace(c.PostForm("color"))
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Create(requesterID, boardID, name, color)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *labelController) GetBoardLabels(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	labels, err := controller.usecase.GetBoardLabels(requesterID, boardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(labels, nil))
+}
+
+func (controller *labelController) Update(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	labelIDStr := c.Param("label_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	labelID, err := primitive.ObjectIDFromHex(labelIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	name, isExist := c.GetPostForm("name")
+	if isExist {
+		err = controller.usecase.UpdateName(requesterID, boardID, labelID, strings.TrimSpace(name))
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	color, isExist := c.GetPostForm("color")
+	if isExist {
+		err = controller.usecase.UpdateColor(requesterID, boardID, labelID, strings.TrimSpace(color))
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *labelController) Delete(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	labelIDStr := c.Param("label_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	labelID, err := primitive.ObjectIDFromHex(labelIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Delete(requesterID, boardID, labelID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *labelController) AttachToCard(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	labelIDStr := c.PostForm("label_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	labelID, err := primitive.ObjectIDFromHex(labelIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.AttachToCard(requesterID, boardID, listID, cardID, labelID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *labelController) DetachFromCard(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardIDStr := c.Param("card_id")
+	labelIDStr := c.Param("label_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	cardID, err := primitive.ObjectIDFromHex(cardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	labelID, err := primitive.ObjectIDFromHex(labelIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.DetachFromCard(requesterID, boardID, listID, cardID, labelID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,133 @@
+package controllers_test
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/label/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLabelController(t *testing.T) {
+	suite.Run(t, new(labelControllerSuite))
+}
+
+type labelControllerSuite struct {
+	suite.Suite
+
+	router     *gin.Engine
+	controller controllers.LabelController
+	response   *httptest.ResponseRecorder
+	context    *gin.Context
+	usecase    *mocks.Usecase
+}
+
+func (s *labelControllerSuite) SetupTest() {
+	s.usecase = new(mocks.Usecase)
+
+	s.usecase.On("Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("GetBoardLabels", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Label{{ID: primitive.NewObjectID()}}, nil)
+	s.usecase.On("UpdateName", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("UpdateColor", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("Delete", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("AttachToCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("DetachFromCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+
+	s.controller = controllers.NewLabelController(s.usecase)
+	s.response = httptest.NewRecorder()
+	s.context, s.router = gin.CreateTestContext(s.response)
+
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}
+
+	s.router.POST("/boards/:board_id/labels", setCurrentUser, s.controller.Create)
+	s.router.GET("/boards/:board_id/labels", setCurrentUser, s.controller.GetBoardLabels)
+	s.router.PATCH("/boards/:board_id/labels/:label_id", setCurrentUser, s.controller.Update)
+	s.router.DELETE("/boards/:board_id/labels/:label_id", setCurrentUser, s.controller.Delete)
+	s.router.POST("/boards/:board_id/lists/:list_id/cards/:card_id/labels", setCurrentUser, s.controller.AttachToCard)
+	s.router.DELETE("/boards/:board_id/lists/:list_id/cards/:card_id/labels/:label_id", setCurrentUser, s.controller.DetachFromCard)
+}
+
+func (s *labelControllerSuite) TestCreate() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	name, _ := writer.CreateFormField("name")
+	name.Write([]byte(" bug "))
+	color, _ := writer.CreateFormField("color")
+	color.Write([]byte("#EB5A46"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/labels", primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), "bug", "#EB5A46")
+}
+
+func (s *labelControllerSuite) TestGetBoardLabels() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/labels", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetBoardLabels", 1)
+}
+
+func (s *labelControllerSuite) TestUpdateColorOnly() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	color, _ := writer.CreateFormField("color")
+	color.Write([]byte("#61BD4F"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/labels/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateName", 0)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateColor", 1)
+}
+
+func (s *labelControllerSuite) TestDelete() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/labels/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 1)
+}
+
+func (s *labelControllerSuite) TestAttachToCard() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	labelID, _ := writer.CreateFormField("label_id")
+	labelID.Write([]byte(primitive.NewObjectID().Hex()))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/labels", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "AttachToCard", 1)
+}
+
+func (s *labelControllerSuite) TestDetachFromCard() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/lists/%s/cards/%s/labels/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "DetachFromCard", 1)
+}
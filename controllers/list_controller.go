@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/list"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -13,6 +14,7 @@ import (
 type ListController interface {
 	Create(c *gin.Context)
 	Update(c *gin.Context)
+	Delete(c *gin.Context)
 }
 
 type listController struct {
@@ -84,5 +86,62 @@ func (controller *listController) Update(c *gin.Context) {
 		}
 	}
 
+	archivedStr, isExist := c.GetPostForm("archived")
+	if isExist {
+		archived, err := strconv.ParseBool(archivedStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		if archived {
+			err = controller.usecase.Archive(userID, boardID, listID)
+		} else {
+			err = controller.usecase.Unarchive(userID, boardID, listID)
+		}
+
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *listController) Delete(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	listIDStr := c.Param("list_id")
+	cardAction := c.Query("cards")
+	targetListIDStr := c.Query("target_list_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	listID, err := primitive.ObjectIDFromHex(listIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	targetListID := primitive.NilObjectID
+	if cardAction == list.CardActionMove {
+		targetListID, err = primitive.ObjectIDFromHex(targetListIDStr)
+		if err != nil {
+			respondBasedOnError(c, custom_errors.ErrListInvalidTargetList)
+			return
+		}
+	}
+
+	err = controller.usecase.Delete(userID, boardID, listID, cardAction, targetListID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
 	c.Status(http.StatusNoContent)
 }
@@ -36,7 +36,10 @@ func (s *listControllerSuite) SetupTest() {
 
 	s.usecase.On("Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
 	s.usecase.On("UpdateTitle", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("Archive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("Unarchive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
 	s.usecase.On("UpdatePosition", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("int")).Return(nil)
+	s.usecase.On("Delete", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
 
 	s.controller = controllers.NewListController(s.usecase)
 	s.response = httptest.NewRecorder()
@@ -50,6 +53,10 @@ func (s *listControllerSuite) SetupTest() {
 		c.Set("current_user_id", primitive.NewObjectID())
 		c.Next()
 	}, s.controller.Update)
+	s.router.DELETE("/boards/:board_id/lists/:list_id", func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}, s.controller.Delete)
 }
 
 func (s *listControllerSuite) TestCreate() {
@@ -97,3 +104,35 @@ func (s *listControllerSuite) TestUpdatePosition() {
 	s.usecase.AssertNumberOfCalls(s.T(), "UpdateTitle", 0)
 	s.usecase.AssertNumberOfCalls(s.T(), "UpdatePosition", 1)
 }
+
+func (s *listControllerSuite) TestUpdateArchived() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	archived, _ := writer.CreateFormField("archived")
+	archived.Write([]byte("false"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/lists/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Archive", 0)
+	s.usecase.AssertNumberOfCalls(s.T(), "Unarchive", 1)
+}
+
+func (s *listControllerSuite) TestDeleteMoveCardsInvalidTargetList() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/lists/%s?cards=move&target_list_id=invalid", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusBadRequest, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *listControllerSuite) TestDelete() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/lists/%s?cards=move&target_list_id=%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 1)
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/notification"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type NotificationController interface {
+	GetNotifications(c *gin.Context)
+	MarkAsRead(c *gin.Context)
+	MarkAllAsRead(c *gin.Context)
+}
+
+type notificationController struct {
+	usecase notification.Usecase
+}
+
+func NewNotificationController(usecase notification.Usecase) NotificationController {
+	return &notificationController{usecase: usecase}
+}
+
+func (controller *notificationController) GetNotifications(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+
+	var err error
+	limit := 0
+	if limitStr, isExist := c.GetQuery("limit"); isExist {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	unreadOnly := false
+	if unreadStr, isExist := c.GetQuery("unread"); isExist {
+		unreadOnly, err = strconv.ParseBool(unreadStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	notifications, nextCursor, unreadCount, err := controller.usecase.GetNotifications(requesterID, c.Query("cursor"), limit, unreadOnly)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(notifications, map[string]interface{}{
+		"next_cursor":  nextCursor,
+		"unread_count": unreadCount,
+	}))
+}
+
+func (controller *notificationController) MarkAsRead(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	notificationIDStr := c.Param("notification_id")
+
+	notificationID, err := primitive.ObjectIDFromHex(notificationIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.MarkAsRead(requesterID, notificationID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *notificationController) MarkAllAsRead(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+
+	err := controller.usecase.MarkAllAsRead(requesterID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
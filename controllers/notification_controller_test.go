@@ -0,0 +1,91 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/notification/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNotificationController(t *testing.T) {
+	suite.Run(t, new(notificationControllerSuite))
+}
+
+type notificationControllerSuite struct {
+	suite.Suite
+
+	router     *gin.Engine
+	controller controllers.NotificationController
+	response   *httptest.ResponseRecorder
+	context    *gin.Context
+	usecase    *mocks.Usecase
+}
+
+func (s *notificationControllerSuite) SetupTest() {
+	s.usecase = new(mocks.Usecase)
+
+	s.usecase.On("GetNotifications", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("bool")).Return([]*models.Notification{{ID: primitive.NewObjectID()}}, "next", 3, nil)
+	s.usecase.On("MarkAsRead", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("MarkAllAsRead", mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+
+	s.controller = controllers.NewNotificationController(s.usecase)
+	s.response = httptest.NewRecorder()
+	s.context, s.router = gin.CreateTestContext(s.response)
+
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}
+
+	s.router.GET("/notifications", setCurrentUser, s.controller.GetNotifications)
+	s.router.POST("/notifications/read", setCurrentUser, s.controller.MarkAllAsRead)
+	s.router.POST("/notifications/:notification_id/read", setCurrentUser, s.controller.MarkAsRead)
+}
+
+func (s *notificationControllerSuite) TestGetNotifications() {
+	s.context.Request, _ = http.NewRequest("GET", "/notifications?unread=true&limit=5&cursor=abc", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	var body map[string]interface{}
+	json.NewDecoder(s.response.Body).Decode(&body)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetNotifications", mock.AnythingOfType("primitive.ObjectID"), "abc", 5, true)
+	assert.Equal(s.T(), map[string]interface{}{"next_cursor": "next", "unread_count": float64(3)}, body["meta"])
+}
+
+func (s *notificationControllerSuite) TestGetNotificationsInvalidUnreadFilter() {
+	s.context.Request, _ = http.NewRequest("GET", "/notifications?unread=maybe", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetNotifications", 0)
+}
+
+func (s *notificationControllerSuite) TestMarkAsRead() {
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/notifications/%s/read", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "MarkAsRead", 1)
+	s.usecase.AssertNumberOfCalls(s.T(), "MarkAllAsRead", 0)
+}
+
+func (s *notificationControllerSuite) TestMarkAllAsRead() {
+	s.context.Request, _ = http.NewRequest("POST", "/notifications/read", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "MarkAllAsRead", 1)
+	s.usecase.AssertNumberOfCalls(s.T(), "MarkAsRead", 0)
+}
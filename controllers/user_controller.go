@@ -5,13 +5,26 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/oauth"
 	"github.com/jordyf15/thullo-api/user"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type UserController interface {
 	Register(c *gin.Context)
 	LoginWithGoogle(c *gin.Context)
+	LoginWithOIDC(c *gin.Context)
+	LoginWithGithub(c *gin.Context)
 	Login(c *gin.Context)
+	GetCurrentUser(c *gin.Context)
+	UpdateCurrentUser(c *gin.Context)
+	GetUser(c *gin.Context)
+	ChangePassword(c *gin.Context)
+	ForgotPassword(c *gin.Context)
+	ResetPassword(c *gin.Context)
+	SendVerificationEmail(c *gin.Context)
+	VerifyEmail(c *gin.Context)
 }
 
 type userController struct {
@@ -39,7 +52,27 @@ func (controller *userController) Register(c *gin.Context) {
 }
 
 func (controller *userController) LoginWithGoogle(c *gin.Context) {
-	loginResponse, err := controller.userUsecase.LoginWithGoogle(c.PostForm("token"))
+	loginResponse, err := controller.userUsecase.LoginWithOIDC(oauth.OIDCProviderGoogle, c.PostForm("token"), c.PostForm("nonce"))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loginResponse)
+}
+
+func (controller *userController) LoginWithOIDC(c *gin.Context) {
+	loginResponse, err := controller.userUsecase.LoginWithOIDC(c.Param("provider"), c.PostForm("id_token"), c.PostForm("nonce"))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, loginResponse)
+}
+
+func (controller *userController) LoginWithGithub(c *gin.Context) {
+	loginResponse, err := controller.userUsecase.LoginWithGithub(c.PostForm("code"))
 	if err != nil {
 		respondBasedOnError(c, err)
 		return
@@ -57,3 +90,130 @@ func (controller *userController) Login(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+func (controller *userController) GetCurrentUser(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+
+	user, err := controller.userUsecase.GetByID(userID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(user, nil))
+}
+
+func (controller *userController) UpdateCurrentUser(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+
+	fields := map[string]string{}
+	for _, key := range []string{"name", "username", "bio"} {
+		if value, isExist := c.GetPostForm(key); isExist {
+			fields[key] = value
+		}
+	}
+
+	if len(fields) > 0 {
+		err := controller.userUsecase.UpdateProfile(userID, fields)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	fileHeader, err := c.FormFile("image")
+	if err == http.ErrMissingFile {
+		c.Status(http.StatusNoContent)
+		return
+	} else if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+	defer file.Close()
+
+	err = controller.userUsecase.UpdateDisplayPicture(userID, utils.NewNamedFileReader(file, fileHeader.Filename))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *userController) GetUser(c *gin.Context) {
+	userIDStr := c.Param("user_id")
+
+	userID, err := primitive.ObjectIDFromHex(userIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	profile, err := controller.userUsecase.GetProfile(userID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(profile, nil))
+}
+
+func (controller *userController) ChangePassword(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+
+	err := controller.userUsecase.ChangePassword(userID, c.PostForm("current_password"), c.PostForm("new_password"))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *userController) ForgotPassword(c *gin.Context) {
+	err := controller.userUsecase.ForgotPassword(c.PostForm("email"))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *userController) ResetPassword(c *gin.Context) {
+	err := controller.userUsecase.ResetPassword(c.PostForm("token"), c.PostForm("password"))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *userController) SendVerificationEmail(c *gin.Context) {
+	userID := c.MustGet("current_user_id").(primitive.ObjectID)
+
+	err := controller.userUsecase.SendVerificationEmail(userID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *userController) VerifyEmail(c *gin.Context) {
+	err := controller.userUsecase.VerifyEmail(c.PostForm("token"))
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
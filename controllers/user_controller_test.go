@@ -3,6 +3,7 @@ package controllers_test
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/controllers"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/oauth"
 	"github.com/jordyf15/thullo-api/user/mocks"
 	"github.com/jordyf15/thullo-api/utils"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +33,7 @@ type userControllerSuite struct {
 	controller controllers.UserController
 	response   *httptest.ResponseRecorder
 	context    *gin.Context
+	usecase    *mocks.Usecase
 }
 
 var (
@@ -67,17 +70,42 @@ var (
 )
 
 func (s *userControllerSuite) SetupTest() {
-	usecaseMock := new(mocks.Usecase)
-
-	usecaseMock.On("LoginWithGoogle", mock.AnythingOfType("string")).Return(uscLoginResponse, nil)
-	usecaseMock.On("Login", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(uscLoginResponse, nil)
-
-	s.controller = controllers.NewUserController(usecaseMock)
+	s.usecase = new(mocks.Usecase)
+
+	s.usecase.On("LoginWithOIDC", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(uscLoginResponse, nil)
+	s.usecase.On("LoginWithGithub", mock.AnythingOfType("string")).Return(uscLoginResponse, nil)
+	s.usecase.On("Login", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(uscLoginResponse, nil)
+	s.usecase.On("GetByID", mock.AnythingOfType("primitive.ObjectID")).Return(uscUser, nil)
+	s.usecase.On("GetProfile", mock.AnythingOfType("primitive.ObjectID")).Return(uscUser.Profile(), nil)
+	s.usecase.On("UpdateProfile", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("map[string]string")).Return(nil)
+	s.usecase.On("UpdateDisplayPicture", mock.AnythingOfType("primitive.ObjectID"), mock.Anything).Return(nil)
+	s.usecase.On("ChangePassword", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("ForgotPassword", mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("ResetPassword", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("SendVerificationEmail", mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("VerifyEmail", mock.AnythingOfType("string")).Return(nil)
+
+	s.controller = controllers.NewUserController(s.usecase)
 	s.response = httptest.NewRecorder()
 	s.context, s.router = gin.CreateTestContext(s.response)
 
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", uscUserID)
+		c.Next()
+	}
+
 	s.router.POST("/login/google", s.controller.LoginWithGoogle)
+	s.router.POST("/login/oidc/:provider", s.controller.LoginWithOIDC)
+	s.router.POST("/login/github", s.controller.LoginWithGithub)
 	s.router.POST("/login", s.controller.Login)
+	s.router.GET("/users/me", setCurrentUser, s.controller.GetCurrentUser)
+	s.router.PATCH("/users/me", setCurrentUser, s.controller.UpdateCurrentUser)
+	s.router.POST("/users/me/password", setCurrentUser, s.controller.ChangePassword)
+	s.router.GET("/users/:user_id", setCurrentUser, s.controller.GetUser)
+	s.router.POST("/password/forgot", s.controller.ForgotPassword)
+	s.router.POST("/password/reset", s.controller.ResetPassword)
+	s.router.POST("/users/me/verification", setCurrentUser, s.controller.SendVerificationEmail)
+	s.router.POST("/email/verify", s.controller.VerifyEmail)
 }
 
 func (s *userControllerSuite) TestLogin() {
@@ -159,6 +187,48 @@ func (s *userControllerSuite) TestLogin() {
 	assert.Equal(s.T(), float64(1), expiresAt)
 }
 
+func (s *userControllerSuite) TestLoginWithOIDC() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	idToken, _ := writer.CreateFormField("id_token")
+	idToken.Write([]byte("idtoken"))
+	nonce, _ := writer.CreateFormField("nonce")
+	nonce.Write([]byte("nonce"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", "/login/oidc/keycloak", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "LoginWithOIDC", "keycloak", "idtoken", "nonce")
+}
+
+func (s *userControllerSuite) TestLoginWithGithub() {
+	var receivedResponse map[string]interface{}
+
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	code, _ := writer.CreateFormField("code")
+	code.Write([]byte("code"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", "/login/github", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "LoginWithGithub", "code")
+
+	meta, isExist := receivedResponse["meta"].(map[string]interface{})
+	assert.True(s.T(), isExist)
+
+	accessToken, isExist := meta["access_token"]
+	assert.True(s.T(), isExist)
+	assert.Equal(s.T(), "accessToken", accessToken)
+}
+
 func (s *userControllerSuite) TestLoginWithGoogle() {
 	var receivedResponse map[string]interface{}
 
@@ -174,6 +244,7 @@ func (s *userControllerSuite) TestLoginWithGoogle() {
 	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
 
 	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "LoginWithOIDC", oauth.OIDCProviderGoogle, "token", "")
 
 	data, isExist := receivedResponse["data"].(map[string]interface{})
 	assert.True(s.T(), isExist)
@@ -235,3 +306,149 @@ func (s *userControllerSuite) TestLoginWithGoogle() {
 	assert.True(s.T(), isExist)
 	assert.Equal(s.T(), float64(1), expiresAt)
 }
+
+func (s *userControllerSuite) TestGetCurrentUser() {
+	var receivedResponse map[string]interface{}
+
+	s.context.Request, _ = http.NewRequest("GET", "/users/me", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetByID", uscUserID)
+
+	data, isExist := receivedResponse["data"].(map[string]interface{})
+	assert.True(s.T(), isExist)
+	assert.Equal(s.T(), "jojo@gmail.com", data["email"])
+}
+
+func (s *userControllerSuite) TestGetUser() {
+	var receivedResponse map[string]interface{}
+
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/users/%s", uscUserID.Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+	json.NewDecoder(s.response.Body).Decode(&receivedResponse)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "GetProfile", uscUserID)
+
+	data, isExist := receivedResponse["data"].(map[string]interface{})
+	assert.True(s.T(), isExist)
+	assert.Equal(s.T(), "jojo", data["username"])
+	_, isExist = data["email"]
+	assert.False(s.T(), isExist)
+}
+
+func (s *userControllerSuite) TestGetUserInvalidID() {
+	s.context.Request, _ = http.NewRequest("GET", "/users/invalid", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetProfile", 0)
+}
+
+func (s *userControllerSuite) TestUpdateCurrentUserProfileOnly() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	name, _ := writer.CreateFormField("name")
+	name.Write([]byte("jonathan joestar"))
+	bio, _ := writer.CreateFormField("bio")
+	bio.Write([]byte(""))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", "/users/me", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "UpdateProfile", uscUserID, map[string]string{"name": "jonathan joestar", "bio": ""})
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateDisplayPicture", 0)
+}
+
+func (s *userControllerSuite) TestUpdateCurrentUserDisplayPictureOnly() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	image, _ := writer.CreateFormFile("image", "avatar.png")
+	image.Write([]byte("image"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", "/users/me", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateProfile", 0)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateDisplayPicture", 1)
+}
+
+func (s *userControllerSuite) TestChangePassword() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	currentPassword, _ := writer.CreateFormField("current_password")
+	currentPassword.Write([]byte("Password123!"))
+	newPassword, _ := writer.CreateFormField("new_password")
+	newPassword.Write([]byte("NewPassword123!"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", "/users/me/password", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "ChangePassword", uscUserID, "Password123!", "NewPassword123!")
+}
+
+func (s *userControllerSuite) TestForgotPassword() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	email, _ := writer.CreateFormField("email")
+	email.Write([]byte("jojo@gmail.com"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", "/password/forgot", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "ForgotPassword", "jojo@gmail.com")
+}
+
+func (s *userControllerSuite) TestResetPassword() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	token, _ := writer.CreateFormField("token")
+	token.Write([]byte("resettoken"))
+	password, _ := writer.CreateFormField("password")
+	password.Write([]byte("NewPassword123!"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", "/password/reset", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "ResetPassword", "resettoken", "NewPassword123!")
+}
+
+func (s *userControllerSuite) TestSendVerificationEmail() {
+	s.context.Request, _ = http.NewRequest("POST", "/users/me/verification", nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "SendVerificationEmail", uscUserID)
+}
+
+func (s *userControllerSuite) TestVerifyEmail() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	token, _ := writer.CreateFormField("token")
+	token.Write([]byte("verificationtoken"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", "/email/verify", buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "VerifyEmail", "verificationtoken")
+}
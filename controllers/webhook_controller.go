@@ -0,0 +1,186 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/utils"
+	"github.com/jordyf15/thullo-api/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type WebhookController interface {
+	Create(c *gin.Context)
+	GetBoardWebhooks(c *gin.Context)
+	Update(c *gin.Context)
+	Delete(c *gin.Context)
+	GetDeliveryLogs(c *gin.Context)
+}
+
+type webhookController struct {
+	usecase webhook.Usecase
+}
+
+func NewWebhookController(usecase webhook.Usecase) WebhookController {
+	return &webhookController{usecase: usecase}
+}
+
+func (controller *webhookController) Create(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	url := strings.TrimSpace(c.PostForm("url"))
+	eventTypes := parseEventTypes(c.PostForm("event_types"))
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Create(requesterID, boardID, url, eventTypes)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *webhookController) GetBoardWebhooks(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	webhooks, err := controller.usecase.GetBoardWebhooks(requesterID, boardID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(webhooks, nil))
+}
+
+func (controller *webhookController) Update(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	webhookIDStr := c.Param("webhook_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	webhookID, err := primitive.ObjectIDFromHex(webhookIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	url, isExist := c.GetPostForm("url")
+	if isExist {
+		err = controller.usecase.UpdateURL(requesterID, boardID, webhookID, strings.TrimSpace(url))
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	eventTypesStr, isExist := c.GetPostForm("event_types")
+	if isExist {
+		err = controller.usecase.UpdateEventTypes(requesterID, boardID, webhookID, parseEventTypes(eventTypesStr))
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	activeStr, isExist := c.GetPostForm("active")
+	if isExist {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+
+		err = controller.usecase.UpdateActive(requesterID, boardID, webhookID, active)
+		if err != nil {
+			respondBasedOnError(c, err)
+			return
+		}
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *webhookController) Delete(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	webhookIDStr := c.Param("webhook_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	webhookID, err := primitive.ObjectIDFromHex(webhookIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	err = controller.usecase.Delete(requesterID, boardID, webhookID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (controller *webhookController) GetDeliveryLogs(c *gin.Context) {
+	requesterID := c.MustGet("current_user_id").(primitive.ObjectID)
+	boardIDStr := c.Param("board_id")
+	webhookIDStr := c.Param("webhook_id")
+
+	boardID, err := primitive.ObjectIDFromHex(boardIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	webhookID, err := primitive.ObjectIDFromHex(webhookIDStr)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	deliveryLogs, err := controller.usecase.GetDeliveryLogs(requesterID, boardID, webhookID)
+	if err != nil {
+		respondBasedOnError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, utils.DataResponse(deliveryLogs, nil))
+}
+
+// event types are sent as a comma separated list, an empty list subscribes the webhook to every event
+func parseEventTypes(eventTypesStr string) []string {
+	eventTypes := []string{}
+	for _, eventType := range strings.Split(eventTypesStr, ",") {
+		eventType = strings.TrimSpace(eventType)
+		if eventType != "" {
+			eventTypes = append(eventTypes, eventType)
+		}
+	}
+
+	return eventTypes
+}
@@ -0,0 +1,141 @@
+package controllers_test
+
+import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/webhook/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWebhookController(t *testing.T) {
+	suite.Run(t, new(webhookControllerSuite))
+}
+
+type webhookControllerSuite struct {
+	suite.Suite
+
+	router     *gin.Engine
+	controller controllers.WebhookController
+	response   *httptest.ResponseRecorder
+	context    *gin.Context
+	usecase    *mocks.Usecase
+}
+
+func (s *webhookControllerSuite) SetupTest() {
+	s.usecase = new(mocks.Usecase)
+
+	s.usecase.On("Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string"), mock.AnythingOfType("[]string")).Return(nil)
+	s.usecase.On("GetBoardWebhooks", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Webhook{{ID: primitive.NewObjectID()}}, nil)
+	s.usecase.On("UpdateURL", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("string")).Return(nil)
+	s.usecase.On("UpdateEventTypes", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("[]string")).Return(nil)
+	s.usecase.On("UpdateActive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("bool")).Return(nil)
+	s.usecase.On("Delete", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.usecase.On("GetDeliveryLogs", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return([]*models.WebhookDeliveryLog{{ID: primitive.NewObjectID()}}, nil)
+
+	s.controller = controllers.NewWebhookController(s.usecase)
+	s.response = httptest.NewRecorder()
+	s.context, s.router = gin.CreateTestContext(s.response)
+
+	setCurrentUser := func(c *gin.Context) {
+		c.Set("current_user_id", primitive.NewObjectID())
+		c.Next()
+	}
+
+	s.router.POST("/boards/:board_id/webhooks", setCurrentUser, s.controller.Create)
+	s.router.GET("/boards/:board_id/webhooks", setCurrentUser, s.controller.GetBoardWebhooks)
+	s.router.PATCH("/boards/:board_id/webhooks/:webhook_id", setCurrentUser, s.controller.Update)
+	s.router.DELETE("/boards/:board_id/webhooks/:webhook_id", setCurrentUser, s.controller.Delete)
+	s.router.GET("/boards/:board_id/webhooks/:webhook_id/deliveries", setCurrentUser, s.controller.GetDeliveryLogs)
+}
+
+func (s *webhookControllerSuite) TestCreate() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	url, _ := writer.CreateFormField("url")
+	url.Write([]byte(" https://example.com/hooks "))
+	eventTypes, _ := writer.CreateFormField("event_types")
+	eventTypes.Write([]byte("card.created, ,comment.created"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("POST", fmt.Sprintf("/boards/%s/webhooks", primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertCalled(s.T(), "Create", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), "https://example.com/hooks", []string{models.BoardEventCardCreated, models.BoardEventCommentCreated})
+}
+
+func (s *webhookControllerSuite) TestGetBoardWebhooks() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/webhooks", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetBoardWebhooks", 1)
+}
+
+func (s *webhookControllerSuite) TestUpdateInvalidActive() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	active, _ := writer.CreateFormField("active")
+	active.Write([]byte("maybe"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/webhooks/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateActive", 0)
+}
+
+func (s *webhookControllerSuite) TestUpdateActiveOnly() {
+	buf := new(bytes.Buffer)
+	writer := multipart.NewWriter(buf)
+	active, _ := writer.CreateFormField("active")
+	active.Write([]byte("true"))
+	writer.Close()
+
+	s.context.Request, _ = http.NewRequest("PATCH", fmt.Sprintf("/boards/%s/webhooks/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), buf)
+	s.context.Request.Header.Set("Content-Type", writer.FormDataContentType())
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateURL", 0)
+	s.usecase.AssertNumberOfCalls(s.T(), "UpdateEventTypes", 0)
+	s.usecase.AssertCalled(s.T(), "UpdateActive", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID"), true)
+}
+
+func (s *webhookControllerSuite) TestDeleteInvalidWebhookID() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/webhooks/invalid", primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.NotEqual(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *webhookControllerSuite) TestDelete() {
+	s.context.Request, _ = http.NewRequest("DELETE", fmt.Sprintf("/boards/%s/webhooks/%s", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusNoContent, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "Delete", 1)
+}
+
+func (s *webhookControllerSuite) TestGetDeliveryLogs() {
+	s.context.Request, _ = http.NewRequest("GET", fmt.Sprintf("/boards/%s/webhooks/%s/deliveries", primitive.NewObjectID().Hex(), primitive.NewObjectID().Hex()), nil)
+	s.router.ServeHTTP(s.response, s.context.Request)
+
+	assert.Equal(s.T(), http.StatusOK, s.response.Code)
+	s.usecase.AssertNumberOfCalls(s.T(), "GetDeliveryLogs", 1)
+}
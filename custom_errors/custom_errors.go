@@ -8,6 +8,8 @@ var (
 	ErrInvalidIDInPath     = newErr(102, "Invalid ID in path")
 	ErrRecordNotFound      = newErr(103, "Record not found")
 	ErrNotAuthorized       = newErr(104, "You are not authorized to perform this action")
+	ErrInvalidCursor       = newErr(105, "Pagination cursor is invalid")
+	ErrConflictingUpdate   = newErr(106, "Resource is being updated by another request, please try again")
 
 	// User Errors
 	ErrCurrentPasswordWrong          = newErr(201, "Wrong current password")
@@ -24,15 +26,25 @@ var (
 	ErrPasswordInvalid               = newErr(212, "Password is invalid")
 	ErrImageFormatInvalid            = newErr(213, "Image format must be in JPEG format")
 	ErrImageSizeTooLarge             = newErr(214, "Image size is too large")
+	ErrBioTooLong                    = newErr(215, "Bio is too long")
+	ErrEmailAddressAlreadyVerified   = newErr(216, "Email address is already verified")
 
 	// token errors
-	ErrMalformedRefreshToken   = newErr(301, "Refresh token is malformed")
-	ErrInvalidRefreshToken     = newErr(302, "Invalid refresh token")
-	ErrRefreshTokenNotFound    = newErr(303, "Refresh token not found")
-	ErrMalformedAccessToken    = newErr(304, "Access token is malformed")
-	ErrInvalidAccessToken      = newErr(305, "Invalid access token")
-	ErrAccessTokenExpired      = newErr(306, "Access token expired")
-	ErrGoogleOauthTokenExpired = newErr(307, "Google oauth token expired")
+	ErrMalformedRefreshToken         = newErr(301, "Refresh token is malformed")
+	ErrInvalidRefreshToken           = newErr(302, "Invalid refresh token")
+	ErrRefreshTokenNotFound          = newErr(303, "Refresh token not found")
+	ErrMalformedAccessToken          = newErr(304, "Access token is malformed")
+	ErrInvalidAccessToken            = newErr(305, "Invalid access token")
+	ErrAccessTokenExpired            = newErr(306, "Access token expired")
+	ErrGoogleOauthTokenExpired       = newErr(307, "Google oauth token expired")
+	ErrPasswordResetTokenInvalid     = newErr(308, "Password reset token is invalid or has expired")
+	ErrEmailVerificationTokenInvalid = newErr(309, "Email verification token is invalid or has expired")
+	ErrGithubOauthCodeInvalid        = newErr(310, "Github oauth code is invalid or has expired")
+	ErrGithubEmailNotVerified        = newErr(311, "Github account has no verified primary email address")
+	ErrOIDCProviderUnknown           = newErr(312, "OpenID Connect provider is unknown")
+	ErrIDTokenInvalid                = newErr(313, "ID token is invalid")
+	ErrOauthEmailNotVerified         = newErr(314, "Email address has not been verified by the provider")
+	ErrIDTokenExpired                = newErr(315, "ID token expired")
 
 	// cover errors
 	ErrMalformedCover             = newErr(401, "Cover is malformed")
@@ -48,17 +60,54 @@ var (
 	ErrUserIsAlreadyBoardMember = newErr(504, "User is already a board member")
 	ErrInvalidBoardMemberRole   = newErr(505, "Board member role is invalid")
 	ErrBoardMustHaveAnAdmin     = newErr(506, "Board must have atleast one admin")
+	ErrBoardInvalidSortOption   = newErr(507, "Board sort option is invalid")
+	ErrBoardArchived            = newErr(508, "Board is archived")
+	ErrBoardNotArchived         = newErr(509, "Board is not archived")
+	ErrBoardInvalidDueFilter    = newErr(510, "Board due filter is invalid")
+	ErrUserEmailNotVerified     = newErr(511, "User has not verified their email address")
 
 	// list errors
-	ErrListTitleEmpty      = newErr(601, "List title is empty")
-	ErrListPositionTooLow  = newErr(602, "List position is too low")
-	ErrListPositionTooHigh = newErr(603, "List position is too high")
+	ErrListTitleEmpty        = newErr(601, "List title is empty")
+	ErrListPositionTooLow    = newErr(602, "List position is too low")
+	ErrListPositionTooHigh   = newErr(603, "List position is too high")
+	ErrListArchived          = newErr(604, "List is archived")
+	ErrListNotArchived       = newErr(605, "List is not archived")
+	ErrListInvalidCardAction = newErr(606, "Action for the cards of the list is invalid")
+	ErrListInvalidTargetList = newErr(607, "Target list for the cards is invalid")
 
 	// card errors
-	ErrCardTitleEmpty = newErr(701, "Card title is empty")
+	ErrCardTitleEmpty        = newErr(701, "Card title is empty")
+	ErrCardArchived          = newErr(702, "Card is archived")
+	ErrCardNotArchived       = newErr(703, "Card is not archived")
+	ErrCardPositionTooLow    = newErr(704, "Card position is too low")
+	ErrCardPositionTooHigh   = newErr(705, "Card position is too high")
+	ErrCardDateInvalid       = newErr(706, "Card date must be in RFC 3339 format")
+	ErrCardStartAfterDue     = newErr(707, "Card start date must not be after its due date")
+	ErrCardAssigneeNotMember = newErr(708, "Card assignee must be a member of the board")
 
 	// comment errors
 	ErrCommentEmpty = newErr(801, "Comment is empty")
+
+	// attachment errors
+	ErrAttachmentEmpty    = newErr(901, "Attachment file is empty")
+	ErrAttachmentTooLarge = newErr(902, "Attachment file is too large")
+	ErrAttachmentNotImage = newErr(903, "Only image attachments can be used as a cover")
+
+	// label errors
+	ErrLabelNameEmpty     = newErr(1001, "Label name is empty")
+	ErrLabelNameTooLong   = newErr(1002, "Label name is too long")
+	ErrLabelColorInvalid  = newErr(1003, "Label color must be a hex color such as #61BD4F")
+	ErrLabelFilterInvalid = newErr(1004, "Label filter is invalid")
+
+	// checklist errors
+	ErrChecklistTitleEmpty          = newErr(1101, "Checklist title is empty")
+	ErrChecklistItemTitleEmpty      = newErr(1102, "Checklist item title is empty")
+	ErrChecklistItemPositionTooLow  = newErr(1103, "Checklist item position is too low")
+	ErrChecklistItemPositionTooHigh = newErr(1104, "Checklist item position is too high")
+
+	// webhook errors
+	ErrWebhookURLInvalid       = newErr(1201, "Webhook URL must be an absolute http or https URL")
+	ErrWebhookEventTypeInvalid = newErr(1202, "Webhook event type is invalid")
 )
 
 type Error struct {
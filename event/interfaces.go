@@ -0,0 +1,30 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// StreamHeartbeatInterval keeps idle streams from being closed by proxies
+	StreamHeartbeatInterval = 30 * time.Second
+	// MaxQueuedEvents bounds the events waiting for a consumer, older events are dropped first
+	MaxQueuedEvents = 10000
+)
+
+type Repository interface {
+	// Publish is best effort, failures are logged instead of returned since the change is already
+	// stored by then and clients that missed the event catch up by reading the board again
+	Publish(event *models.BoardEvent)
+	Subscribe(ctx context.Context, boardID primitive.ObjectID) (<-chan *models.BoardEvent, error)
+	// Consume blocks until a published event is available or ctx is done, unlike subscriptions
+	// every event is only handed to one consumer across all instances
+	Consume(ctx context.Context) (*models.BoardEvent, error)
+}
+
+type Usecase interface {
+	Subscribe(ctx context.Context, requesterID, boardID primitive.ObjectID) (<-chan *models.BoardEvent, error)
+}
@@ -0,0 +1,84 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/jordyf15/thullo-api/models"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Consume provides a mock function with given fields: ctx
+func (_m *Repository) Consume(ctx context.Context) (*models.BoardEvent, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *models.BoardEvent
+	if rf, ok := ret.Get(0).(func(context.Context) *models.BoardEvent); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.BoardEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Publish provides a mock function with given fields: _a0
+func (_m *Repository) Publish(_a0 *models.BoardEvent) {
+	_m.Called(_a0)
+}
+
+// Subscribe provides a mock function with given fields: ctx, boardID
+func (_m *Repository) Subscribe(ctx context.Context, boardID primitive.ObjectID) (<-chan *models.BoardEvent, error) {
+	ret := _m.Called(ctx, boardID)
+
+	var r0 <-chan *models.BoardEvent
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID) <-chan *models.BoardEvent); ok {
+		r0 = rf(ctx, boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *models.BoardEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,56 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/jordyf15/thullo-api/models"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// Subscribe provides a mock function with given fields: ctx, requesterID, boardID
+func (_m *Usecase) Subscribe(ctx context.Context, requesterID primitive.ObjectID, boardID primitive.ObjectID) (<-chan *models.BoardEvent, error) {
+	ret := _m.Called(ctx, requesterID, boardID)
+
+	var r0 <-chan *models.BoardEvent
+	if rf, ok := ret.Get(0).(func(context.Context, primitive.ObjectID, primitive.ObjectID) <-chan *models.BoardEvent); ok {
+		r0 = rf(ctx, requesterID, boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan *models.BoardEvent)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(ctx, requesterID, boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+type mockConstructorTestingTNewUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsecase creates a new instance of Usecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsecase(t mockConstructorTestingTNewUsecase) *Usecase {
+	mock := &Usecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
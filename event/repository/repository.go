@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v9"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RedisChannelBoardEventsPrefix = "board_events:"
+	RedisKeyBoardEventsQueue      = "board_events_queue"
+	consumeTimeout                = time.Second
+	// activeWebhooksTTL is how long it is remembered whether a board has active webhooks,
+	// events of a board whose first webhook was just activated may be left out of the queue for that long
+	activeWebhooksTTL = 10 * time.Second
+)
+
+type activeWebhooksEntry struct {
+	hasActiveWebhooks bool
+	expiresAt         time.Time
+}
+
+type eventRepository struct {
+	redis       *redis.Client
+	webhookRepo webhook.Repository
+
+	activeWebhooksMutex sync.Mutex
+	activeWebhooks      map[primitive.ObjectID]activeWebhooksEntry
+}
+
+func NewEventRepository(redis *redis.Client, webhookRepo webhook.Repository) event.Repository {
+	return &eventRepository{
+		redis:          redis,
+		webhookRepo:    webhookRepo,
+		activeWebhooks: make(map[primitive.ObjectID]activeWebhooksEntry),
+	}
+}
+
+func (repo *eventRepository) Publish(_event *models.BoardEvent) {
+	_event.CreatedAt = time.Now()
+
+	payload, err := json.Marshal(_event)
+	if err != nil {
+		log.Printf("failed to encode %s event of board %s: %s", _event.Type, _event.BoardID.Hex(), err)
+		return
+	}
+
+	// only the events of boards that have a webhook to send them to are queued for the dispatcher,
+	// when that can not be checked the event is queued anyway and the dispatcher sorts it out
+	isQueued, err := repo.hasActiveWebhooks(_event.BoardID)
+	if err != nil {
+		log.Printf("failed to check the webhooks of board %s: %s", _event.BoardID.Hex(), err)
+		isQueued = true
+	}
+
+	ctx := context.Background()
+	var queueLength *redis.IntCmd
+	_, err = repo.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Publish(ctx, boardEventsChannel(_event.BoardID), payload)
+
+		if isQueued {
+			queueLength = pipe.LPush(ctx, RedisKeyBoardEventsQueue, payload)
+			pipe.LTrim(ctx, RedisKeyBoardEventsQueue, 0, event.MaxQueuedEvents-1)
+		}
+
+		return nil
+	})
+	if err != nil {
+		log.Printf("failed to publish %s event of board %s: %s", _event.Type, _event.BoardID.Hex(), err)
+		return
+	}
+
+	if queueLength != nil && queueLength.Val() > event.MaxQueuedEvents {
+		log.Printf("dropped %d queued board events, the webhook dispatcher is falling behind", queueLength.Val()-event.MaxQueuedEvents)
+	}
+}
+
+// Subscribe returns the events of the board that are published by any instance until ctx is done,
+// the returned channel is closed afterwards
+func (repo *eventRepository) Subscribe(ctx context.Context, boardID primitive.ObjectID) (<-chan *models.BoardEvent, error) {
+	pubsub := repo.redis.Subscribe(ctx, boardEventsChannel(boardID))
+
+	// waiting for the confirmation makes sure events published after Subscribe returns are not missed
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		pubsub.Close()
+		return nil, err
+	}
+
+	events := make(chan *models.BoardEvent)
+
+	go func() {
+		defer close(events)
+		defer pubsub.Close()
+
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, isOpen := <-messages:
+				if !isOpen {
+					return
+				}
+
+				_event := &models.BoardEvent{}
+				err := json.Unmarshal([]byte(message.Payload), _event)
+				if err != nil {
+					log.Printf("failed to decode event of board %s: %s", boardID.Hex(), err)
+					continue
+				}
+
+				select {
+				case events <- _event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+func (repo *eventRepository) Consume(ctx context.Context) (*models.BoardEvent, error) {
+	for {
+		// blocking for a limited time lets ctx be checked in between
+		result, err := repo.redis.BRPop(ctx, consumeTimeout, RedisKeyBoardEventsQueue).Result()
+		if err == redis.Nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		// the result holds the key followed by the value
+		_event := &models.BoardEvent{}
+		err = json.Unmarshal([]byte(result[1]), _event)
+		if err != nil {
+			log.Printf("failed to decode queued event: %s", err)
+			continue
+		}
+
+		return _event, nil
+	}
+}
+
+// hasActiveWebhooks is checked on every mutation of the board so the answer is kept for a while
+// instead of reading the webhooks of the board every time, failed reads are not kept
+func (repo *eventRepository) hasActiveWebhooks(boardID primitive.ObjectID) (bool, error) {
+	now := time.Now()
+
+	repo.activeWebhooksMutex.Lock()
+	entry, isExist := repo.activeWebhooks[boardID]
+	repo.activeWebhooksMutex.Unlock()
+
+	if isExist && now.Before(entry.expiresAt) {
+		return entry.hasActiveWebhooks, nil
+	}
+
+	webhooks, err := repo.webhookRepo.GetBoardWebhooks(boardID)
+	if err != nil {
+		return false, err
+	}
+
+	hasActiveWebhooks := false
+	for _, _webhook := range webhooks {
+		if _webhook.Active {
+			hasActiveWebhooks = true
+			break
+		}
+	}
+
+	repo.activeWebhooksMutex.Lock()
+	defer repo.activeWebhooksMutex.Unlock()
+
+	// the entries of boards that are no longer active are dropped so the map does not keep growing
+	for cachedBoardID, cachedEntry := range repo.activeWebhooks {
+		if !now.Before(cachedEntry.expiresAt) {
+			delete(repo.activeWebhooks, cachedBoardID)
+		}
+	}
+
+	repo.activeWebhooks[boardID] = activeWebhooksEntry{hasActiveWebhooks: hasActiveWebhooks, expiresAt: now.Add(activeWebhooksTTL)}
+
+	return hasActiveWebhooks, nil
+}
+
+func boardEventsChannel(boardID primitive.ObjectID) string {
+	return fmt.Sprintf("%s%s", RedisChannelBoardEventsPrefix, boardID.Hex())
+}
@@ -0,0 +1,168 @@
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v9"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/event/repository"
+	"github.com/jordyf15/thullo-api/models"
+	wr "github.com/jordyf15/thullo-api/webhook/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEventRepository(t *testing.T) {
+	suite.Run(t, new(eventRepositorySuite))
+}
+
+type eventRepositorySuite struct {
+	suite.Suite
+	miniredis   *miniredis.Miniredis
+	webhookRepo *wr.Repository
+	repository  event.Repository
+}
+
+var (
+	boardID1 = primitive.NewObjectID()
+	boardID2 = primitive.NewObjectID()
+	boardID3 = primitive.NewObjectID()
+	boardID4 = primitive.NewObjectID()
+	actorID  = primitive.NewObjectID()
+)
+
+func (s *eventRepositorySuite) SetupTest() {
+	_miniredis, err := miniredis.Run()
+	if err != nil {
+		s.T().Fatalf("An error occured: %s", err)
+	}
+
+	s.webhookRepo = new(wr.Repository)
+	s.webhookRepo.On("GetBoardWebhooks", boardID1).Return([]*models.Webhook{{Active: true}}, nil)
+	s.webhookRepo.On("GetBoardWebhooks", boardID2).Return([]*models.Webhook{{Active: false}, {Active: true}}, nil)
+	s.webhookRepo.On("GetBoardWebhooks", boardID3).Return([]*models.Webhook{{Active: false}}, nil)
+	s.webhookRepo.On("GetBoardWebhooks", boardID4).Return(nil, errors.New("database is unreachable"))
+
+	s.miniredis = _miniredis
+	s.repository = repository.NewEventRepository(redis.NewClient(&redis.Options{
+		Addr: _miniredis.Addr(),
+	}), s.webhookRepo)
+}
+
+func (s *eventRepositorySuite) TearDownTest() {
+	s.miniredis.Close()
+}
+
+func (s *eventRepositorySuite) TestSubscribeReceivesBoardEvents() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.repository.Subscribe(ctx, boardID1)
+	assert.NoError(s.T(), err)
+
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventListCreated, BoardID: boardID2, ActorID: actorID})
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID1, ActorID: actorID})
+
+	select {
+	case _event := <-events:
+		assert.Equal(s.T(), models.BoardEventCardCreated, _event.Type)
+		assert.Equal(s.T(), boardID1, _event.BoardID)
+		assert.Equal(s.T(), actorID, _event.ActorID)
+		assert.False(s.T(), _event.CreatedAt.IsZero())
+	case <-time.After(time.Second):
+		s.T().Fatal("event was not received")
+	}
+}
+
+func (s *eventRepositorySuite) TestSubscribeClosedWhenContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := s.repository.Subscribe(ctx, boardID1)
+	assert.NoError(s.T(), err)
+
+	cancel()
+
+	select {
+	case _, isOpen := <-events:
+		assert.False(s.T(), isOpen)
+	case <-time.After(time.Second):
+		s.T().Fatal("channel was not closed")
+	}
+}
+
+func (s *eventRepositorySuite) TestConsumeReturnsEachEventOnce() {
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventListCreated, BoardID: boardID1, ActorID: actorID})
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID2, ActorID: actorID})
+
+	_event, err := s.repository.Consume(context.Background())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.BoardEventListCreated, _event.Type)
+	assert.Equal(s.T(), boardID1, _event.BoardID)
+
+	_event, err = s.repository.Consume(context.Background())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), models.BoardEventCardCreated, _event.Type)
+}
+
+func (s *eventRepositorySuite) TestPublishQueuesOnlyBoardsWithActiveWebhooks() {
+	s.webhookRepo.On("GetBoardWebhooks", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Webhook{}, nil)
+
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventListCreated, BoardID: boardID3, ActorID: actorID})
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: primitive.NewObjectID(), ActorID: actorID})
+
+	queue, err := s.miniredis.List(repository.RedisKeyBoardEventsQueue)
+	assert.Error(s.T(), err)
+	assert.Empty(s.T(), queue)
+}
+
+func (s *eventRepositorySuite) TestPublishQueuesWhenWebhooksCannotBeChecked() {
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventListCreated, BoardID: boardID4, ActorID: actorID})
+
+	_event, err := s.repository.Consume(context.Background())
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), boardID4, _event.BoardID)
+}
+
+func (s *eventRepositorySuite) TestPublishChecksWebhooksOncePerBoard() {
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventListCreated, BoardID: boardID1, ActorID: actorID})
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID1, ActorID: actorID})
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventListCreated, BoardID: boardID3, ActorID: actorID})
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID3, ActorID: actorID})
+
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "GetBoardWebhooks", 2)
+
+	queue, err := s.miniredis.List(repository.RedisKeyBoardEventsQueue)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), queue, 2)
+}
+
+func (s *eventRepositorySuite) TestPublishChecksWebhooksAgainAfterFailure() {
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventListCreated, BoardID: boardID4, ActorID: actorID})
+	s.repository.Publish(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID4, ActorID: actorID})
+
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "GetBoardWebhooks", 2)
+}
+
+func (s *eventRepositorySuite) TestConsumeStopsWhenContextDone() {
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_event, err := s.repository.Consume(ctx)
+
+	assert.Error(s.T(), err)
+	assert.Nil(s.T(), _event)
+}
+
+func (s *eventRepositorySuite) TestPublishWithoutRedis() {
+	s.miniredis.Close()
+
+	assert.NotPanics(s.T(), func() {
+		s.repository.Publish(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID1, ActorID: actorID})
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/jordyf15/thullo-api/board"
+	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type eventUsecase struct {
+	eventRepo       event.Repository
+	boardRepo       board.Repository
+	boardMemberRepo board_member.Repository
+}
+
+func NewEventUsecase(eventRepo event.Repository, boardRepo board.Repository, boardMemberRepo board_member.Repository) event.Usecase {
+	return &eventUsecase{eventRepo: eventRepo, boardRepo: boardRepo, boardMemberRepo: boardMemberRepo}
+}
+
+// Subscribe returns the events of the board for as long as the requester can see the board,
+// the returned channel is closed when ctx is done, the board is deleted or the requester loses access to it
+func (usecase *eventUsecase) Subscribe(ctx context.Context, requesterID, boardID primitive.ObjectID) (<-chan *models.BoardEvent, error) {
+	err := board.CheckIfRequesterCanSeeBoard(usecase.boardRepo, usecase.boardMemberRepo, requesterID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	boardEvents, err := usecase.eventRepo.Subscribe(ctx, boardID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	events := make(chan *models.BoardEvent)
+
+	go func() {
+		defer close(events)
+		defer cancel()
+
+		for _event := range boardEvents {
+			select {
+			case events <- _event:
+			case <-ctx.Done():
+				return
+			}
+
+			switch _event.Type {
+			case models.BoardEventBoardDeleted:
+				return
+			// removed members and visibility changes can take away the access of the requester
+			case models.BoardEventMemberRemoved, models.BoardEventBoardUpdated:
+				err := board.CheckIfRequesterCanSeeBoard(usecase.boardRepo, usecase.boardMemberRepo, requesterID, boardID)
+				if err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	return events, nil
+}
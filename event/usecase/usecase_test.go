@@ -0,0 +1,194 @@
+package usecase_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	br "github.com/jordyf15/thullo-api/board/mocks"
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	"github.com/jordyf15/thullo-api/event/usecase"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestEventUsecase(t *testing.T) {
+	suite.Run(t, new(eventUsecaseSuite))
+}
+
+var (
+	privateBoard = &models.Board{
+		ID:         primitive.NewObjectID(),
+		Visibility: models.BoardVisibilityPrivate,
+	}
+	publicBoard = &models.Board{
+		ID:         primitive.NewObjectID(),
+		Visibility: models.BoardVisibilityPublic,
+	}
+
+	boardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		BoardID: privateBoard.ID,
+		UserID:  primitive.NewObjectID(),
+		Role:    models.MemberRoleAdmin,
+	}
+)
+
+type eventUsecaseSuite struct {
+	suite.Suite
+
+	usecase         event.Usecase
+	eventRepo       *er.Repository
+	boardRepo       *br.Repository
+	boardMemberRepo *bmr.Repository
+
+	boardEvents  chan *models.BoardEvent
+	boardMembers []*models.BoardMember
+}
+
+func (s *eventUsecaseSuite) SetupTest() {
+	s.eventRepo = new(er.Repository)
+	s.boardRepo = new(br.Repository)
+	s.boardMemberRepo = new(bmr.Repository)
+
+	s.boardEvents = make(chan *models.BoardEvent, 10)
+	s.boardMembers = []*models.BoardMember{boardMember}
+
+	// like the repository, the subscription is closed once ctx is done
+	s.eventRepo.On("Subscribe", mock.Anything, mock.AnythingOfType("primitive.ObjectID")).Return(func(ctx context.Context, boardID primitive.ObjectID) <-chan *models.BoardEvent {
+		publishedEvents, boardEvents := s.boardEvents, make(chan *models.BoardEvent)
+		go func() {
+			defer close(boardEvents)
+
+			for {
+				select {
+				case _event := <-publishedEvents:
+					boardEvents <- _event
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		return boardEvents
+	}, nil)
+	s.boardRepo.On("GetBoardByID", mock.AnythingOfType("primitive.ObjectID")).Return(func(boardID primitive.ObjectID) *models.Board {
+		switch boardID {
+		case privateBoard.ID:
+			return privateBoard
+		case publicBoard.ID:
+			return publicBoard
+		}
+
+		return nil
+	}, func(boardID primitive.ObjectID) error {
+		if boardID != privateBoard.ID && boardID != publicBoard.ID {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(func(boardID primitive.ObjectID) []*models.BoardMember {
+		if boardID == privateBoard.ID {
+			return s.boardMembers
+		}
+
+		return []*models.BoardMember{}
+	}, nil)
+
+	s.usecase = usecase.NewEventUsecase(s.eventRepo, s.boardRepo, s.boardMemberRepo)
+}
+
+func (s *eventUsecaseSuite) TestSubscribeBoardNotFound() {
+	_, err := s.usecase.Subscribe(context.Background(), boardMember.UserID, primitive.NewObjectID())
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.eventRepo.AssertNumberOfCalls(s.T(), "Subscribe", 0)
+}
+
+func (s *eventUsecaseSuite) TestSubscribePrivateBoardNotAuthorized() {
+	_, err := s.usecase.Subscribe(context.Background(), primitive.NewObjectID(), privateBoard.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.eventRepo.AssertNumberOfCalls(s.T(), "Subscribe", 0)
+}
+
+func (s *eventUsecaseSuite) TestSubscribePublicBoardByNonMember() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.usecase.Subscribe(ctx, primitive.NewObjectID(), publicBoard.ID)
+
+	assert.NoError(s.T(), err)
+
+	s.boardEvents <- &models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: publicBoard.ID}
+
+	_event := s.receive(events)
+	assert.NotNil(s.T(), _event)
+	assert.Equal(s.T(), models.BoardEventCardCreated, _event.Type)
+}
+
+func (s *eventUsecaseSuite) TestSubscribeClosedWhenBoardDeleted() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.usecase.Subscribe(ctx, boardMember.UserID, privateBoard.ID)
+
+	assert.NoError(s.T(), err)
+
+	s.boardEvents <- &models.BoardEvent{Type: models.BoardEventBoardDeleted, BoardID: privateBoard.ID}
+	s.boardEvents <- &models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: privateBoard.ID}
+
+	_event := s.receive(events)
+	assert.Equal(s.T(), models.BoardEventBoardDeleted, _event.Type)
+	assert.Nil(s.T(), s.receive(events))
+}
+
+func (s *eventUsecaseSuite) TestSubscribeClosedWhenRequesterRemoved() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, err := s.usecase.Subscribe(ctx, boardMember.UserID, privateBoard.ID)
+
+	assert.NoError(s.T(), err)
+
+	s.boardMembers = []*models.BoardMember{}
+	s.boardEvents <- &models.BoardEvent{Type: models.BoardEventMemberRemoved, BoardID: privateBoard.ID, Data: boardMember}
+	s.boardEvents <- &models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: privateBoard.ID}
+
+	_event := s.receive(events)
+	assert.Equal(s.T(), models.BoardEventMemberRemoved, _event.Type)
+	assert.Nil(s.T(), s.receive(events))
+}
+
+func (s *eventUsecaseSuite) TestSubscribeClosedWhenContextDone() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	events, err := s.usecase.Subscribe(ctx, boardMember.UserID, privateBoard.ID)
+
+	assert.NoError(s.T(), err)
+
+	cancel()
+
+	assert.Nil(s.T(), s.receive(events))
+}
+
+// receive returns the next event of the stream or nil once the stream is closed
+func (s *eventUsecaseSuite) receive(events <-chan *models.BoardEvent) *models.BoardEvent {
+	select {
+	case _event := <-events:
+		return _event
+	case <-time.After(time.Second):
+		s.T().Fatal("stream was neither closed nor received an event")
+	}
+
+	return nil
+}
@@ -0,0 +1,34 @@
+package label
+
+import (
+	"regexp"
+
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var (
+	ColorRegex = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+)
+
+const (
+	NameMaxLength = 50
+)
+
+type Repository interface {
+	Create(label *models.Label, activity *models.Activity) error
+	GetLabelByID(labelID primitive.ObjectID) (*models.Label, error)
+	GetBoardLabels(boardID primitive.ObjectID) ([]*models.Label, error)
+	Update(label *models.Label, activity *models.Activity) error
+	Delete(label *models.Label, updatedCards []*models.Card, activity *models.Activity) error
+}
+
+type Usecase interface {
+	Create(requesterID, boardID primitive.ObjectID, name, color string) error
+	GetBoardLabels(requesterID, boardID primitive.ObjectID) ([]*models.Label, error)
+	UpdateName(requesterID, boardID, labelID primitive.ObjectID, name string) error
+	UpdateColor(requesterID, boardID, labelID primitive.ObjectID, color string) error
+	Delete(requesterID, boardID, labelID primitive.ObjectID) error
+	AttachToCard(requesterID, boardID, listID, cardID, labelID primitive.ObjectID) error
+	DetachFromCard(requesterID, boardID, listID, cardID, labelID primitive.ObjectID) error
+}
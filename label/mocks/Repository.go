@@ -0,0 +1,118 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: _a0, activity
+func (_m *Repository) Create(_a0 *models.Label, activity *models.Activity) error {
+	ret := _m.Called(_a0, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Label, *models.Activity) error); ok {
+		r0 = rf(_a0, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: _a0, updatedCards, activity
+func (_m *Repository) Delete(_a0 *models.Label, updatedCards []*models.Card, activity *models.Activity) error {
+	ret := _m.Called(_a0, updatedCards, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Label, []*models.Card, *models.Activity) error); ok {
+		r0 = rf(_a0, updatedCards, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBoardLabels provides a mock function with given fields: boardID
+func (_m *Repository) GetBoardLabels(boardID primitive.ObjectID) ([]*models.Label, error) {
+	ret := _m.Called(boardID)
+
+	var r0 []*models.Label
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.Label); ok {
+		r0 = rf(boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Label)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetLabelByID provides a mock function with given fields: labelID
+func (_m *Repository) GetLabelByID(labelID primitive.ObjectID) (*models.Label, error) {
+	ret := _m.Called(labelID)
+
+	var r0 *models.Label
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *models.Label); ok {
+		r0 = rf(labelID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Label)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(labelID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: _a0, activity
+func (_m *Repository) Update(_a0 *models.Label, activity *models.Activity) error {
+	ret := _m.Called(_a0, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Label, *models.Activity) error); ok {
+		r0 = rf(_a0, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,137 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// AttachToCard provides a mock function with given fields: requesterID, boardID, listID, cardID, labelID
+func (_m *Usecase) AttachToCard(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, labelID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, labelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, labelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: requesterID, boardID, name, color
+func (_m *Usecase) Create(requesterID primitive.ObjectID, boardID primitive.ObjectID, name string, color string) error {
+	ret := _m.Called(requesterID, boardID, name, color)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, string, string) error); ok {
+		r0 = rf(requesterID, boardID, name, color)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: requesterID, boardID, labelID
+func (_m *Usecase) Delete(requesterID primitive.ObjectID, boardID primitive.ObjectID, labelID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, labelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, labelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DetachFromCard provides a mock function with given fields: requesterID, boardID, listID, cardID, labelID
+func (_m *Usecase) DetachFromCard(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardID primitive.ObjectID, labelID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardID, labelID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardID, labelID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBoardLabels provides a mock function with given fields: requesterID, boardID
+func (_m *Usecase) GetBoardLabels(requesterID primitive.ObjectID, boardID primitive.ObjectID) ([]*models.Label, error) {
+	ret := _m.Called(requesterID, boardID)
+
+	var r0 []*models.Label
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) []*models.Label); ok {
+		r0 = rf(requesterID, boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Label)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(requesterID, boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateColor provides a mock function with given fields: requesterID, boardID, labelID, color
+func (_m *Usecase) UpdateColor(requesterID primitive.ObjectID, boardID primitive.ObjectID, labelID primitive.ObjectID, color string) error {
+	ret := _m.Called(requesterID, boardID, labelID, color)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, labelID, color)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateName provides a mock function with given fields: requesterID, boardID, labelID, name
+func (_m *Usecase) UpdateName(requesterID primitive.ObjectID, boardID primitive.ObjectID, labelID primitive.ObjectID, name string) error {
+	ret := _m.Called(requesterID, boardID, labelID, name)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, labelID, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsecase creates a new instance of Usecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsecase(t mockConstructorTestingTNewUsecase) *Usecase {
+	mock := &Usecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/label"
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type labelRepository struct {
+	dbClient *db.Client
+}
+
+func NewLabelRepository(dbClient *db.Client) label.Repository {
+	return &labelRepository{dbClient: dbClient}
+}
+
+func (repo *labelRepository) Create(label *models.Label, _activity *models.Activity) error {
+	label.ID = primitive.NewObjectID()
+	label.CreatedAt = time.Now()
+	label.UpdatedAt = label.CreatedAt
+
+	updates := map[string]interface{}{
+		fmt.Sprintf("labels/%s", label.ID.Hex()): label,
+	}
+
+	if _activity != nil {
+		_activity.TargetID = label.ID
+	}
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *labelRepository) GetLabelByID(labelID primitive.ObjectID) (*models.Label, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("labels/%s", labelID.Hex()))
+
+	label := &models.Label{}
+
+	err := ref.Get(ctx, &label)
+	if err != nil {
+		return nil, err
+	}
+
+	if label == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return label, nil
+}
+
+func (repo *labelRepository) GetBoardLabels(boardID primitive.ObjectID) ([]*models.Label, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("labels").OrderByChild("board_id").EqualTo(boardID.Hex())
+
+	labelsMap := make(map[string]*models.Label)
+
+	err := ref.Get(ctx, &labelsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	labels := []*models.Label{}
+
+	for _, label := range labelsMap {
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+func (repo *labelRepository) Update(label *models.Label, _activity *models.Activity) error {
+	label.UpdatedAt = time.Now()
+
+	updates := map[string]interface{}{
+		fmt.Sprintf("labels/%s", label.ID.Hex()): label,
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+// Delete removes the label together with its ID from the labels of the cards it was detached from,
+// only the labels of the cards are written so changes made to the rest of the cards are kept
+func (repo *labelRepository) Delete(label *models.Label, updatedCards []*models.Card, _activity *models.Activity) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("labels/%s", label.ID.Hex()): nil,
+	}
+
+	for _, card := range updatedCards {
+		updates[fmt.Sprintf("cards/%s/label_ids", card.ID.Hex())] = card.LabelIDs
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
@@ -0,0 +1,49 @@
+package repository_test
+
+import (
+	"testing"
+
+	acr "github.com/jordyf15/thullo-api/activity/repository"
+	"github.com/jordyf15/thullo-api/label/repository"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLabelChangesRecordActivities(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	assert.NoError(t, err)
+
+	labelRepo := repository.NewLabelRepository(dbClient)
+	activityRepo := acr.NewActivityRepository(dbClient)
+
+	boardID := primitive.NewObjectID()
+	_label := &models.Label{BoardID: boardID, Name: "bug", Color: "#EB5A46"}
+
+	err = labelRepo.Create(_label, &models.Activity{BoardID: boardID, Verb: models.ActivityVerbCreated, Target: models.ActivityTargetLabel})
+	assert.NoError(t, err)
+
+	_label.Name = "defect"
+	err = labelRepo.Update(_label, &models.Activity{BoardID: boardID, Verb: models.ActivityVerbUpdated, Target: models.ActivityTargetLabel, TargetID: _label.ID})
+	assert.NoError(t, err)
+
+	err = labelRepo.Delete(_label, nil, &models.Activity{BoardID: boardID, Verb: models.ActivityVerbDeleted, Target: models.ActivityTargetLabel, TargetID: _label.ID})
+	assert.NoError(t, err)
+
+	// each change is written together with its activity
+	assert.Equal(t, 3, server.Requests("PATCH", "/"))
+
+	activities, err := activityRepo.GetBoardActivities(boardID)
+	assert.NoError(t, err)
+	assert.Len(t, activities, 3)
+	for _, _activity := range activities {
+		assert.Equal(t, _label.ID, _activity.TargetID)
+	}
+
+	_, err = labelRepo.GetLabelByID(_label.ID)
+	assert.Error(t, err)
+}
@@ -0,0 +1,379 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jordyf15/thullo-api/activity"
+	"github.com/jordyf15/thullo-api/board"
+	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/label"
+	"github.com/jordyf15/thullo-api/list"
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type labelUsecase struct {
+	labelRepo       label.Repository
+	boardRepo       board.Repository
+	boardMemberRepo board_member.Repository
+	listRepo        list.Repository
+	cardRepo        card.Repository
+	eventRepo       event.Repository
+}
+
+func NewLabelUsecase(labelRepo label.Repository, boardRepo board.Repository, boardMemberRepo board_member.Repository, listRepo list.Repository, cardRepo card.Repository, eventRepo event.Repository) label.Usecase {
+	return &labelUsecase{labelRepo: labelRepo, boardRepo: boardRepo, boardMemberRepo: boardMemberRepo, listRepo: listRepo, cardRepo: cardRepo, eventRepo: eventRepo}
+}
+
+func (usecase *labelUsecase) Create(requesterID, boardID primitive.ObjectID, name, color string) error {
+	errors := verifyName(name)
+	errors = append(errors, verifyColor(color)...)
+	if len(errors) > 0 {
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return err
+	}
+
+	_label := &models.Label{
+		BoardID: boardID,
+		Name:    name,
+		Color:   strings.ToUpper(color),
+	}
+
+	err = usecase.labelRepo.Create(_label, &models.Activity{
+		BoardID: boardID,
+		ActorID: requesterID,
+		Verb:    models.ActivityVerbCreated,
+		Target:  models.ActivityTargetLabel,
+		After:   _label,
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventLabelCreated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _label,
+	})
+
+	return nil
+}
+
+func (usecase *labelUsecase) GetBoardLabels(requesterID, boardID primitive.ObjectID) ([]*models.Label, error) {
+	_board, err := usecase.boardRepo.GetBoardByID(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the labels of private boards can only be seen by its members
+	if _board.Visibility == models.BoardVisibilityPrivate {
+		err = usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	labels, err := usecase.labelRepo.GetBoardLabels(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].CreatedAt.Before(labels[j].CreatedAt)
+	})
+
+	return labels, nil
+}
+
+func (usecase *labelUsecase) UpdateName(requesterID, boardID, labelID primitive.ObjectID, name string) error {
+	errors := verifyName(name)
+	if len(errors) > 0 {
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	_label, err := usecase.getBoardLabel(requesterID, boardID, labelID)
+	if err != nil {
+		return err
+	}
+
+	_activity := &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUpdated,
+		Target:   models.ActivityTargetLabel,
+		TargetID: _label.ID,
+		Before:   map[string]interface{}{"name": _label.Name},
+		After:    map[string]interface{}{"name": name},
+	}
+
+	_label.Name = name
+
+	err = usecase.labelRepo.Update(_label, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventLabelUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _label,
+	})
+
+	return nil
+}
+
+func (usecase *labelUsecase) UpdateColor(requesterID, boardID, labelID primitive.ObjectID, color string) error {
+	errors := verifyColor(color)
+	if len(errors) > 0 {
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	_label, err := usecase.getBoardLabel(requesterID, boardID, labelID)
+	if err != nil {
+		return err
+	}
+
+	_activity := &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUpdated,
+		Target:   models.ActivityTargetLabel,
+		TargetID: _label.ID,
+		Before:   map[string]interface{}{"color": _label.Color},
+		After:    map[string]interface{}{"color": strings.ToUpper(color)},
+	}
+
+	_label.Color = strings.ToUpper(color)
+
+	err = usecase.labelRepo.Update(_label, _activity)
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventLabelUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _label,
+	})
+
+	return nil
+}
+
+func (usecase *labelUsecase) Delete(requesterID, boardID, labelID primitive.ObjectID) error {
+	_label, err := usecase.getBoardLabel(requesterID, boardID, labelID)
+	if err != nil {
+		return err
+	}
+
+	lists, err := usecase.listRepo.GetBoardLists(boardID)
+	if err != nil {
+		return err
+	}
+
+	// archived cards lose the label as well so it does not come back when they are restored
+	updatedCards := []*models.Card{}
+	for _, list := range lists {
+		cards, err := usecase.cardRepo.GetListCards(list.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, card := range cards {
+			if card.RemoveLabel(_label.ID) {
+				updatedCards = append(updatedCards, card)
+			}
+		}
+	}
+
+	err = usecase.labelRepo.Delete(_label, updatedCards, &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbDeleted,
+		Target:   models.ActivityTargetLabel,
+		TargetID: _label.ID,
+		Before:   _label,
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventLabelDeleted,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _label,
+	})
+
+	return nil
+}
+
+func (usecase *labelUsecase) AttachToCard(requesterID, boardID, listID, cardID, labelID primitive.ObjectID) error {
+	_label, err := usecase.getBoardLabel(requesterID, boardID, labelID)
+	if err != nil {
+		return err
+	}
+
+	_card, err := usecase.getBoardCard(boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	if _card.HasLabel(_label.ID) {
+		return nil
+	}
+
+	prevLabelIDs := _card.LabelIDs
+	_card.LabelIDs = append(_card.LabelIDs, _label.ID)
+	_card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(_card.ID, _card, []string{"label_ids", "updated_at"}, activity.NewCardActivity(requesterID, boardID, _card.ID, models.ActivityVerbUpdated,
+		map[string]interface{}{"label_ids": prevLabelIDs},
+		map[string]interface{}{"label_ids": _card.LabelIDs},
+	))
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _card,
+	})
+
+	return nil
+}
+
+func (usecase *labelUsecase) DetachFromCard(requesterID, boardID, listID, cardID, labelID primitive.ObjectID) error {
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return err
+	}
+
+	// the label itself is not looked up so IDs of labels that no longer exist can still be detached
+	_card, err := usecase.getBoardCard(boardID, listID, cardID)
+	if err != nil {
+		return err
+	}
+
+	prevLabelIDs := append([]primitive.ObjectID{}, _card.LabelIDs...)
+	if !_card.RemoveLabel(labelID) {
+		return nil
+	}
+
+	_card.UpdatedAt = time.Now()
+
+	err = usecase.cardRepo.UpdateCard(_card.ID, _card, []string{"label_ids", "updated_at"}, activity.NewCardActivity(requesterID, boardID, _card.ID, models.ActivityVerbUpdated,
+		map[string]interface{}{"label_ids": prevLabelIDs},
+		map[string]interface{}{"label_ids": _card.LabelIDs},
+	))
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventCardUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    _card,
+	})
+
+	return nil
+}
+
+func (usecase *labelUsecase) getBoardLabel(requesterID, boardID, labelID primitive.ObjectID) (*models.Label, error) {
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	_label, err := usecase.labelRepo.GetLabelByID(labelID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _label.BoardID != boardID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return _label, nil
+}
+
+func (usecase *labelUsecase) getBoardCard(boardID, listID, cardID primitive.ObjectID) (*models.Card, error) {
+	list, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.BoardID != boardID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	_card, err := usecase.cardRepo.GetCardByID(cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _card.ListID != listID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return _card, nil
+}
+
+func (usecase *labelUsecase) checkIfRequesterIsMemberOfBoard(requesterID, boardID primitive.ObjectID) error {
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	// if there are no board members it means there are no board
+	// a board will always atleast have 1 member
+	if len(boardMembers) == 0 {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID {
+			return nil
+		}
+	}
+
+	return custom_errors.ErrNotAuthorized
+}
+
+func verifyName(name string) []error {
+	errors := []error{}
+
+	if name == "" {
+		errors = append(errors, custom_errors.ErrLabelNameEmpty)
+	}
+
+	if len(name) > label.NameMaxLength {
+		errors = append(errors, custom_errors.ErrLabelNameTooLong)
+	}
+
+	return errors
+}
+
+func verifyColor(color string) []error {
+	errors := []error{}
+
+	if !label.ColorRegex.MatchString(color) {
+		errors = append(errors, custom_errors.ErrLabelColorInvalid)
+	}
+
+	return errors
+}
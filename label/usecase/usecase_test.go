@@ -0,0 +1,356 @@
+package usecase_test
+
+import (
+	"strings"
+	"testing"
+
+	br "github.com/jordyf15/thullo-api/board/mocks"
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	cr "github.com/jordyf15/thullo-api/card/mocks"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	"github.com/jordyf15/thullo-api/label"
+	lbr "github.com/jordyf15/thullo-api/label/mocks"
+	"github.com/jordyf15/thullo-api/label/usecase"
+	lr "github.com/jordyf15/thullo-api/list/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestLabelUsecase(t *testing.T) {
+	suite.Run(t, new(labelUsecaseSuite))
+}
+
+var (
+	publicBoard = &models.Board{
+		ID:         primitive.NewObjectID(),
+		Visibility: models.BoardVisibilityPublic,
+	}
+	privateBoard = &models.Board{
+		ID:         primitive.NewObjectID(),
+		Visibility: models.BoardVisibilityPrivate,
+	}
+
+	boardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		UserID:  primitive.NewObjectID(),
+		BoardID: publicBoard.ID,
+	}
+	privateBoardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		UserID:  boardMember.UserID,
+		BoardID: privateBoard.ID,
+	}
+
+	label1 = &models.Label{
+		ID:      primitive.NewObjectID(),
+		BoardID: publicBoard.ID,
+		Name:    "bug",
+		Color:   "#EB5A46",
+	}
+	otherBoardLabel = &models.Label{
+		ID:      primitive.NewObjectID(),
+		BoardID: privateBoard.ID,
+		Name:    "feature",
+		Color:   "#61BD4F",
+	}
+
+	list1 = &models.List{
+		ID:      primitive.NewObjectID(),
+		BoardID: publicBoard.ID,
+	}
+	list2 = &models.List{
+		ID:       primitive.NewObjectID(),
+		BoardID:  publicBoard.ID,
+		Archived: true,
+	}
+
+	card1 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list1.ID,
+	}
+	card2 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list1.ID,
+	}
+	card3 = &models.Card{
+		ID:       primitive.NewObjectID(),
+		ListID:   list2.ID,
+		Archived: true,
+	}
+)
+
+type labelUsecaseSuite struct {
+	suite.Suite
+
+	usecase         label.Usecase
+	labelRepo       *lbr.Repository
+	boardRepo       *br.Repository
+	boardMemberRepo *bmr.Repository
+	listRepo        *lr.Repository
+	cardRepo        *cr.Repository
+	eventRepo       *er.Repository
+
+	createdLabel *models.Label
+	updatedLabel *models.Label
+	updatedCards []*models.Card
+	activities   []*models.Activity
+}
+
+func (s *labelUsecaseSuite) SetupTest() {
+	s.labelRepo = new(lbr.Repository)
+	s.boardRepo = new(br.Repository)
+	s.boardMemberRepo = new(bmr.Repository)
+	s.listRepo = new(lr.Repository)
+	s.cardRepo = new(cr.Repository)
+	s.eventRepo = new(er.Repository)
+
+	s.createdLabel = nil
+	s.updatedLabel = nil
+	s.updatedCards = []*models.Card{}
+	s.activities = nil
+
+	label1.Name = "bug"
+	label1.Color = "#EB5A46"
+	card1.LabelIDs = []primitive.ObjectID{label1.ID}
+	card2.LabelIDs = nil
+	card3.LabelIDs = []primitive.ObjectID{otherBoardLabel.ID, label1.ID}
+
+	getBoardByID := func(boardID primitive.ObjectID) *models.Board {
+		if boardID == privateBoard.ID {
+			return privateBoard
+		}
+
+		return publicBoard
+	}
+
+	getBoardMembers := func(boardID primitive.ObjectID) []*models.BoardMember {
+		switch boardID {
+		case publicBoard.ID:
+			return []*models.BoardMember{boardMember}
+		case privateBoard.ID:
+			return []*models.BoardMember{privateBoardMember}
+		default:
+			return []*models.BoardMember{}
+		}
+	}
+
+	getLabelByID := func(labelID primitive.ObjectID) *models.Label {
+		if labelID == otherBoardLabel.ID {
+			return otherBoardLabel
+		}
+
+		return label1
+	}
+
+	getListByID := func(listID primitive.ObjectID) *models.List {
+		if listID == list2.ID {
+			return list2
+		}
+
+		return list1
+	}
+
+	getListCards := func(listID primitive.ObjectID) []*models.Card {
+		if listID == list2.ID {
+			return []*models.Card{card3}
+		}
+
+		return []*models.Card{card1, card2}
+	}
+
+	getCardByID := func(cardID primitive.ObjectID) *models.Card {
+		switch cardID {
+		case card1.ID:
+			return card1
+		case card2.ID:
+			return card2
+		default:
+			return card3
+		}
+	}
+
+	s.boardRepo.On("GetBoardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardByID, nil)
+	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardMembers, nil)
+	s.labelRepo.On("Create", mock.AnythingOfType("*models.Label"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.createdLabel = args[0].(*models.Label)
+		s.activities = append(s.activities, args[1].(*models.Activity))
+	}).Return(nil)
+	s.labelRepo.On("GetLabelByID", mock.AnythingOfType("primitive.ObjectID")).Return(getLabelByID, nil)
+	s.labelRepo.On("GetBoardLabels", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Label{label1}, nil)
+	s.labelRepo.On("Update", mock.AnythingOfType("*models.Label"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.updatedLabel = args[0].(*models.Label)
+		s.activities = append(s.activities, args[1].(*models.Activity))
+	}).Return(nil)
+	s.labelRepo.On("Delete", mock.AnythingOfType("*models.Label"), mock.AnythingOfType("[]*models.Card"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.updatedCards = args[1].([]*models.Card)
+		s.activities = append(s.activities, args[2].(*models.Activity))
+	}).Return(nil)
+	s.listRepo.On("GetBoardLists", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.List{list1, list2}, nil)
+	s.listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(getListByID, nil)
+	s.cardRepo.On("GetListCards", mock.AnythingOfType("primitive.ObjectID")).Return(getListCards, nil)
+	s.cardRepo.On("GetCardByID", mock.AnythingOfType("primitive.ObjectID")).Return(getCardByID, nil)
+	s.cardRepo.On("UpdateCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.Card"), mock.AnythingOfType("[]string"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		s.updatedCards = append(s.updatedCards, args[1].(*models.Card))
+	}).Return(nil)
+
+	s.eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Return()
+
+	s.usecase = usecase.NewLabelUsecase(s.labelRepo, s.boardRepo, s.boardMemberRepo, s.listRepo, s.cardRepo, s.eventRepo)
+}
+
+func (s *labelUsecaseSuite) TestCreateInvalidFields() {
+	err := s.usecase.Create(boardMember.UserID, publicBoard.ID, "", "green")
+
+	assert.Error(s.T(), err)
+	errors := err.(*custom_errors.MultipleErrors).Errors
+	assert.Len(s.T(), errors, 2)
+	assert.Equal(s.T(), custom_errors.ErrLabelNameEmpty.Error(), errors[0].Error())
+	assert.Equal(s.T(), custom_errors.ErrLabelColorInvalid.Error(), errors[1].Error())
+	s.labelRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *labelUsecaseSuite) TestCreateNameTooLong() {
+	err := s.usecase.Create(boardMember.UserID, publicBoard.ID, strings.Repeat("a", label.NameMaxLength+1), "#61BD4F")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrLabelNameTooLong.Error(), err.(*custom_errors.MultipleErrors).Errors[0].Error())
+}
+
+func (s *labelUsecaseSuite) TestCreateNotAuthorized() {
+	err := s.usecase.Create(primitive.NewObjectID(), publicBoard.ID, "bug", "#EB5A46")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.labelRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *labelUsecaseSuite) TestCreateSuccessful() {
+	err := s.usecase.Create(boardMember.UserID, publicBoard.ID, "feature", "#61bd4f")
+
+	assert.NoError(s.T(), err)
+	s.labelRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	assert.Equal(s.T(), publicBoard.ID, s.createdLabel.BoardID)
+	assert.Equal(s.T(), "feature", s.createdLabel.Name)
+	assert.Equal(s.T(), "#61BD4F", s.createdLabel.Color)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbCreated, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetLabel, s.activities[0].Target)
+	assert.Equal(s.T(), s.createdLabel, s.activities[0].After)
+}
+
+func (s *labelUsecaseSuite) TestGetBoardLabelsPrivateBoardAsNonMember() {
+	labels, err := s.usecase.GetBoardLabels(primitive.NewObjectID(), privateBoard.ID)
+
+	assert.Nil(s.T(), labels)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+}
+
+func (s *labelUsecaseSuite) TestGetBoardLabelsPublicBoardAsNonMember() {
+	labels, err := s.usecase.GetBoardLabels(primitive.NewObjectID(), publicBoard.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), labels, 1)
+}
+
+func (s *labelUsecaseSuite) TestUpdateNameOfOtherBoardLabel() {
+	err := s.usecase.UpdateName(boardMember.UserID, publicBoard.ID, otherBoardLabel.ID, "renamed")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.labelRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *labelUsecaseSuite) TestUpdateNameSuccessful() {
+	err := s.usecase.UpdateName(boardMember.UserID, publicBoard.ID, label1.ID, "defect")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "defect", s.updatedLabel.Name)
+	assert.Equal(s.T(), "#EB5A46", s.updatedLabel.Color)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), map[string]interface{}{"name": "bug"}, s.activities[0].Before)
+	assert.Equal(s.T(), map[string]interface{}{"name": "defect"}, s.activities[0].After)
+}
+
+func (s *labelUsecaseSuite) TestUpdateColorInvalid() {
+	err := s.usecase.UpdateColor(boardMember.UserID, publicBoard.ID, label1.ID, "#GGGGGG")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrLabelColorInvalid.Error(), err.(*custom_errors.MultipleErrors).Errors[0].Error())
+	s.labelRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *labelUsecaseSuite) TestUpdateColorSuccessful() {
+	err := s.usecase.UpdateColor(boardMember.UserID, publicBoard.ID, label1.ID, "#0079bf")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "#0079BF", s.updatedLabel.Color)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), map[string]interface{}{"color": "#EB5A46"}, s.activities[0].Before)
+	assert.Equal(s.T(), map[string]interface{}{"color": "#0079BF"}, s.activities[0].After)
+}
+
+func (s *labelUsecaseSuite) TestDeleteNotAuthorized() {
+	err := s.usecase.Delete(primitive.NewObjectID(), publicBoard.ID, label1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.labelRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *labelUsecaseSuite) TestDeleteRemovesLabelFromCards() {
+	err := s.usecase.Delete(boardMember.UserID, publicBoard.ID, label1.ID)
+
+	assert.NoError(s.T(), err)
+	s.labelRepo.AssertNumberOfCalls(s.T(), "Delete", 1)
+	assert.ElementsMatch(s.T(), []*models.Card{card1, card3}, s.updatedCards)
+	assert.Empty(s.T(), card1.LabelIDs)
+	assert.Equal(s.T(), []primitive.ObjectID{otherBoardLabel.ID}, card3.LabelIDs)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbDeleted, s.activities[0].Verb)
+	assert.Equal(s.T(), label1.ID, s.activities[0].TargetID)
+}
+
+func (s *labelUsecaseSuite) TestAttachToCardOtherBoardLabel() {
+	err := s.usecase.AttachToCard(boardMember.UserID, publicBoard.ID, list1.ID, card2.ID, otherBoardLabel.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *labelUsecaseSuite) TestAttachToCardAlreadyAttached() {
+	err := s.usecase.AttachToCard(boardMember.UserID, publicBoard.ID, list1.ID, card1.ID, label1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	assert.Len(s.T(), card1.LabelIDs, 1)
+}
+
+func (s *labelUsecaseSuite) TestAttachToCardSuccessful() {
+	err := s.usecase.AttachToCard(boardMember.UserID, publicBoard.ID, list1.ID, card2.ID, label1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Equal(s.T(), []primitive.ObjectID{label1.ID}, card2.LabelIDs)
+}
+
+func (s *labelUsecaseSuite) TestDetachFromCardNotAuthorized() {
+	err := s.usecase.DetachFromCard(primitive.NewObjectID(), publicBoard.ID, list1.ID, card1.ID, label1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+}
+
+func (s *labelUsecaseSuite) TestDetachFromCardSuccessful() {
+	err := s.usecase.DetachFromCard(boardMember.UserID, publicBoard.ID, list1.ID, card1.ID, label1.ID)
+
+	assert.NoError(s.T(), err)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 1)
+	assert.Empty(s.T(), card1.LabelIDs)
+}
@@ -5,15 +5,32 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	CardActionArchive = "archive"
+	CardActionDelete  = "delete"
+	CardActionMove    = "move"
+)
+
 type Repository interface {
-	Create(list *models.List) error
+	Create(list *models.List, place func(lists []*models.List) ([]*models.List, error), activity *models.Activity) error
 	GetBoardLists(boardID primitive.ObjectID) ([]*models.List, error)
 	GetListByID(listID primitive.ObjectID) (*models.List, error)
-	UpdateList(listID primitive.ObjectID, list *models.List) error
+	// UpdateList only writes the given fields of the list, the rank of a list is never
+	// written through it since it may only change under the position lock of the board
+	UpdateList(listID primitive.ObjectID, list *models.List, fields []string, activity *models.Activity) error
+	// ReorderBoardLists writes the ranks of the lists that reorder returns under the position lock of the board
+	ReorderBoardLists(boardID primitive.ObjectID, reorder func(lists []*models.List) ([]*models.List, error), activity *models.Activity) error
+	// Archive writes the archived state of the list and of the cards in a single update
+	Archive(list *models.List, cards []*models.Card, activity *models.Activity) error
+	Delete(list *models.List, deletedCards []*models.Card, deletedComments []*models.Comment, deletedAttachments []*models.Attachment, activity *models.Activity) error
+	MigratePositionsToRanks() error
 }
 
 type Usecase interface {
 	Create(requesterID, boardID primitive.ObjectID, title string) error
 	UpdateTitle(requesterID, boardID, listID primitive.ObjectID, title string) error
 	UpdatePosition(requesterID, boardID, listID primitive.ObjectID, newPosition int) error
+	Archive(requesterID, boardID, listID primitive.ObjectID) error
+	Unarchive(requesterID, boardID, listID primitive.ObjectID) error
+	Delete(requesterID, boardID, listID primitive.ObjectID, cardAction string, targetListID primitive.ObjectID) error
 }
@@ -14,13 +14,41 @@ type Repository struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: _a0
-func (_m *Repository) Create(_a0 *models.List) error {
-	ret := _m.Called(_a0)
+// Archive provides a mock function with given fields: _a0, cards, activity
+func (_m *Repository) Archive(_a0 *models.List, cards []*models.Card, activity *models.Activity) error {
+	ret := _m.Called(_a0, cards, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(*models.List) error); ok {
-		r0 = rf(_a0)
+	if rf, ok := ret.Get(0).(func(*models.List, []*models.Card, *models.Activity) error); ok {
+		r0 = rf(_a0, cards, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: _a0, place, activity
+func (_m *Repository) Create(_a0 *models.List, place func([]*models.List) ([]*models.List, error), activity *models.Activity) error {
+	ret := _m.Called(_a0, place, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.List, func([]*models.List) ([]*models.List, error), *models.Activity) error); ok {
+		r0 = rf(_a0, place, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: _a0, deletedCards, deletedComments, deletedAttachments, activity
+func (_m *Repository) Delete(_a0 *models.List, deletedCards []*models.Card, deletedComments []*models.Comment, deletedAttachments []*models.Attachment, activity *models.Activity) error {
+	ret := _m.Called(_a0, deletedCards, deletedComments, deletedAttachments, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.List, []*models.Card, []*models.Comment, []*models.Attachment, *models.Activity) error); ok {
+		r0 = rf(_a0, deletedCards, deletedComments, deletedAttachments, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -74,13 +102,41 @@ func (_m *Repository) GetListByID(listID primitive.ObjectID) (*models.List, erro
 	return r0, r1
 }
 
-// UpdateList provides a mock function with given fields: listID, _a1
-func (_m *Repository) UpdateList(listID primitive.ObjectID, _a1 *models.List) error {
-	ret := _m.Called(listID, _a1)
+// MigratePositionsToRanks provides a mock function with given fields:
+func (_m *Repository) MigratePositionsToRanks() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReorderBoardLists provides a mock function with given fields: boardID, reorder, activity
+func (_m *Repository) ReorderBoardLists(boardID primitive.ObjectID, reorder func([]*models.List) ([]*models.List, error), activity *models.Activity) error {
+	ret := _m.Called(boardID, reorder, activity)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, func([]*models.List) ([]*models.List, error), *models.Activity) error); ok {
+		r0 = rf(boardID, reorder, activity)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateList provides a mock function with given fields: listID, _a1, fields, activity
+func (_m *Repository) UpdateList(listID primitive.ObjectID, _a1 *models.List, fields []string, activity *models.Activity) error {
+	ret := _m.Called(listID, _a1, fields, activity)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *models.List) error); ok {
-		r0 = rf(listID, _a1)
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, *models.List, []string, *models.Activity) error); ok {
+		r0 = rf(listID, _a1, fields, activity)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -12,6 +12,20 @@ type Usecase struct {
 	mock.Mock
 }
 
+// Archive provides a mock function with given fields: requesterID, boardID, listID
+func (_m *Usecase) Archive(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: requesterID, boardID, title
 func (_m *Usecase) Create(requesterID primitive.ObjectID, boardID primitive.ObjectID, title string) error {
 	ret := _m.Called(requesterID, boardID, title)
@@ -26,6 +40,34 @@ func (_m *Usecase) Create(requesterID primitive.ObjectID, boardID primitive.Obje
 	return r0
 }
 
+// Delete provides a mock function with given fields: requesterID, boardID, listID, cardAction, targetListID
+func (_m *Usecase) Delete(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, cardAction string, targetListID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID, cardAction, targetListID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID, cardAction, targetListID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Unarchive provides a mock function with given fields: requesterID, boardID, listID
+func (_m *Usecase) Unarchive(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, listID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, listID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdatePosition provides a mock function with given fields: requesterID, boardID, listID, newPosition
 func (_m *Usecase) UpdatePosition(requesterID primitive.ObjectID, boardID primitive.ObjectID, listID primitive.ObjectID, newPosition int) error {
 	ret := _m.Called(requesterID, boardID, listID, newPosition)
@@ -3,12 +3,16 @@ package repository
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/activity"
 	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/list"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -20,15 +24,18 @@ func NewListRepository(dbClient *db.Client) list.Repository {
 	return &listRepository{dbClient: dbClient}
 }
 
-func (repo *listRepository) Create(list *models.List) error {
+// Create stores the list under the position lock of its board, place receives the lists of the board
+// and returns the new list together with the lists whose ranks had to be rebalanced to make room for it
+func (repo *listRepository) Create(list *models.List, place func(lists []*models.List) ([]*models.List, error), _activity *models.Activity) error {
 	list.ID = primitive.NewObjectID()
 	list.CreatedAt = time.Now()
 	list.UpdatedAt = list.CreatedAt
 
-	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("lists/%s", list.ID.Hex()))
+	if _activity != nil {
+		_activity.TargetID = list.ID
+	}
 
-	return ref.Set(ctx, list)
+	return repo.reorderBoardLists(list.BoardID, place, list, _activity)
 }
 
 func (repo *listRepository) GetBoardLists(boardID primitive.ObjectID) ([]*models.List, error) {
@@ -69,9 +76,185 @@ func (repo *listRepository) GetListByID(listID primitive.ObjectID) (*models.List
 	return list, nil
 }
 
-func (repo *listRepository) UpdateList(listID primitive.ObjectID, list *models.List) error {
+func (repo *listRepository) UpdateList(listID primitive.ObjectID, list *models.List, fields []string, _activity *models.Activity) error {
+	updates := map[string]interface{}{}
+
+	err := utils.AddFieldsToUpdates(updates, fmt.Sprintf("lists/%s", listID.Hex()), list, fields...)
+	if err != nil {
+		return err
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
 	ctx := context.Background()
-	ref := repo.dbClient.NewRef(fmt.Sprintf("lists/%s", listID.Hex()))
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *listRepository) Archive(list *models.List, cards []*models.Card, _activity *models.Activity) error {
+	updates := map[string]interface{}{}
+
+	err := utils.AddFieldsToUpdates(updates, fmt.Sprintf("lists/%s", list.ID.Hex()), list, "archived", "archived_at", "updated_at")
+	if err != nil {
+		return err
+	}
+
+	for _, card := range cards {
+		err = utils.AddFieldsToUpdates(updates, fmt.Sprintf("cards/%s", card.ID.Hex()), card, "archived", "archived_at", "updated_at")
+		if err != nil {
+			return err
+		}
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *listRepository) Delete(list *models.List, deletedCards []*models.Card, deletedComments []*models.Comment, deletedAttachments []*models.Attachment, _activity *models.Activity) error {
+	updates := map[string]interface{}{
+		fmt.Sprintf("lists/%s", list.ID.Hex()): nil,
+	}
+
+	for _, card := range deletedCards {
+		updates[fmt.Sprintf("cards/%s", card.ID.Hex())] = nil
+		updates[fmt.Sprintf("checklists/%s", card.ID.Hex())] = nil
+	}
+
+	for _, comment := range deletedComments {
+		updates[fmt.Sprintf("comments/%s", comment.ID.Hex())] = nil
+	}
+
+	for _, attachment := range deletedAttachments {
+		updates[fmt.Sprintf("attachments/%s", attachment.ID.Hex())] = nil
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *listRepository) ReorderBoardLists(boardID primitive.ObjectID, reorder func(lists []*models.List) ([]*models.List, error), _activity *models.Activity) error {
+	return repo.reorderBoardLists(boardID, reorder, nil, _activity)
+}
+
+// reorderBoardLists writes the ranks of the lists returned by reorder under the position lock of the board,
+// createdList is written as a whole when it is given
+func (repo *listRepository) reorderBoardLists(boardID primitive.ObjectID, reorder func(lists []*models.List) ([]*models.List, error), createdList *models.List, _activity *models.Activity) error {
+	ctx := context.Background()
+	lockPath := fmt.Sprintf("list_position_locks/%s", boardID.Hex())
+	lockRef := repo.dbClient.NewRef(lockPath)
+
+	lease, err := utils.AcquireLock(ctx, lockRef)
+	if err != nil {
+		return err
+	}
+
+	isReleased := false
+	defer func() {
+		if !isReleased {
+			utils.ReleaseLock(ctx, lockRef, lease)
+		}
+	}()
+
+	lists, err := repo.GetBoardLists(boardID)
+	if err != nil {
+		return err
+	}
+
+	updatedLists, err := reorder(lists)
+	if err != nil {
+		return err
+	}
+
+	// the new positions are written together with the release of the lock
+	updates := map[string]interface{}{
+		lockPath: nil,
+	}
+
+	for _, list := range updatedLists {
+		if createdList != nil && list.ID == createdList.ID {
+			updates[fmt.Sprintf("lists/%s", list.ID.Hex())] = list
+			continue
+		}
+
+		updates[fmt.Sprintf("lists/%s/rank", list.ID.Hex())] = list.Rank
+	}
+
+	activity.AddToUpdates(updates, _activity)
+
+	ref := repo.dbClient.NewRef("")
+
+	err = ref.Update(ctx, updates)
+	if err != nil {
+		return err
+	}
+
+	isReleased = true
+
+	return nil
+}
+
+// MigratePositionsToRanks gives every list that was stored before ranks were introduced a rank
+// that keeps the order of its position, boards whose lists all have a rank are left untouched
+func (repo *listRepository) MigratePositionsToRanks() error {
+	ctx := context.Background()
+	listsMap := make(map[string]*models.List)
+
+	err := repo.dbClient.NewRef("lists").Get(ctx, &listsMap)
+	if err != nil {
+		return err
+	}
+
+	boardLists := make(map[primitive.ObjectID][]*models.List)
+	for _, list := range listsMap {
+		boardLists[list.BoardID] = append(boardLists[list.BoardID], list)
+	}
+
+	updates := map[string]interface{}{}
+	for _, lists := range boardLists {
+		if !hasListWithoutRank(lists) {
+			continue
+		}
+
+		// archived lists kept their old position, so the active list wins a tie
+		sort.SliceStable(lists, func(i, j int) bool {
+			if lists[i].Position != lists[j].Position {
+				return lists[i].Position < lists[j].Position
+			}
+
+			if lists[i].Archived != lists[j].Archived {
+				return !lists[i].Archived
+			}
+
+			return lists[i].CreatedAt.Before(lists[j].CreatedAt)
+		})
+
+		for i, newRank := range rank.Spread(len(lists)) {
+			updates[fmt.Sprintf("lists/%s/rank", lists[i].ID.Hex())] = newRank
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return repo.dbClient.NewRef("").Update(ctx, updates)
+}
+
+func hasListWithoutRank(lists []*models.List) bool {
+	for _, list := range lists {
+		if list.Rank == "" {
+			return true
+		}
+	}
 
-	return ref.Set(ctx, list)
+	return false
 }
@@ -0,0 +1,126 @@
+package repository_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/list"
+	"github.com/jordyf15/thullo-api/list/repository"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestListRepository(t *testing.T) {
+	suite.Run(t, new(listRepositorySuite))
+}
+
+type listRepositorySuite struct {
+	suite.Suite
+	server     *rtdbtest.Server
+	repository list.Repository
+
+	boardID primitive.ObjectID
+	lists   []*models.List
+}
+
+func (s *listRepositorySuite) SetupTest() {
+	s.server = rtdbtest.NewServer()
+
+	dbClient, err := s.server.NewDatabaseClient()
+	s.Require().NoError(err)
+
+	s.repository = repository.NewListRepository(dbClient)
+
+	s.boardID = primitive.NewObjectID()
+	s.lists = []*models.List{}
+	for _, listRank := range rank.Spread(3) {
+		list := &models.List{ID: primitive.NewObjectID(), BoardID: s.boardID, Rank: listRank}
+		s.Require().NoError(s.server.Set(fmt.Sprintf("lists/%s", list.ID.Hex()), list))
+		s.lists = append(s.lists, list)
+	}
+}
+
+func (s *listRepositorySuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *listRepositorySuite) lockPath() string {
+	return fmt.Sprintf("list_position_locks/%s", s.boardID.Hex())
+}
+
+func (s *listRepositorySuite) TestReorderBoardListsWhileLocked() {
+	err := s.repository.ReorderBoardLists(s.boardID, func(lists []*models.List) ([]*models.List, error) {
+		return nil, s.repository.ReorderBoardLists(s.boardID, func(lists []*models.List) ([]*models.List, error) {
+			return lists, nil
+		}, nil)
+	}, nil)
+
+	assert.Equal(s.T(), custom_errors.ErrConflictingUpdate, err)
+}
+
+func (s *listRepositorySuite) TestReorderBoardListsReleasesLockWhenReorderFails() {
+	reorderErr := errors.New("reorder failed")
+
+	err := s.repository.ReorderBoardLists(s.boardID, func(lists []*models.List) ([]*models.List, error) {
+		return nil, reorderErr
+	}, nil)
+
+	assert.Equal(s.T(), reorderErr, err)
+
+	var lease int64
+	s.server.Get(s.lockPath(), &lease)
+	assert.Zero(s.T(), lease)
+}
+
+func (s *listRepositorySuite) TestReorderBoardListsReleasesLockWhenUpdateFails() {
+	s.server.FailRequests("PATCH", true)
+
+	err := s.repository.ReorderBoardLists(s.boardID, func(lists []*models.List) ([]*models.List, error) {
+		return lists, nil
+	}, nil)
+
+	assert.Error(s.T(), err)
+
+	s.server.FailRequests("PATCH", false)
+
+	err = s.repository.ReorderBoardLists(s.boardID, func(lists []*models.List) ([]*models.List, error) {
+		return lists, nil
+	}, nil)
+
+	assert.NoError(s.T(), err)
+}
+
+func (s *listRepositorySuite) TestReorderBoardListsSuccessful() {
+	movedList := s.lists[0]
+
+	err := s.repository.ReorderBoardLists(s.boardID, func(lists []*models.List) ([]*models.List, error) {
+		assert.Len(s.T(), lists, 3)
+
+		for _, list := range lists {
+			if list.ID == movedList.ID {
+				list.Rank = rank.Spread(4)[3]
+				return []*models.List{list}, nil
+			}
+		}
+
+		return nil, custom_errors.ErrRecordNotFound
+	}, &models.Activity{BoardID: s.boardID, Verb: models.ActivityVerbMoved, Target: models.ActivityTargetList})
+
+	assert.NoError(s.T(), err)
+
+	list, err := s.repository.GetListByID(movedList.ID)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), rank.Spread(4)[3], list.Rank)
+
+	var lease int64
+	s.server.Get(s.lockPath(), &lease)
+	assert.Zero(s.T(), lease)
+	// the positions, the activity and the release of the lock are a single write
+	assert.Equal(s.T(), 1, s.server.Requests("PATCH", "/"))
+}
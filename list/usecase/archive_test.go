@@ -0,0 +1,80 @@
+package usecase_test
+
+import (
+	"fmt"
+	"testing"
+
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	cr "github.com/jordyf15/thullo-api/card/repository"
+	cu "github.com/jordyf15/thullo-api/card/usecase"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	"github.com/jordyf15/thullo-api/list"
+	"github.com/jordyf15/thullo-api/list/repository"
+	"github.com/jordyf15/thullo-api/list/usecase"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestDeleteArchiveCardsCanBeRestored archives a list together with its cards against the realtime
+// database repositories and restores one of the cards afterwards
+func TestDeleteArchiveCardsCanBeRestored(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	boardID := primitive.NewObjectID()
+	requesterID := primitive.NewObjectID()
+	listRepo := repository.NewListRepository(dbClient)
+	cardRepo := cr.NewCardRepository(dbClient)
+	boardMemberRepo := new(bmr.Repository)
+	boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.BoardMember{{UserID: requesterID, BoardID: boardID}}, nil)
+	eventRepo := new(er.Repository)
+	eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Return()
+
+	archivedList := &models.List{ID: primitive.NewObjectID(), BoardID: boardID, Title: "list", Rank: "i"}
+	server.Set(fmt.Sprintf("lists/%s", archivedList.ID.Hex()), archivedList)
+
+	cards := []*models.Card{}
+	for _, cardRank := range rank.Spread(2) {
+		card := &models.Card{ID: primitive.NewObjectID(), ListID: archivedList.ID, Title: "card", Rank: cardRank}
+		server.Set(fmt.Sprintf("cards/%s", card.ID.Hex()), card)
+		cards = append(cards, card)
+	}
+
+	listUsecase := usecase.NewListUsecase(listRepo, nil, boardMemberRepo, cardRepo, nil, nil, eventRepo, nil, nil)
+	cardUsecase := cu.NewCardUsecase(listRepo, cardRepo, boardMemberRepo, nil, nil, nil, eventRepo, nil)
+
+	err = listUsecase.Delete(requesterID, boardID, archivedList.ID, list.CardActionArchive, primitive.NilObjectID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, server.Requests("PATCH", "/"))
+
+	storedList, err := listRepo.GetListByID(archivedList.ID)
+	assert.NoError(t, err)
+	assert.True(t, storedList.Archived)
+	assert.NotNil(t, storedList.ArchivedAt)
+	assert.Equal(t, "list", storedList.Title)
+
+	for _, card := range cards {
+		storedCard, err := cardRepo.GetCardByID(card.ID)
+		assert.NoError(t, err)
+		assert.True(t, storedCard.Archived)
+		assert.Equal(t, card.Rank, storedCard.Rank)
+	}
+
+	err = cardUsecase.Unarchive(requesterID, boardID, archivedList.ID, cards[0].ID)
+	assert.NoError(t, err)
+
+	restoredCard, err := cardRepo.GetCardByID(cards[0].ID)
+	assert.NoError(t, err)
+	assert.False(t, restoredCard.Archived)
+	assert.Nil(t, restoredCard.ArchivedAt)
+	assert.Equal(t, archivedList.ID, restoredCard.ListID)
+}
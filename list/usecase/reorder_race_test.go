@@ -0,0 +1,156 @@
+package usecase_test
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	"github.com/jordyf15/thullo-api/list/repository"
+	"github.com/jordyf15/thullo-api/list/usecase"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/utils"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestUpdatePositionConcurrentReorders runs the reorders against the realtime database repository
+// so they contend for the same position lock the way concurrent requests do
+func TestUpdatePositionConcurrentReorders(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	boardID := primitive.NewObjectID()
+	requesterID := primitive.NewObjectID()
+	listRepo := repository.NewListRepository(dbClient)
+	boardMemberRepo := new(bmr.Repository)
+	boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.BoardMember{{UserID: requesterID, BoardID: boardID}}, nil)
+
+	listCount := 8
+	listIDs := []primitive.ObjectID{}
+	for _, listRank := range rank.Spread(listCount) {
+		list := &models.List{ID: primitive.NewObjectID(), BoardID: boardID, Rank: listRank}
+		server.Set(fmt.Sprintf("lists/%s", list.ID.Hex()), list)
+		listIDs = append(listIDs, list.ID)
+	}
+
+	eventRepo := new(er.Repository)
+	eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Return()
+
+	listUsecase := usecase.NewListUsecase(listRepo, nil, boardMemberRepo, nil, nil, nil, eventRepo, nil, nil)
+
+	// the board starts out locked by another request, so the first reorders have to wait for it
+	lockRef := dbClient.NewRef(fmt.Sprintf("list_position_locks/%s", boardID.Hex()))
+	lease, err := utils.AcquireLock(context.Background(), lockRef)
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	time.AfterFunc(30*time.Millisecond, func() {
+		utils.ReleaseLock(context.Background(), lockRef, lease)
+	})
+
+	workerCount, reordersPerWorker := 4, 5
+	var wg sync.WaitGroup
+	errs := make(chan error, workerCount*reordersPerWorker)
+
+	for i := 0; i < workerCount; i++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+
+			random := rand.New(rand.NewSource(seed))
+			for j := 0; j < reordersPerWorker; j++ {
+				listID := listIDs[random.Intn(listCount)]
+				errs <- listUsecase.UpdatePosition(requesterID, boardID, listID, random.Intn(listCount))
+			}
+		}(int64(i))
+	}
+
+	wg.Wait()
+	close(errs)
+
+	// every reorder that ran into the lock has been retried until it got through
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	lockAttempts := server.Requests("GET", fmt.Sprintf("/list_position_locks/%s", boardID.Hex()))
+	assert.Greater(t, lockAttempts, workerCount*reordersPerWorker, "the reorders never contended for the lock")
+
+	// the positions are derived from the ranks, so distinct ranks mean they form a permutation
+	lists, err := listRepo.GetBoardLists(boardID)
+	assert.NoError(t, err)
+
+	ranks := map[string]bool{}
+	for _, list := range lists {
+		ranks[list.Rank] = true
+	}
+
+	assert.Len(t, ranks, listCount)
+}
+
+func TestConcurrentCreates(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	boardID := primitive.NewObjectID()
+	requesterID := primitive.NewObjectID()
+	listRepo := repository.NewListRepository(dbClient)
+	boardMemberRepo := new(bmr.Repository)
+	boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.BoardMember{{UserID: requesterID, BoardID: boardID}}, nil)
+
+	eventRepo := new(er.Repository)
+	eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Return()
+
+	listUsecase := usecase.NewListUsecase(listRepo, nil, boardMemberRepo, nil, nil, nil, eventRepo, nil, nil)
+
+	creationCount := 4
+	var wg sync.WaitGroup
+	errs := make(chan error, creationCount)
+
+	for i := 0; i < creationCount; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			errs <- listUsecase.Create(requesterID, boardID, fmt.Sprintf("list %d", i))
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	// every creation picked its rank from the lists written by the ones before it
+	lists, err := listRepo.GetBoardLists(boardID)
+	assert.NoError(t, err)
+	assert.Len(t, lists, creationCount)
+
+	ranks := map[string]bool{}
+	for _, list := range lists {
+		ranks[list.Rank] = true
+	}
+
+	assert.Len(t, ranks, creationCount)
+}
@@ -1,13 +1,21 @@
 package usecase
 
 import (
+	"sort"
 	"time"
 
+	"github.com/jordyf15/thullo-api/attachment"
 	"github.com/jordyf15/thullo-api/board"
 	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/card"
+	"github.com/jordyf15/thullo-api/comment"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
 	"github.com/jordyf15/thullo-api/list"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/jordyf15/thullo-api/storage"
+	"github.com/jordyf15/thullo-api/utils"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
@@ -15,10 +23,16 @@ type listUsecase struct {
 	listRepo        list.Repository
 	boardRepo       board.Repository
 	boardMemberRepo board_member.Repository
+	cardRepo        card.Repository
+	commentRepo     comment.Repository
+	attachmentRepo  attachment.Repository
+	eventRepo       event.Repository
+	storage         storage.Storage
+	fileStorage     storage.Storage
 }
 
-func NewListUsecase(listRepo list.Repository, boardRepo board.Repository, boardMemberRepo board_member.Repository) list.Usecase {
-	return &listUsecase{listRepo: listRepo, boardRepo: boardRepo, boardMemberRepo: boardMemberRepo}
+func NewListUsecase(listRepo list.Repository, boardRepo board.Repository, boardMemberRepo board_member.Repository, cardRepo card.Repository, commentRepo comment.Repository, attachmentRepo attachment.Repository, eventRepo event.Repository, storage storage.Storage, fileStorage storage.Storage) list.Usecase {
+	return &listUsecase{listRepo: listRepo, boardRepo: boardRepo, boardMemberRepo: boardMemberRepo, cardRepo: cardRepo, commentRepo: commentRepo, attachmentRepo: attachmentRepo, eventRepo: eventRepo, storage: storage, fileStorage: fileStorage}
 }
 
 func (usecase *listUsecase) Create(requesterID, boardID primitive.ObjectID, title string) error {
@@ -31,22 +45,44 @@ func (usecase *listUsecase) Create(requesterID, boardID primitive.ObjectID, titl
 		return err
 	}
 
-	lists, err := usecase.listRepo.GetBoardLists(boardID)
-	if err != nil {
-		return err
+	newList := &models.List{
+		Title:   title,
+		BoardID: boardID,
 	}
 
-	list := &models.List{
-		Title:    title,
-		BoardID:  boardID,
-		Position: len(lists),
-	}
+	// the rank is picked from the lists read under the board's position lock, so concurrent
+	// creations can't end up with the same rank and a rebalance is written together with the list
+	err = utils.RetryOnConflict(func() error {
+		return usecase.listRepo.Create(newList, func(lists []*models.List) ([]*models.List, error) {
+			activeListCount := 0
+			for _, list := range lists {
+				if !list.Archived {
+					activeListCount++
+				}
+			}
 
-	err = usecase.listRepo.Create(list)
+			sortListsByRank(lists)
+
+			return usecase.placeList(lists, newList, activeListCount), nil
+		}, &models.Activity{
+			BoardID: boardID,
+			ActorID: requesterID,
+			Verb:    models.ActivityVerbCreated,
+			Target:  models.ActivityTargetList,
+			After:   newList,
+		})
+	})
 	if err != nil {
 		return err
 	}
 
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventListCreated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    newList,
+	})
+
 	return nil
 }
 
@@ -69,14 +105,31 @@ func (usecase *listUsecase) UpdateTitle(requesterID, boardID, listID primitive.O
 		return custom_errors.ErrRecordNotFound
 	}
 
+	_activity := &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUpdated,
+		Target:   models.ActivityTargetList,
+		TargetID: listID,
+		Before:   map[string]interface{}{"title": list.Title},
+		After:    map[string]interface{}{"title": title},
+	}
+
 	list.Title = title
 	list.UpdatedAt = time.Now()
 
-	err = usecase.listRepo.UpdateList(listID, list)
+	err = usecase.listRepo.UpdateList(listID, list, []string{"title", "updated_at"}, _activity)
 	if err != nil {
 		return err
 	}
 
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventListUpdated,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    list,
+	})
+
 	return nil
 }
 
@@ -86,49 +139,376 @@ func (usecase *listUsecase) UpdatePosition(requesterID, boardID, listID primitiv
 		return err
 	}
 
-	updatedList, err := usecase.listRepo.GetListByID(listID)
+	// the positions are recalculated from the lists read while holding the board's
+	// position lock, a reorder that runs into another one is retried
+	var updatedLists []*models.List
+	_activity := &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbMoved,
+		Target:   models.ActivityTargetList,
+		TargetID: listID,
+		After:    map[string]interface{}{"position": newPosition},
+	}
+	err = utils.RetryOnConflict(func() error {
+		return usecase.listRepo.ReorderBoardLists(boardID, func(lists []*models.List) ([]*models.List, error) {
+			_activity.Before = map[string]interface{}{"position": listPosition(lists, listID)}
+
+			updatedLists, err = usecase.reorderLists(lists, listID, newPosition)
+			return updatedLists, err
+		}, _activity)
+	})
 	if err != nil {
 		return err
 	}
-	prevPosition := updatedList.Position
 
-	if updatedList.BoardID != boardID {
+	// only the lists that were given a new rank are sent
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventListMoved,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    updatedLists,
+	})
+
+	return nil
+}
+
+func (usecase *listUsecase) reorderLists(lists []*models.List, listID primitive.ObjectID, newPosition int) ([]*models.List, error) {
+	var updatedList *models.List
+	otherLists := []*models.List{}
+	activeListCount := 0
+
+	for _, list := range lists {
+		if !list.Archived {
+			activeListCount++
+		}
+
+		if list.ID == listID {
+			updatedList = list
+		} else {
+			otherLists = append(otherLists, list)
+		}
+	}
+
+	// the list does not belong to the board
+	if updatedList == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	if updatedList.Archived {
+		return nil, custom_errors.ErrListArchived
+	}
+
+	if newPosition < 0 {
+		return nil, custom_errors.ErrListPositionTooLow
+	}
+
+	if newPosition >= activeListCount {
+		return nil, custom_errors.ErrListPositionTooHigh
+	}
+
+	sortListsByRank(otherLists)
+
+	return usecase.placeList(otherLists, updatedList, newPosition), nil
+}
+
+func (usecase *listUsecase) Archive(requesterID, boardID, listID primitive.ObjectID) error {
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return err
+	}
+
+	archivedList, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return err
+	}
+
+	if archivedList.BoardID != boardID {
 		return custom_errors.ErrRecordNotFound
 	}
 
-	lists, err := usecase.listRepo.GetBoardLists(boardID)
+	if archivedList.Archived {
+		return custom_errors.ErrListArchived
+	}
+
+	// the archived list keeps its rank so it is put back
+	// in the same place between the other lists when restored
+	archivedList.Archived = true
+	archivedList.UpdatedAt = time.Now()
+	archivedList.ArchivedAt = &archivedList.UpdatedAt
+
+	err = usecase.listRepo.UpdateList(archivedList.ID, archivedList, []string{"archived", "archived_at", "updated_at"}, &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbArchived,
+		Target:   models.ActivityTargetList,
+		TargetID: archivedList.ID,
+	})
 	if err != nil {
 		return err
 	}
 
-	if newPosition < 0 {
-		return custom_errors.ErrListPositionTooLow
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventListArchived,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    archivedList,
+	})
+
+	return nil
+}
+
+func (usecase *listUsecase) Unarchive(requesterID, boardID, listID primitive.ObjectID) error {
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return err
 	}
 
-	if newPosition >= len(lists) {
-		return custom_errors.ErrListPositionTooHigh
+	restoredList, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return err
 	}
 
-	for _, list := range lists {
-		if updatedList.ID == list.ID {
-			updatedList.Position = newPosition
+	if restoredList.BoardID != boardID {
+		return custom_errors.ErrRecordNotFound
+	}
 
-			err = usecase.listRepo.UpdateList(updatedList.ID, updatedList)
-			if err != nil {
-				return err
-			}
-		} else {
-			isChange := usecase.readjustOtherListPosition(list, prevPosition, newPosition)
+	if !restoredList.Archived {
+		return custom_errors.ErrListNotArchived
+	}
 
-			if isChange {
-				err = usecase.listRepo.UpdateList(list.ID, list)
-				if err != nil {
-					return err
-				}
+	restoredList.Archived = false
+	restoredList.ArchivedAt = nil
+	restoredList.UpdatedAt = time.Now()
+
+	err = usecase.listRepo.UpdateList(restoredList.ID, restoredList, []string{"archived", "archived_at", "updated_at"}, &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbUnarchived,
+		Target:   models.ActivityTargetList,
+		TargetID: restoredList.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventListUnarchived,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    restoredList,
+	})
+
+	return nil
+}
+
+func (usecase *listUsecase) Delete(requesterID, boardID, listID primitive.ObjectID, cardAction string, targetListID primitive.ObjectID) error {
+	if cardAction != list.CardActionArchive && cardAction != list.CardActionDelete && cardAction != list.CardActionMove {
+		return custom_errors.ErrListInvalidCardAction
+	}
+
+	err := usecase.checkIfRequesterIsMemberOfBoard(requesterID, boardID)
+	if err != nil {
+		return err
+	}
+
+	deletedList, err := usecase.listRepo.GetListByID(listID)
+	if err != nil {
+		return err
+	}
+
+	if deletedList.BoardID != boardID {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	cards, err := usecase.cardRepo.GetListCards(deletedList.ID)
+	if err != nil {
+		return err
+	}
+
+	// archived cards can only be restored while their list is around,
+	// so the list is archived together with them instead of being deleted
+	if cardAction == list.CardActionArchive {
+		return usecase.archiveWithCards(requesterID, deletedList, cards)
+	}
+
+	if cardAction == list.CardActionMove {
+		return usecase.deleteAndMoveCards(requesterID, deletedList, targetListID)
+	}
+
+	deletedCards := []*models.Card{}
+	deletedComments := []*models.Comment{}
+	deletedAttachments := []*models.Attachment{}
+	deletedCovers := []*models.BoardCover{}
+
+	for _, card := range cards {
+		comments, err := usecase.commentRepo.GetCardComments(card.ID)
+		if err != nil {
+			return err
+		}
+
+		attachments, err := usecase.attachmentRepo.GetCardAttachments(card.ID)
+		if err != nil {
+			return err
+		}
+
+		deletedCards = append(deletedCards, card)
+		deletedComments = append(deletedComments, comments...)
+		deletedAttachments = append(deletedAttachments, attachments...)
+
+		if card.Cover != nil {
+			deletedCovers = append(deletedCovers, card.Cover)
+		}
+	}
+
+	// what happened to the cards of the list is recorded along with the deletion
+	err = usecase.listRepo.Delete(deletedList, deletedCards, deletedComments, deletedAttachments, &models.Activity{
+		BoardID:  boardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbDeleted,
+		Target:   models.ActivityTargetList,
+		TargetID: deletedList.ID,
+		Before:   deletedList,
+		After:    map[string]interface{}{"card_action": cardAction},
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventListDeleted,
+		BoardID: boardID,
+		ActorID: requesterID,
+		Data:    deletedList,
+	})
+
+	if len(deletedCovers) > 0 {
+		board.DeleteCoverImages(usecase.storage, deletedCovers...)
+	}
+
+	if len(deletedAttachments) > 0 {
+		attachment.DeleteAttachmentFiles(usecase.storage, usecase.fileStorage, deletedAttachments...)
+	}
+
+	return nil
+}
+
+// deleteAndMoveCards deletes the list and appends its cards to the target list in a single update, the cards
+// are read and written under the position locks of both lists so cards that are moved in the meantime are not left behind
+func (usecase *listUsecase) deleteAndMoveCards(requesterID primitive.ObjectID, deletedList *models.List, targetListID primitive.ObjectID) error {
+	targetList, err := usecase.listRepo.GetListByID(targetListID)
+	if err != nil {
+		return err
+	}
+
+	if targetList.BoardID != deletedList.BoardID {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	if targetList.ID == deletedList.ID {
+		return custom_errors.ErrListInvalidTargetList
+	}
+
+	if targetList.Archived {
+		return custom_errors.ErrListArchived
+	}
+
+	var movedCards []*models.Card
+	err = utils.RetryOnConflict(func() error {
+		return usecase.cardRepo.MoveCardsAndDeleteList(deletedList, targetList.ID, func(cards, targetCards []*models.Card) ([]*models.Card, error) {
+			now := time.Now()
+			for _, card := range cards {
+				card.ListID = targetList.ID
+				card.UpdatedAt = now
 			}
+
+			movedCards = cards
+			return usecase.appendCards(targetCards, cards), nil
+		}, &models.Activity{
+			BoardID:  deletedList.BoardID,
+			ActorID:  requesterID,
+			Verb:     models.ActivityVerbDeleted,
+			Target:   models.ActivityTargetList,
+			TargetID: deletedList.ID,
+			Before:   deletedList,
+			After:    map[string]interface{}{"card_action": list.CardActionMove, "target_list_id": targetList.ID},
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	usecase.eventRepo.Publish(&models.BoardEvent{
+		Type:    models.BoardEventListDeleted,
+		BoardID: deletedList.BoardID,
+		ActorID: requesterID,
+		Data:    deletedList,
+	})
+
+	for _, card := range movedCards {
+		usecase.eventRepo.Publish(&models.BoardEvent{
+			Type:    models.BoardEventCardMoved,
+			BoardID: deletedList.BoardID,
+			ActorID: requesterID,
+			Data:    card,
+		})
+	}
+
+	return nil
+}
+
+// archiveWithCards archives the list and the cards in it that are still active in a single update,
+// a list that is already archived only has its remaining cards archived
+func (usecase *listUsecase) archiveWithCards(requesterID primitive.ObjectID, archivedList *models.List, cards []*models.Card) error {
+	now := time.Now()
+
+	isListArchived := archivedList.Archived
+	if !isListArchived {
+		archivedList.Archived = true
+		archivedList.ArchivedAt = &now
+		archivedList.UpdatedAt = now
+	}
+
+	archivedCards := []*models.Card{}
+	for _, card := range cards {
+		if !card.Archived {
+			card.Archived = true
+			card.ArchivedAt = &now
+			card.UpdatedAt = now
+			archivedCards = append(archivedCards, card)
 		}
 	}
 
+	err := usecase.listRepo.Archive(archivedList, archivedCards, &models.Activity{
+		BoardID:  archivedList.BoardID,
+		ActorID:  requesterID,
+		Verb:     models.ActivityVerbArchived,
+		Target:   models.ActivityTargetList,
+		TargetID: archivedList.ID,
+		After:    map[string]interface{}{"card_action": list.CardActionArchive},
+	})
+	if err != nil {
+		return err
+	}
+
+	if !isListArchived {
+		usecase.eventRepo.Publish(&models.BoardEvent{
+			Type:    models.BoardEventListArchived,
+			BoardID: archivedList.BoardID,
+			ActorID: requesterID,
+			Data:    archivedList,
+		})
+	}
+
+	for _, card := range archivedCards {
+		usecase.eventRepo.Publish(&models.BoardEvent{
+			Type:    models.BoardEventCardArchived,
+			BoardID: archivedList.BoardID,
+			ActorID: requesterID,
+			Data:    card,
+		})
+	}
+
 	return nil
 }
 
@@ -159,18 +539,100 @@ func (usecase *listUsecase) checkIfRequesterIsMemberOfBoard(requesterID, boardID
 	return nil
 }
 
-func (usecase *listUsecase) readjustOtherListPosition(otherList *models.List, prevPosition, newPosition int) bool {
-	if newPosition > prevPosition {
-		if otherList.Position > prevPosition && otherList.Position <= newPosition {
-			otherList.Position -= 1
-			return true
+// placeList gives placedList a rank that puts it at position among the active lists,
+// lists must be sorted by rank and must not contain placedList. Only placedList has to be
+// written unless the ranks of the board had to be rebalanced, then every list is returned.
+func (usecase *listUsecase) placeList(lists []*models.List, placedList *models.List, position int) []*models.List {
+	// the list goes right after the active list that currently comes before the position
+	index := 0
+	activeListCount := 0
+	for i := 0; i < len(lists) && activeListCount < position; i++ {
+		if !lists[i].Archived {
+			activeListCount++
+			index = i + 1
+		}
+	}
+
+	prevRank, nextRank := "", ""
+	if index > 0 {
+		prevRank = lists[index-1].Rank
+	}
+
+	if index < len(lists) {
+		nextRank = lists[index].Rank
+	}
+
+	newRank, err := rank.Between(prevRank, nextRank)
+	if err == nil && !rank.NeedsRebalance(newRank) {
+		placedList.Rank = newRank
+		return []*models.List{placedList}
+	}
+
+	// there is no room left between the neighbours or their ranks are broken
+	orderedLists := append([]*models.List{}, lists[:index]...)
+	orderedLists = append(orderedLists, placedList)
+	orderedLists = append(orderedLists, lists[index:]...)
+
+	for i, newRank := range rank.Spread(len(orderedLists)) {
+		orderedLists[i].Rank = newRank
+	}
+
+	return orderedLists
+}
+
+// appendCards gives the moved cards ranks after the cards of the target list while keeping
+// their order, every card is given a new rank when the ranks have to be rebalanced
+func (usecase *listUsecase) appendCards(targetCards, movedCards []*models.Card) []*models.Card {
+	card.SortByRank(targetCards)
+	card.SortByRank(movedCards)
+
+	lastRank := ""
+	if len(targetCards) > 0 {
+		lastRank = targetCards[len(targetCards)-1].Rank
+	}
+
+	for _, card := range movedCards {
+		newRank, err := rank.Between(lastRank, "")
+		if err != nil || rank.NeedsRebalance(newRank) {
+			orderedCards := append([]*models.Card{}, targetCards...)
+			orderedCards = append(orderedCards, movedCards...)
+
+			for i, newRank := range rank.Spread(len(orderedCards)) {
+				orderedCards[i].Rank = newRank
+			}
+
+			return orderedCards
 		}
-	} else {
-		if otherList.Position < prevPosition && otherList.Position >= newPosition {
-			otherList.Position += 1
-			return true
+
+		card.Rank = newRank
+		lastRank = newRank
+	}
+
+	return movedCards
+}
+
+// listPosition returns the position of the list between the active lists of the board, -1 when it is not one of them
+func listPosition(lists []*models.List, listID primitive.ObjectID) int {
+	activeLists := []*models.List{}
+	for _, list := range lists {
+		if !list.Archived {
+			activeLists = append(activeLists, list)
 		}
 	}
 
-	return false
+	sortListsByRank(activeLists)
+
+	for i, list := range activeLists {
+		if list.ID == listID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func sortListsByRank(lists []*models.List) {
+	sort.SliceStable(lists, func(i, j int) bool {
+		return lists[i].Rank < lists[j].Rank
+	})
 }
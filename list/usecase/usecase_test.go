@@ -1,15 +1,23 @@
 package usecase_test
 
 import (
+	"strings"
+	"sync"
 	"testing"
 
+	ar "github.com/jordyf15/thullo-api/attachment/mocks"
 	br "github.com/jordyf15/thullo-api/board/mocks"
 	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	cr "github.com/jordyf15/thullo-api/card/mocks"
+	cmr "github.com/jordyf15/thullo-api/comment/mocks"
 	"github.com/jordyf15/thullo-api/custom_errors"
+	er "github.com/jordyf15/thullo-api/event/mocks"
 	"github.com/jordyf15/thullo-api/list"
 	lr "github.com/jordyf15/thullo-api/list/mocks"
 	"github.com/jordyf15/thullo-api/list/usecase"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/rank"
+	sr "github.com/jordyf15/thullo-api/storage/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
@@ -43,28 +51,48 @@ var (
 	}
 
 	list1 = &models.List{
-		ID:       primitive.NewObjectID(),
-		BoardID:  board1.ID,
-		Title:    "title 1",
-		Position: 0,
+		ID:      primitive.NewObjectID(),
+		BoardID: board1.ID,
+		Title:   "title 1",
+		Rank:    "a",
 	}
 	list2 = &models.List{
-		ID:       primitive.NewObjectID(),
-		BoardID:  board1.ID,
-		Title:    "title 2",
-		Position: 1,
+		ID:      primitive.NewObjectID(),
+		BoardID: board1.ID,
+		Title:   "title 2",
+		Rank:    "i",
 	}
 	list3 = &models.List{
-		ID:       primitive.NewObjectID(),
-		BoardID:  board1.ID,
-		Title:    "title 3",
-		Position: 2,
+		ID:      primitive.NewObjectID(),
+		BoardID: board1.ID,
+		Title:   "title 3",
+		Rank:    "r",
 	}
 	list4 = &models.List{
-		ID:       primitive.NewObjectID(),
-		BoardID:  board2.ID,
-		Title:    "title 1",
-		Position: 0,
+		ID:      primitive.NewObjectID(),
+		BoardID: board2.ID,
+		Title:   "title 1",
+		Rank:    "i",
+	}
+
+	card1 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list2.ID,
+		Title:  "card 1",
+		Rank:   "a",
+		Cover:  &models.BoardCover{Images: []*models.Image{{ID: "image-1"}, {ID: "image-2"}}},
+	}
+	card2 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list2.ID,
+		Title:  "card 2",
+		Rank:   "i",
+	}
+	card3 = &models.Card{
+		ID:     primitive.NewObjectID(),
+		ListID: list3.ID,
+		Title:  "card 3",
+		Rank:   "i",
 	}
 )
 
@@ -75,17 +103,51 @@ type listUsecaseSuite struct {
 	listRepo        *lr.Repository
 	boardRepo       *br.Repository
 	boardMemberRepo *bmr.Repository
+	cardRepo        *cr.Repository
+	commentRepo     *cmr.Repository
+	attachmentRepo  *ar.Repository
+	eventRepo       *er.Repository
+	storage         *sr.Storage
+	fileStorage     *sr.Storage
+
+	createdList        *models.List
+	updatedLists       []*models.List
+	updatedCards       []*models.Card
+	deletedCards       []*models.Card
+	deletedComments    []*models.Comment
+	deletedAttachments []*models.Attachment
+	events             []*models.BoardEvent
+	activities         []*models.Activity
 }
 
 func (s *listUsecaseSuite) SetupTest() {
 	s.boardRepo = new(br.Repository)
 	s.listRepo = new(lr.Repository)
 	s.boardMemberRepo = new(bmr.Repository)
-
-	// need to reset list position
-	list1.Position = 0
-	list2.Position = 1
-	list3.Position = 2
+	s.cardRepo = new(cr.Repository)
+	s.commentRepo = new(cmr.Repository)
+	s.attachmentRepo = new(ar.Repository)
+	s.eventRepo = new(er.Repository)
+	s.storage = new(sr.Storage)
+	s.fileStorage = new(sr.Storage)
+
+	// need to reset list rank
+	list1.Rank = "a"
+	list2.Rank = "i"
+	list3.Rank = "r"
+	list1.Archived = false
+	list2.Archived = false
+	list2.ArchivedAt = nil
+	list3.Archived = false
+	card1.ListID = list2.ID
+	card2.ListID = list2.ID
+	card1.Rank = "a"
+	card2.Rank = "i"
+	card3.Rank = "i"
+	card1.Archived = false
+	card2.Archived = false
+	card1.ArchivedAt = nil
+	card2.ArchivedAt = nil
 
 	getListByID := func(listID primitive.ObjectID) *models.List {
 		if listID == list3.ID {
@@ -107,13 +169,81 @@ func (s *listUsecaseSuite) SetupTest() {
 		return []*models.BoardMember{}
 	}
 
+	s.activities = []*models.Activity{}
+	recordActivity := func(args mock.Arguments) {
+		if _activity, _ := args.Get(len(args) - 1).(*models.Activity); _activity != nil {
+			s.activities = append(s.activities, _activity)
+		}
+	}
+
 	s.listRepo.On("GetBoardLists", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.List{list1, list2, list3}, nil)
-	s.listRepo.On("Create", mock.AnythingOfType("*models.List")).Return(nil)
+	s.listRepo.On("Create", mock.AnythingOfType("*models.List"), mock.Anything, mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		recordActivity(args)
+		s.createdList = args[0].(*models.List)
+	}).Return(func(list *models.List, place func([]*models.List) ([]*models.List, error), _activity *models.Activity) error {
+		updatedLists, err := place([]*models.List{list1, list2, list3})
+		s.updatedLists = updatedLists
+		return err
+	})
 	s.listRepo.On("GetListByID", mock.AnythingOfType("primitive.ObjectID")).Return(getListByID, nil)
-	s.listRepo.On("UpdateList", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.List")).Return(nil)
+	s.listRepo.On("UpdateList", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.List"), mock.AnythingOfType("[]string"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
 	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(getBoardMembers, nil)
 
-	s.usecase = usecase.NewListUsecase(s.listRepo, s.boardRepo, s.boardMemberRepo)
+	getListCards := func(listID primitive.ObjectID) []*models.Card {
+		switch listID {
+		case list2.ID:
+			return []*models.Card{card2, card1}
+		case list3.ID:
+			return []*models.Card{card3}
+		}
+
+		return []*models.Card{}
+	}
+
+	s.updatedLists = nil
+	s.listRepo.On("ReorderBoardLists", mock.AnythingOfType("primitive.ObjectID"), mock.Anything, mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(func(boardID primitive.ObjectID, reorder func([]*models.List) ([]*models.List, error), _activity *models.Activity) error {
+		updatedLists, err := reorder([]*models.List{list1, list2, list3})
+		s.updatedLists = updatedLists
+		return err
+	})
+	s.cardRepo.On("GetListCards", mock.AnythingOfType("primitive.ObjectID")).Return(getListCards, nil)
+	s.cardRepo.On("UpdateCard", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("*models.Card"), mock.AnythingOfType("[]string"), mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(nil)
+	s.commentRepo.On("GetCardComments", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Comment{{ID: primitive.NewObjectID()}}, nil)
+	s.attachmentRepo.On("GetCardAttachments", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Attachment{{ID: primitive.NewObjectID(), File: &models.Image{ID: "file"}}}, nil)
+	s.listRepo.On("Delete", mock.AnythingOfType("*models.List"), mock.AnythingOfType("[]*models.Card"), mock.AnythingOfType("[]*models.Comment"), mock.AnythingOfType("[]*models.Attachment"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		recordActivity(args)
+		s.deletedCards = args[1].([]*models.Card)
+		s.deletedComments = args[2].([]*models.Comment)
+		s.deletedAttachments = args[3].([]*models.Attachment)
+	}).Return(nil)
+	s.cardRepo.On("MoveCardsAndDeleteList", mock.AnythingOfType("*models.List"), mock.AnythingOfType("primitive.ObjectID"), mock.Anything, mock.AnythingOfType("*models.Activity")).Run(recordActivity).Return(func(deletedList *models.List, targetListID primitive.ObjectID, move func([]*models.Card, []*models.Card) ([]*models.Card, error), _activity *models.Activity) error {
+		updatedCards, err := move(getListCards(deletedList.ID), getListCards(targetListID))
+		s.updatedCards = updatedCards
+		return err
+	})
+	s.listRepo.On("Archive", mock.AnythingOfType("*models.List"), mock.AnythingOfType("[]*models.Card"), mock.AnythingOfType("*models.Activity")).Run(func(args mock.Arguments) {
+		recordActivity(args)
+		s.updatedCards = args[1].([]*models.Card)
+	}).Return(nil)
+	s.storage.On("DeleteFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image")).Run(func(args mock.Arguments) {
+		arg1 := args[0].(chan<- error)
+		arg1 <- nil
+		arg2 := args[1].(*sync.WaitGroup)
+		arg2.Done()
+	})
+	s.fileStorage.On("DeleteFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image")).Run(func(args mock.Arguments) {
+		arg1 := args[0].(chan<- error)
+		arg1 <- nil
+		arg2 := args[1].(*sync.WaitGroup)
+		arg2.Done()
+	})
+
+	s.events = []*models.BoardEvent{}
+	s.eventRepo.On("Publish", mock.AnythingOfType("*models.BoardEvent")).Run(func(args mock.Arguments) {
+		s.events = append(s.events, args[0].(*models.BoardEvent))
+	}).Return()
+
+	s.usecase = usecase.NewListUsecase(s.listRepo, s.boardRepo, s.boardMemberRepo, s.cardRepo, s.commentRepo, s.attachmentRepo, s.eventRepo, s.storage, s.fileStorage)
 }
 
 func (s *listUsecaseSuite) TestCreateListEmptyTitle() {
@@ -141,6 +271,16 @@ func (s *listUsecaseSuite) TestCreateListSuccessful() {
 	err := s.usecase.Create(boardMember1.UserID, board1.ID, "todo 1")
 
 	assert.NoError(s.T(), err)
+	s.listRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+	assert.Equal(s.T(), []*models.List{s.createdList}, s.updatedLists)
+	assert.Greater(s.T(), s.createdList.Rank, list3.Rank)
+	assert.Len(s.T(), s.events, 1)
+	assert.Equal(s.T(), models.BoardEventListCreated, s.events[0].Type)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbCreated, s.activities[0].Verb)
+	assert.Equal(s.T(), models.ActivityTargetList, s.activities[0].Target)
+	assert.Equal(s.T(), s.createdList, s.activities[0].After)
 }
 
 func (s *listUsecaseSuite) TestUpdateTitleEmptyTitle() {
@@ -187,7 +327,7 @@ func (s *listUsecaseSuite) TestUpdatePositionTooLow() {
 
 	assert.Error(s.T(), err)
 	assert.Equal(s.T(), custom_errors.ErrListPositionTooLow.Error(), err.Error())
-	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+	assert.Len(s.T(), s.updatedLists, 0)
 }
 
 func (s *listUsecaseSuite) TestUpdatePositionTooHigh() {
@@ -195,7 +335,7 @@ func (s *listUsecaseSuite) TestUpdatePositionTooHigh() {
 
 	assert.Error(s.T(), err)
 	assert.Equal(s.T(), custom_errors.ErrListPositionTooHigh.Error(), err.Error())
-	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+	assert.Len(s.T(), s.updatedLists, 0)
 }
 
 func (s *listUsecaseSuite) TestUpdatePositionBoardNotFound() {
@@ -203,7 +343,7 @@ func (s *listUsecaseSuite) TestUpdatePositionBoardNotFound() {
 
 	assert.Error(s.T(), err)
 	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
-	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+	assert.Len(s.T(), s.updatedLists, 0)
 }
 
 func (s *listUsecaseSuite) TestUpdatePositionNotAuthorized() {
@@ -211,7 +351,7 @@ func (s *listUsecaseSuite) TestUpdatePositionNotAuthorized() {
 
 	assert.Error(s.T(), err)
 	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
-	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+	assert.Len(s.T(), s.updatedLists, 0)
 }
 
 func (s *listUsecaseSuite) TestUpdateListNotBelongToBoard() {
@@ -219,25 +359,192 @@ func (s *listUsecaseSuite) TestUpdateListNotBelongToBoard() {
 
 	assert.Error(s.T(), err)
 	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
-	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+	assert.Len(s.T(), s.updatedLists, 0)
 }
 
 func (s *listUsecaseSuite) TestUpdatePositionUpward() {
 	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, 1)
 
 	assert.NoError(s.T(), err)
-	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 2)
-	assert.Equal(s.T(), 1, list1.Position)
-	assert.Equal(s.T(), 0, list2.Position)
-	assert.Equal(s.T(), 2, list3.Position)
+	assert.Len(s.T(), s.updatedLists, 1)
+	assert.Greater(s.T(), list1.Rank, list2.Rank)
+	assert.Less(s.T(), list1.Rank, list3.Rank)
+	assert.Equal(s.T(), "i", list2.Rank)
+	assert.Equal(s.T(), "r", list3.Rank)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbMoved, s.activities[0].Verb)
+	assert.Equal(s.T(), list1.ID, s.activities[0].TargetID)
+	assert.Equal(s.T(), map[string]interface{}{"position": 0}, s.activities[0].Before)
+	assert.Equal(s.T(), map[string]interface{}{"position": 1}, s.activities[0].After)
 }
 
 func (s *listUsecaseSuite) TestUpdatePositionDownward() {
 	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list3.ID, 1)
 
 	assert.NoError(s.T(), err)
-	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 2)
-	assert.Equal(s.T(), 0, list1.Position)
-	assert.Equal(s.T(), 1, list3.Position)
-	assert.Equal(s.T(), 2, list2.Position)
+	assert.Len(s.T(), s.updatedLists, 1)
+	assert.Greater(s.T(), list3.Rank, list1.Rank)
+	assert.Less(s.T(), list3.Rank, list2.Rank)
+}
+
+func (s *listUsecaseSuite) TestArchiveNotAuthorized() {
+	err := s.usecase.Archive(primitive.NewObjectID(), board1.ID, list1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+}
+
+func (s *listUsecaseSuite) TestArchiveAlreadyArchived() {
+	list1.Archived = true
+
+	err := s.usecase.Archive(boardMember1.UserID, board1.ID, list1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrListArchived.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+}
+
+func (s *listUsecaseSuite) TestArchiveSuccessful() {
+	err := s.usecase.Archive(boardMember1.UserID, board1.ID, list1.ID)
+
+	assert.NoError(s.T(), err)
+	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 1)
+	assert.True(s.T(), list1.Archived)
+	assert.Equal(s.T(), list1.UpdatedAt, *list1.ArchivedAt)
+	assert.Equal(s.T(), "a", list1.Rank)
+}
+
+func (s *listUsecaseSuite) TestUnarchiveNotArchived() {
+	err := s.usecase.Unarchive(boardMember1.UserID, board1.ID, list1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrListNotArchived.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+}
+
+func (s *listUsecaseSuite) TestUnarchiveSuccessful() {
+	list2.Archived = true
+
+	err := s.usecase.Unarchive(boardMember1.UserID, board1.ID, list2.ID)
+
+	assert.NoError(s.T(), err)
+	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 1)
+	assert.False(s.T(), list2.Archived)
+	assert.Nil(s.T(), list2.ArchivedAt)
+	assert.Equal(s.T(), "i", list2.Rank)
+}
+
+func (s *listUsecaseSuite) TestUpdatePositionArchivedList() {
+	list1.Archived = true
+
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list1.ID, 1)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrListArchived.Error(), err.Error())
+	assert.Len(s.T(), s.updatedLists, 0)
+}
+
+func (s *listUsecaseSuite) TestDeleteInvalidCardAction() {
+	err := s.usecase.Delete(boardMember1.UserID, board1.ID, list2.ID, "keep", primitive.NilObjectID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrListInvalidCardAction.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *listUsecaseSuite) TestDeleteNotAuthorized() {
+	err := s.usecase.Delete(primitive.NewObjectID(), board1.ID, list2.ID, list.CardActionDelete, primitive.NilObjectID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *listUsecaseSuite) TestDeleteArchiveCards() {
+	err := s.usecase.Delete(boardMember1.UserID, board1.ID, list2.ID, list.CardActionArchive, primitive.NilObjectID)
+
+	assert.NoError(s.T(), err)
+	// the list is archived together with its cards so they can still be restored
+	s.listRepo.AssertNumberOfCalls(s.T(), "Archive", 1)
+	s.listRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "UpdateCard", 0)
+	s.listRepo.AssertNumberOfCalls(s.T(), "UpdateList", 0)
+	assert.Len(s.T(), s.updatedCards, 2)
+	assert.True(s.T(), list2.Archived)
+	assert.NotNil(s.T(), list2.ArchivedAt)
+	assert.True(s.T(), card1.Archived)
+	assert.NotNil(s.T(), card1.ArchivedAt)
+	assert.True(s.T(), card2.Archived)
+	assert.NotNil(s.T(), card2.ArchivedAt)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbArchived, s.activities[0].Verb)
+	assert.Equal(s.T(), map[string]interface{}{"card_action": list.CardActionArchive}, s.activities[0].After)
+	assert.Len(s.T(), s.events, 3)
+	assert.Equal(s.T(), models.BoardEventListArchived, s.events[0].Type)
+}
+
+func (s *listUsecaseSuite) TestDeleteDeleteCards() {
+	err := s.usecase.Delete(boardMember1.UserID, board1.ID, list2.ID, list.CardActionDelete, primitive.NilObjectID)
+
+	assert.NoError(s.T(), err)
+	s.listRepo.AssertNumberOfCalls(s.T(), "Delete", 1)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "MoveCardsAndDeleteList", 0)
+	assert.Len(s.T(), s.deletedCards, 2)
+	assert.Len(s.T(), s.deletedComments, 2)
+	assert.Len(s.T(), s.deletedAttachments, 2)
+	// the cover images go to imgur while the files of the attachments are documents
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 2)
+	s.fileStorage.AssertNumberOfCalls(s.T(), "DeleteFile", 2)
+}
+
+func (s *listUsecaseSuite) TestDeleteMoveCardsToSameList() {
+	err := s.usecase.Delete(boardMember1.UserID, board1.ID, list2.ID, list.CardActionMove, list2.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrListInvalidTargetList.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "MoveCardsAndDeleteList", 0)
+}
+
+func (s *listUsecaseSuite) TestDeleteMoveCardsToOtherBoardList() {
+	err := s.usecase.Delete(boardMember1.UserID, board1.ID, list2.ID, list.CardActionMove, list4.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.listRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "MoveCardsAndDeleteList", 0)
+}
+
+func (s *listUsecaseSuite) TestDeleteMoveCards() {
+	err := s.usecase.Delete(boardMember1.UserID, board1.ID, list2.ID, list.CardActionMove, list3.ID)
+
+	assert.NoError(s.T(), err)
+	s.listRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+	s.cardRepo.AssertNumberOfCalls(s.T(), "MoveCardsAndDeleteList", 1)
+	assert.Len(s.T(), s.updatedCards, 2)
+	assert.Equal(s.T(), list3.ID, card1.ListID)
+	assert.Equal(s.T(), list3.ID, card2.ListID)
+	assert.Greater(s.T(), card1.Rank, card3.Rank)
+	assert.Greater(s.T(), card2.Rank, card1.Rank)
+	assert.Equal(s.T(), "i", card3.Rank)
+	s.storage.AssertNumberOfCalls(s.T(), "DeleteFile", 0)
+	assert.Len(s.T(), s.activities, 1)
+	assert.Equal(s.T(), models.ActivityVerbDeleted, s.activities[0].Verb)
+	assert.Equal(s.T(), map[string]interface{}{"card_action": list.CardActionMove, "target_list_id": list3.ID}, s.activities[0].After)
+}
+
+func (s *listUsecaseSuite) TestUpdatePositionRebalancesRanks() {
+	list1.Rank = "a" + strings.Repeat("0", rank.MaxLength-2) + "1"
+	list2.Rank = "a" + strings.Repeat("0", rank.MaxLength-2) + "2"
+
+	err := s.usecase.UpdatePosition(boardMember1.UserID, board1.ID, list3.ID, 1)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.updatedLists, 3)
+	assert.Less(s.T(), list1.Rank, list3.Rank)
+	assert.Less(s.T(), list3.Rank, list2.Rank)
+	assert.False(s.T(), rank.NeedsRebalance(list1.Rank))
+	assert.False(s.T(), rank.NeedsRebalance(list2.Rank))
+	assert.False(s.T(), rank.NeedsRebalance(list3.Rank))
 }
@@ -0,0 +1,6 @@
+package mail
+
+// Sender delivers plain text emails to users
+type Sender interface {
+	Send(to, subject, body string) error
+}
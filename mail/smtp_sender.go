@@ -0,0 +1,40 @@
+package mail
+
+import (
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+type smtpSender struct {
+	host     string
+	port     string
+	username string
+	password string
+	from     string
+}
+
+// NewSMTPSender sends emails through the SMTP server at host and port, the username and password
+// can be left empty for servers that do not require authentication such as a local SMTP stub
+func NewSMTPSender(host, port, username, password, from string) Sender {
+	return &smtpSender{host: host, port: port, username: username, password: password, from: from}
+}
+
+func (sender *smtpSender) Send(to, subject, body string) error {
+	var auth smtp.Auth
+	if sender.username != "" {
+		auth = smtp.PlainAuth("", sender.username, sender.password, sender.host)
+	}
+
+	headers := []string{
+		fmt.Sprintf("From: %s", sender.from),
+		fmt.Sprintf("To: %s", to),
+		fmt.Sprintf("Subject: %s", subject),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	}
+	message := strings.Join(headers, "\r\n") + "\r\n\r\n" + body
+
+	return smtp.SendMail(net.JoinHostPort(sender.host, sender.port), auth, sender.from, []string{to}, []byte(message))
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"net/http"
@@ -88,6 +89,20 @@ func init() {
 }
 
 func main() {
+	migrateRanks := flag.Bool("migrate-ranks", false, "give stored lists and cards a rank based on their position and exit")
+	migrateEmails := flag.Bool("verify-existing-emails", false, "mark the email addresses of users created before email verification as verified and exit")
+	flag.Parse()
+
+	if *migrateRanks {
+		migratePositionsToRanks()
+		return
+	}
+
+	if *migrateEmails {
+		verifyExistingEmails()
+		return
+	}
+
 	router = gin.Default()
 
 	trustedProxies := strings.Split(os.Getenv("TRUSTED_PROXIES"), ",")
@@ -24,8 +24,8 @@ func NewAuthMiddleware(usecase token.Usecase) *AuthMiddleware {
 
 func (middleware *AuthMiddleware) AuthenticateJWT(c *gin.Context) {
 	noAuth := map[string][]string{
-		"POST":   {"/login", "/login/google", "/login/github", "/tokens/refresh", "/register"},
-		"GET":    {"/_health"},
+		"POST":   {"/login", "/login/google", "/login/oidc/:provider", "/login/github", "/tokens/refresh", "/register", "/password/forgot", "/password/reset", "/email/verify"},
+		"GET":    {"/_health", "/files/*filepath"},
 		"DELETE": {"/tokens/remove"},
 	}
 
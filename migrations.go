@@ -0,0 +1,36 @@
+package main
+
+import (
+	"log"
+
+	cr "github.com/jordyf15/thullo-api/card/repository"
+	lr "github.com/jordyf15/thullo-api/list/repository"
+	ur "github.com/jordyf15/thullo-api/user/repository"
+)
+
+// migratePositionsToRanks gives the lists and cards that were stored with
+// only an integer position a rank, it can be run again safely
+func migratePositionsToRanks() {
+	err := lr.NewListRepository(rtdbClient).MigratePositionsToRanks()
+	if err != nil {
+		log.Fatalln("Error migrating list positions to ranks: ", err)
+	}
+
+	err = cr.NewCardRepository(rtdbClient).MigratePositionsToRanks()
+	if err != nil {
+		log.Fatalln("Error migrating card positions to ranks: ", err)
+	}
+
+	log.Println("Migrated list and card positions to ranks")
+}
+
+// verifyExistingEmails marks the users that signed up before email addresses had to be
+// verified as verified so they can still be added to boards, it can be run again safely
+func verifyExistingEmails() {
+	count, err := ur.NewUserRepository(dbClient).VerifyExistingEmails()
+	if err != nil {
+		log.Fatalln("Error verifying existing email addresses: ", err)
+	}
+
+	log.Printf("Verified the email addresses of %d existing users", count)
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	ActivityVerbCreated    = "created"
+	ActivityVerbUpdated    = "updated"
+	ActivityVerbMoved      = "moved"
+	ActivityVerbArchived   = "archived"
+	ActivityVerbUnarchived = "unarchived"
+	ActivityVerbDeleted    = "deleted"
+	ActivityVerbAdded      = "added"
+	ActivityVerbRemoved    = "removed"
+
+	ActivityTargetBoard      = "board"
+	ActivityTargetMember     = "member"
+	ActivityTargetList       = "list"
+	ActivityTargetCard       = "card"
+	ActivityTargetComment    = "comment"
+	ActivityTargetAttachment = "attachment"
+	ActivityTargetChecklist  = "checklist"
+	ActivityTargetLabel      = "label"
+)
+
+// Activity records a change made to a board, it is written together with the change itself
+type Activity struct {
+	ID      primitive.ObjectID `json:"id"`
+	BoardID primitive.ObjectID `json:"board_id"`
+	// CardID is set for the changes of a card, its comments, attachments and checklists so they make up the history of the card
+	CardID   *primitive.ObjectID `json:"card_id,omitempty"`
+	ActorID  primitive.ObjectID  `json:"actor_id"`
+	Verb     string              `json:"verb"`
+	Target   string              `json:"target"`
+	TargetID primitive.ObjectID  `json:"target_id"`
+	// Before and After hold the changed values, or the whole target when it is created or deleted
+	Before    interface{} `json:"before,omitempty"`
+	After     interface{} `json:"after,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
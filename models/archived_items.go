@@ -0,0 +1,6 @@
+package models
+
+type ArchivedItems struct {
+	Lists []*List `json:"lists"`
+	Cards []*Card `json:"cards"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AttachmentCoverSource is the source of a card cover that uses the thumbnail of
+// one of the card's attachments, its images belong to the attachment
+const AttachmentCoverSource = "attachment"
+
+type Attachment struct {
+	ID         primitive.ObjectID `json:"id"`
+	CardID     primitive.ObjectID `json:"card_id"`
+	UploaderID primitive.ObjectID `json:"uploader_id"`
+	Name       string             `json:"name"`
+	Size       int64              `json:"size"`
+	MimeType   string             `json:"mime_type"`
+	File       *Image             `json:"file"`
+	Thumbnail  *Image             `json:"thumbnail,omitempty"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
+
+// Images returns the uploaded images of the attachment
+func (attachment *Attachment) Images() Images {
+	images := Images{attachment.File}
+	if attachment.Thumbnail != nil {
+		images = append(images, attachment.Thumbnail)
+	}
+
+	return images
+}
@@ -21,6 +21,11 @@ type Board struct {
 	Visibility  BoardVisibility    `json:"visibility"`
 	OwnerID     primitive.ObjectID `json:"owner_id"`
 	Cover       *BoardCover        `json:"cover"`
+	Lists       []*List            `json:"lists,omitempty"`
+	Labels      []*Label           `json:"labels,omitempty"`
+	Members     []*BoardMember     `json:"members,omitempty"`
+	Archived    bool               `json:"archived"`
+	ArchivedAt  *time.Time         `json:"archived_at"`
 	CreatedAt   time.Time          `json:"created_at"`
 	UpdatedAt   time.Time          `json:"updated_at"`
 }
@@ -41,6 +46,18 @@ func (board *Board) SetVisibility(visibility string) {
 	}
 }
 
+// Thumbnail returns the smallest image of the cover
+func (cover *BoardCover) Thumbnail() *Image {
+	var thumbnail *Image
+	for _, img := range cover.Images {
+		if thumbnail == nil || img.Width < thumbnail.Width {
+			thumbnail = img
+		}
+	}
+
+	return thumbnail
+}
+
 func (board *Board) EmptyImageURLs() {
 	for _, img := range board.Cover.Images {
 		img.URL = ""
@@ -51,12 +68,18 @@ func (board *Board) MarshalJSON() ([]byte, error) {
 	type Alias Board
 	newStruct := &struct {
 		*Alias
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		ArchivedAt *string `json:"archived_at"`
+		CreatedAt  string  `json:"created_at"`
+		UpdatedAt  string  `json:"updated_at"`
 	}{
 		Alias: (*Alias)(board),
 	}
 
+	if board.ArchivedAt != nil {
+		archivedAt := board.ArchivedAt.Format("2006-01-02T15:04:05-0700")
+		newStruct.ArchivedAt = &archivedAt
+	}
+
 	newStruct.CreatedAt = board.CreatedAt.Format("2006-01-02T15:04:05-0700")
 	newStruct.UpdatedAt = board.UpdatedAt.Format("2006-01-02T15:04:05-0700")
 
@@ -67,8 +90,9 @@ func (board *Board) UnmarshalJSON(data []byte) error {
 	type Alias Board
 	alias := &struct {
 		*Alias
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		ArchivedAt *string `json:"archived_at"`
+		CreatedAt  string  `json:"created_at"`
+		UpdatedAt  string  `json:"updated_at"`
 	}{Alias: (*Alias)(board)}
 
 	err := json.Unmarshal(data, &alias)
@@ -76,6 +100,16 @@ func (board *Board) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	board.ArchivedAt = nil
+	if alias.ArchivedAt != nil {
+		archivedAt, err := time.Parse("2006-01-02T15:04:05-0700", *alias.ArchivedAt)
+		if err != nil {
+			return err
+		}
+
+		board.ArchivedAt = &archivedAt
+	}
+
 	board.CreatedAt, err = time.Parse("2006-01-02T15:04:05-0700", alias.CreatedAt)
 	if err != nil {
 		return err
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	BoardEventBoardUpdated      = "board.updated"
+	BoardEventBoardArchived     = "board.archived"
+	BoardEventBoardUnarchived   = "board.unarchived"
+	BoardEventBoardDeleted      = "board.deleted"
+	BoardEventMemberAdded       = "member.added"
+	BoardEventMemberUpdated     = "member.updated"
+	BoardEventMemberRemoved     = "member.removed"
+	BoardEventListCreated       = "list.created"
+	BoardEventListUpdated       = "list.updated"
+	BoardEventListMoved         = "list.moved"
+	BoardEventListArchived      = "list.archived"
+	BoardEventListUnarchived    = "list.unarchived"
+	BoardEventListDeleted       = "list.deleted"
+	BoardEventCardCreated       = "card.created"
+	BoardEventCardUpdated       = "card.updated"
+	BoardEventCardMoved         = "card.moved"
+	BoardEventCardArchived      = "card.archived"
+	BoardEventCardUnarchived    = "card.unarchived"
+	BoardEventCommentCreated    = "comment.created"
+	BoardEventCommentUpdated    = "comment.updated"
+	BoardEventCommentDeleted    = "comment.deleted"
+	BoardEventAttachmentCreated = "attachment.created"
+	BoardEventAttachmentDeleted = "attachment.deleted"
+	BoardEventLabelCreated      = "label.created"
+	BoardEventLabelUpdated      = "label.updated"
+	BoardEventLabelDeleted      = "label.deleted"
+	BoardEventChecklistCreated  = "checklist.created"
+	BoardEventChecklistUpdated  = "checklist.updated"
+	BoardEventChecklistDeleted  = "checklist.deleted"
+)
+
+type BoardEvent struct {
+	Type    string             `json:"type"`
+	BoardID primitive.ObjectID `json:"board_id"`
+	ActorID primitive.ObjectID `json:"actor_id"`
+	// Data is the resource after the change, or the resource as it was before it was deleted
+	Data      interface{} `json:"data"`
+	CreatedAt time.Time   `json:"created_at"`
+}
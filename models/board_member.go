@@ -14,4 +14,5 @@ type BoardMember struct {
 	UserID  primitive.ObjectID `json:"user_id"`
 	BoardID primitive.ObjectID `json:"board_id"`
 	Role    MemberRole         `json:"role"`
+	User    *UserProfile       `json:"user,omitempty"`
 }
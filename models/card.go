@@ -8,27 +8,136 @@ import (
 )
 
 type Card struct {
-	ID          primitive.ObjectID `json:"id"`
-	Title       string             `json:"title"`
-	Description string             `json:"description"`
-	ListID      primitive.ObjectID `json:"list_id"`
-	Cover       *BoardCover        `json:"cover"`
-	Position    int                `json:"position"`
-	// Attachments?
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID          primitive.ObjectID   `json:"id"`
+	Title       string               `json:"title"`
+	Description string               `json:"description"`
+	ListID      primitive.ObjectID   `json:"list_id"`
+	Cover       *BoardCover          `json:"cover"`
+	LabelIDs    []primitive.ObjectID `json:"label_ids"`
+	AssigneeIDs []primitive.ObjectID `json:"assignee_ids"`
+	WatcherIDs  []primitive.ObjectID `json:"watcher_ids"`
+	StartDate   *time.Time           `json:"start_date"`
+	DueDate     *time.Time           `json:"due_date"`
+	Done        bool                 `json:"done"`
+	// ChecklistProgress is kept up to date by the checklists of the card, it is nil when they have no items
+	ChecklistProgress *ChecklistProgress `json:"checklist_progress"`
+	// Position is the index among the active items and is derived from Rank when
+	// the board is read, stored values only matter for the rank migration
+	Position   int        `json:"position"`
+	Rank       string     `json:"rank"`
+	Archived   bool       `json:"archived"`
+	ArchivedAt *time.Time `json:"archived_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsOverdue reports whether the card is not done yet while its due date has passed
+func (card *Card) IsOverdue(now time.Time) bool {
+	return !card.Done && card.DueDate != nil && card.DueDate.Before(now)
+}
+
+// IsDueSoon reports whether the card is not done yet and is due within the window
+func (card *Card) IsDueSoon(now time.Time, window time.Duration) bool {
+	return !card.Done && card.DueDate != nil && !card.DueDate.Before(now) && card.DueDate.Before(now.Add(window))
+}
+
+// HasLabel reports whether the label is attached to the card
+func (card *Card) HasLabel(labelID primitive.ObjectID) bool {
+	for _, cardLabelID := range card.LabelIDs {
+		if cardLabelID == labelID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RemoveLabel detaches the label from the card and reports whether it was attached
+func (card *Card) RemoveLabel(labelID primitive.ObjectID) bool {
+	for i, cardLabelID := range card.LabelIDs {
+		if cardLabelID == labelID {
+			card.LabelIDs = append(card.LabelIDs[:i], card.LabelIDs[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAssigned reports whether the user is assigned to the card
+func (card *Card) IsAssigned(userID primitive.ObjectID) bool {
+	for _, assigneeID := range card.AssigneeIDs {
+		if assigneeID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unassign removes the user from the assignees of the card and reports whether the user was assigned
+func (card *Card) Unassign(userID primitive.ObjectID) bool {
+	for i, assigneeID := range card.AssigneeIDs {
+		if assigneeID == userID {
+			card.AssigneeIDs = append(card.AssigneeIDs[:i], card.AssigneeIDs[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsWatched reports whether the user is watching the card
+func (card *Card) IsWatched(userID primitive.ObjectID) bool {
+	for _, watcherID := range card.WatcherIDs {
+		if watcherID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Unwatch removes the user from the watchers of the card and reports whether the user was watching it
+func (card *Card) Unwatch(userID primitive.ObjectID) bool {
+	for i, watcherID := range card.WatcherIDs {
+		if watcherID == userID {
+			card.WatcherIDs = append(card.WatcherIDs[:i], card.WatcherIDs[i+1:]...)
+			return true
+		}
+	}
+
+	return false
 }
 
 func (card *Card) MarshalJSON() ([]byte, error) {
 	type Alias Card
 	newStruct := &struct {
 		*Alias
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		StartDate  *string `json:"start_date"`
+		DueDate    *string `json:"due_date"`
+		ArchivedAt *string `json:"archived_at"`
+		CreatedAt  string  `json:"created_at"`
+		UpdatedAt  string  `json:"updated_at"`
 	}{
 		Alias: (*Alias)(card),
 	}
 
+	if card.StartDate != nil {
+		startDate := card.StartDate.Format("2006-01-02T15:04:05-0700")
+		newStruct.StartDate = &startDate
+	}
+
+	if card.DueDate != nil {
+		dueDate := card.DueDate.Format("2006-01-02T15:04:05-0700")
+		newStruct.DueDate = &dueDate
+	}
+
+	if card.ArchivedAt != nil {
+		archivedAt := card.ArchivedAt.Format("2006-01-02T15:04:05-0700")
+		newStruct.ArchivedAt = &archivedAt
+	}
+
 	newStruct.CreatedAt = card.CreatedAt.Format("2006-01-02T15:04:05-0700")
 	newStruct.UpdatedAt = card.UpdatedAt.Format("2006-01-02T15:04:05-0700")
 
@@ -39,8 +148,11 @@ func (card *Card) UnmarshalJSON(data []byte) error {
 	type Alias Card
 	alias := &struct {
 		*Alias
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		StartDate  *string `json:"start_date"`
+		DueDate    *string `json:"due_date"`
+		ArchivedAt *string `json:"archived_at"`
+		CreatedAt  string  `json:"created_at"`
+		UpdatedAt  string  `json:"updated_at"`
 	}{Alias: (*Alias)(card)}
 
 	err := json.Unmarshal(data, &alias)
@@ -48,6 +160,36 @@ func (card *Card) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	card.StartDate = nil
+	if alias.StartDate != nil {
+		startDate, err := time.Parse("2006-01-02T15:04:05-0700", *alias.StartDate)
+		if err != nil {
+			return err
+		}
+
+		card.StartDate = &startDate
+	}
+
+	card.DueDate = nil
+	if alias.DueDate != nil {
+		dueDate, err := time.Parse("2006-01-02T15:04:05-0700", *alias.DueDate)
+		if err != nil {
+			return err
+		}
+
+		card.DueDate = &dueDate
+	}
+
+	card.ArchivedAt = nil
+	if alias.ArchivedAt != nil {
+		archivedAt, err := time.Parse("2006-01-02T15:04:05-0700", *alias.ArchivedAt)
+		if err != nil {
+			return err
+		}
+
+		card.ArchivedAt = &archivedAt
+	}
+
 	card.CreatedAt, err = time.Parse("2006-01-02T15:04:05-0700", alias.CreatedAt)
 	if err != nil {
 		return err
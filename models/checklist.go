@@ -0,0 +1,60 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Checklist struct {
+	ID        primitive.ObjectID `json:"id"`
+	CardID    primitive.ObjectID `json:"card_id"`
+	Title     string             `json:"title"`
+	Items     []*ChecklistItem   `json:"items"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
+
+type ChecklistItem struct {
+	ID    primitive.ObjectID `json:"id"`
+	Title string             `json:"title"`
+	Done  bool               `json:"done"`
+}
+
+// ChecklistProgress is how many of the items of all the checklists of a card are done
+type ChecklistProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ItemIndex returns the index of the item in the checklist or -1 if the checklist does not have it
+func (checklist *Checklist) ItemIndex(itemID primitive.ObjectID) int {
+	for i, item := range checklist.Items {
+		if item.ID == itemID {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// GetChecklistProgress counts the items of the checklists,
+// nil is returned when there are no items at all
+func GetChecklistProgress(checklists []*Checklist) *ChecklistProgress {
+	progress := &ChecklistProgress{}
+
+	for _, checklist := range checklists {
+		for _, item := range checklist.Items {
+			progress.Total++
+			if item.Done {
+				progress.Done++
+			}
+		}
+	}
+
+	if progress.Total == 0 {
+		return nil
+	}
+
+	return progress
+}
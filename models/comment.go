@@ -7,10 +7,21 @@ import (
 )
 
 type Comment struct {
-	ID        primitive.ObjectID `json:"id"`
-	AuthorID  primitive.ObjectID `json:"author_id"`
-	CardID    primitive.ObjectID `json:"card_id"`
-	Comment   string             `json:"comment"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at"`
+	ID       primitive.ObjectID `json:"id"`
+	AuthorID primitive.ObjectID `json:"author_id"`
+	CardID   primitive.ObjectID `json:"card_id"`
+	Comment  string             `json:"comment"`
+	// MentionedUserIDs are the board members that were mentioned when the comment was last written
+	MentionedUserIDs []primitive.ObjectID `json:"mentioned_user_ids"`
+	Author           *CommentAuthor       `json:"author,omitempty"`
+	CreatedAt        time.Time            `json:"created_at"`
+	UpdatedAt        time.Time            `json:"updated_at"`
+}
+
+// CommentAuthor is the public profile of the author that is embedded when the comments of a card are read
+type CommentAuthor struct {
+	ID       primitive.ObjectID `json:"id"`
+	Username string             `json:"username"`
+	Name     string             `json:"name"`
+	Images   Images             `json:"images"`
 }
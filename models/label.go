@@ -0,0 +1,16 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Label struct {
+	ID        primitive.ObjectID `json:"id"`
+	BoardID   primitive.ObjectID `json:"board_id"`
+	Name      string             `json:"name"`
+	Color     string             `json:"color"`
+	CreatedAt time.Time          `json:"created_at"`
+	UpdatedAt time.Time          `json:"updated_at"`
+}
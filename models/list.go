@@ -8,24 +8,36 @@ import (
 )
 
 type List struct {
-	ID        primitive.ObjectID `json:"id"`
-	Title     string             `json:"title"`
-	BoardID   primitive.ObjectID `json:"board_id"`
-	Position  int                `json:"position"`
-	CreatedAt time.Time          `json:"created_at"`
-	UpdatedAt time.Time          `json:"updated_at"`
+	ID      primitive.ObjectID `json:"id"`
+	Title   string             `json:"title"`
+	BoardID primitive.ObjectID `json:"board_id"`
+	// Position is the index among the active items and is derived from Rank when
+	// the board is read, stored values only matter for the rank migration
+	Position   int        `json:"position"`
+	Rank       string     `json:"rank"`
+	Cards      []*Card    `json:"cards,omitempty"`
+	Archived   bool       `json:"archived"`
+	ArchivedAt *time.Time `json:"archived_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
 }
 
 func (list *List) MarshalJSON() ([]byte, error) {
 	type Alias List
 	newStruct := &struct {
 		*Alias
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		ArchivedAt *string `json:"archived_at"`
+		CreatedAt  string  `json:"created_at"`
+		UpdatedAt  string  `json:"updated_at"`
 	}{
 		Alias: (*Alias)(list),
 	}
 
+	if list.ArchivedAt != nil {
+		archivedAt := list.ArchivedAt.Format("2006-01-02T15:04:05-0700")
+		newStruct.ArchivedAt = &archivedAt
+	}
+
 	newStruct.CreatedAt = list.CreatedAt.Format("2006-01-02T15:04:05-0700")
 	newStruct.UpdatedAt = list.UpdatedAt.Format("2006-01-02T15:04:05-0700")
 
@@ -36,8 +48,9 @@ func (list *List) UnmarshalJSON(data []byte) error {
 	type Alias List
 	alias := &struct {
 		*Alias
-		CreatedAt string `json:"created_at"`
-		UpdatedAt string `json:"updated_at"`
+		ArchivedAt *string `json:"archived_at"`
+		CreatedAt  string  `json:"created_at"`
+		UpdatedAt  string  `json:"updated_at"`
 	}{Alias: (*Alias)(list)}
 
 	err := json.Unmarshal(data, &alias)
@@ -45,6 +58,16 @@ func (list *List) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	list.ArchivedAt = nil
+	if alias.ArchivedAt != nil {
+		archivedAt, err := time.Parse("2006-01-02T15:04:05-0700", *alias.ArchivedAt)
+		if err != nil {
+			return err
+		}
+
+		list.ArchivedAt = &archivedAt
+	}
+
 	list.CreatedAt, err = time.Parse("2006-01-02T15:04:05-0700", alias.CreatedAt)
 	if err != nil {
 		return err
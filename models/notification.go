@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	NotificationTypeCommentMention     = "comment_mention"
+	NotificationTypeCardMention        = "card_mention"
+	NotificationTypeCardComment        = "card_comment"
+	NotificationTypeBoardMemberAdded   = "board_member_added"
+	NotificationTypeBoardRoleUpdated   = "board_role_updated"
+	NotificationTypeBoardMemberRemoved = "board_member_removed"
+)
+
+type Notification struct {
+	ID        primitive.ObjectID  `json:"id"`
+	UserID    primitive.ObjectID  `json:"user_id"`
+	ActorID   primitive.ObjectID  `json:"actor_id"`
+	Type      string              `json:"type"`
+	BoardID   primitive.ObjectID  `json:"board_id"`
+	ListID    *primitive.ObjectID `json:"list_id,omitempty"`
+	CardID    *primitive.ObjectID `json:"card_id,omitempty"`
+	CommentID *primitive.ObjectID `json:"comment_id,omitempty"`
+	// Role is the new role of the user for role updates
+	Role      MemberRole `json:"role,omitempty"`
+	Read      bool       `json:"read"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -2,15 +2,33 @@ package models
 
 import "github.com/golang-jwt/jwt/v4"
 
-type GoogleTokenInfo struct {
-	jwt.StandardClaims
-	Email         string `json:"email"`
-	EmailVerified bool   `json:"email_verified"`
-	AtHash        string `json:"at_hash"`
-	Nonce         string `json:"nonce"`
-	Name          string `json:"name"`
-	Picture       string `json:"picture"`
-	GivenName     string `json:"given_name"`
-	FamilyName    string `json:"family_name"`
-	Locale        string `json:"locale"`
+// IDTokenClaims are the claims of an OpenID Connect id token
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Nonce             string `json:"nonce"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+	Picture           string `json:"picture"`
+	GivenName         string `json:"given_name"`
+	FamilyName        string `json:"family_name"`
+	Locale            string `json:"locale"`
+}
+
+// GithubUserInfo is the github account of the user that is logging in with github
+type GithubUserInfo struct {
+	ID        int64  `json:"id"`
+	Login     string `json:"login"`
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+	// Email is the primary email address of the account, it is only filled when github has verified it
+	Email string `json:"-"`
+}
+
+// GithubEmail is one of the email addresses of a github account
+type GithubEmail struct {
+	Email    string `json:"email"`
+	Primary  bool   `json:"primary"`
+	Verified bool   `json:"verified"`
 }
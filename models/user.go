@@ -18,6 +18,7 @@ const (
 	maxNameLength     = 30
 	minPasswordLength = 8
 	maxPasswordLength = 30
+	maxBioLength      = 200
 )
 
 var (
@@ -44,6 +45,7 @@ type regexPair struct {
 type User struct {
 	ID                primitive.ObjectID `bson:"_id" json:"id"`
 	Email             string             `bson:"email" json:"email"`
+	EmailVerified     bool               `bson:"email_verified" json:"email_verified"`
 	EncryptedPassword string             `bson:"encrypted_password" json:"-"`
 	Password          string             `bson:"-" json:"-"`
 	Username          string             `bson:"username" json:"username"`
@@ -89,6 +91,7 @@ func (user *User) SetPassword(newPassword string) error {
 func (user *User) VerifyFields() []error {
 	user.Name = strings.Join(strings.Fields(user.Name), " ")
 	user.Username = strings.ToLower(user.Username)
+	user.Bio = strings.TrimSpace(user.Bio)
 
 	for _, img := range user.Images {
 		img.URL = ""
@@ -122,6 +125,10 @@ func (user *User) VerifyFields() []error {
 		errors = append(errors, custom_errors.ErrNameTooLong)
 	}
 
+	if len(user.Bio) > maxBioLength {
+		errors = append(errors, custom_errors.ErrBioTooLong)
+	}
+
 	if len(errors) > 0 {
 		return errors
 	}
@@ -129,6 +136,18 @@ func (user *User) VerifyFields() []error {
 	return nil
 }
 
+// Profile returns the part of the user that can be seen by other users
+func (user *User) Profile() *UserProfile {
+	return &UserProfile{
+		ID:        user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		Bio:       user.Bio,
+		Images:    user.Images,
+		CreatedAt: user.CreatedAt,
+	}
+}
+
 func (user *User) EmptyImageIDs() {
 	for _, image := range user.Images {
 		image.ID = ""
@@ -148,3 +167,13 @@ func (user *User) MarshalJSON() ([]byte, error) {
 
 	return json.Marshal(newStruct)
 }
+
+// UserProfile is the public profile of a user, it leaves out the email address
+type UserProfile struct {
+	ID        primitive.ObjectID `json:"id"`
+	Username  string             `json:"username"`
+	Name      string             `json:"name"`
+	Bio       string             `json:"bio"`
+	Images    Images             `json:"images"`
+	CreatedAt time.Time          `json:"created_at"`
+}
@@ -1,8 +1,36 @@
 package models
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"encoding/json"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type UserBoards struct {
 	UserID   primitive.ObjectID   `json:"user_id"`
 	BoardIDs []primitive.ObjectID `json:"board_ids"`
 }
+
+type UserBoard struct {
+	BoardID    primitive.ObjectID `json:"board_id"`
+	Title      string             `json:"title"`
+	Visibility BoardVisibility    `json:"visibility"`
+	Role       MemberRole         `json:"role"`
+	Thumbnail  *Image             `json:"thumbnail"`
+	UpdatedAt  time.Time          `json:"updated_at"`
+}
+
+func (userBoard *UserBoard) MarshalJSON() ([]byte, error) {
+	type Alias UserBoard
+	newStruct := &struct {
+		*Alias
+		UpdatedAt string `json:"updated_at"`
+	}{
+		Alias: (*Alias)(userBoard),
+	}
+
+	newStruct.UpdatedAt = userBoard.UpdatedAt.Format("2006-01-02T15:04:05-0700")
+
+	return json.Marshal(newStruct)
+}
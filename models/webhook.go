@@ -0,0 +1,61 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Webhook sends the events of a board to the URL, an empty EventTypes subscribes it to every event
+// that webhooks support. Webhooks are disabled by the dispatcher after too many failed deliveries.
+type Webhook struct {
+	ID                  primitive.ObjectID `json:"id"`
+	BoardID             primitive.ObjectID `json:"board_id"`
+	URL                 string             `json:"url"`
+	Secret              string             `json:"secret"`
+	EventTypes          []string           `json:"event_types,omitempty"`
+	Active              bool               `json:"active"`
+	ConsecutiveFailures int                `json:"consecutive_failures"`
+	// DisabledAt is set when the webhook was disabled because its deliveries kept failing
+	DisabledAt *time.Time `json:"disabled_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+// IsSubscribedTo reports whether events of the type are sent to the webhook
+func (webhook *Webhook) IsSubscribedTo(eventType string) bool {
+	if len(webhook.EventTypes) == 0 {
+		return true
+	}
+
+	for _, subscribedEventType := range webhook.EventTypes {
+		if subscribedEventType == eventType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WebhookDelivery is an event that is queued to be sent to a webhook,
+// it keeps its ID across retries so receivers can tell them apart from new events
+type WebhookDelivery struct {
+	ID        primitive.ObjectID `json:"id"`
+	WebhookID primitive.ObjectID `json:"webhook_id"`
+	Event     *BoardEvent        `json:"event"`
+	// Attempt is the number of times the delivery has been tried so far
+	Attempt int `json:"attempt"`
+}
+
+// WebhookDeliveryLog records a single attempt at sending a delivery
+type WebhookDeliveryLog struct {
+	ID         primitive.ObjectID `json:"id"`
+	WebhookID  primitive.ObjectID `json:"webhook_id"`
+	DeliveryID primitive.ObjectID `json:"delivery_id"`
+	EventType  string             `json:"event_type"`
+	Attempt    int                `json:"attempt"`
+	StatusCode int                `json:"status_code,omitempty"`
+	Error      string             `json:"error,omitempty"`
+	Succeeded  bool               `json:"succeeded"`
+	CreatedAt  time.Time          `json:"created_at"`
+}
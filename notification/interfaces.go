@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	DefaultNotificationsLimit = 20
+	MaxNotificationsLimit     = 50
+)
+
+type Repository interface {
+	Create(notifications []*models.Notification) error
+	GetNotificationByID(userID, notificationID primitive.ObjectID) (*models.Notification, error)
+	GetUserNotifications(userID primitive.ObjectID) ([]*models.Notification, error)
+	MarkAsRead(userID primitive.ObjectID, notificationIDs []primitive.ObjectID) error
+}
+
+type Usecase interface {
+	GetNotifications(requesterID primitive.ObjectID, cursor string, limit int, unreadOnly bool) ([]*models.Notification, string, int, error)
+	MarkAsRead(requesterID, notificationID primitive.ObjectID) error
+	MarkAllAsRead(requesterID primitive.ObjectID) error
+}
@@ -0,0 +1,104 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: notifications
+func (_m *Repository) Create(notifications []*models.Notification) error {
+	ret := _m.Called(notifications)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func([]*models.Notification) error); ok {
+		r0 = rf(notifications)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetNotificationByID provides a mock function with given fields: userID, notificationID
+func (_m *Repository) GetNotificationByID(userID primitive.ObjectID, notificationID primitive.ObjectID) (*models.Notification, error) {
+	ret := _m.Called(userID, notificationID)
+
+	var r0 *models.Notification
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) *models.Notification); ok {
+		r0 = rf(userID, notificationID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(userID, notificationID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUserNotifications provides a mock function with given fields: userID
+func (_m *Repository) GetUserNotifications(userID primitive.ObjectID) ([]*models.Notification, error) {
+	ret := _m.Called(userID)
+
+	var r0 []*models.Notification
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.Notification); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Notification)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkAsRead provides a mock function with given fields: userID, notificationIDs
+func (_m *Repository) MarkAsRead(userID primitive.ObjectID, notificationIDs []primitive.ObjectID) error {
+	ret := _m.Called(userID, notificationIDs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, []primitive.ObjectID) error); ok {
+		r0 = rf(userID, notificationIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,95 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// GetNotifications provides a mock function with given fields: requesterID, cursor, limit, unreadOnly
+func (_m *Usecase) GetNotifications(requesterID primitive.ObjectID, cursor string, limit int, unreadOnly bool) ([]*models.Notification, string, int, error) {
+	ret := _m.Called(requesterID, cursor, limit, unreadOnly)
+
+	var r0 []*models.Notification
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, string, int, bool) []*models.Notification); ok {
+		r0 = rf(requesterID, cursor, limit, unreadOnly)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Notification)
+		}
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, string, int, bool) string); ok {
+		r1 = rf(requesterID, cursor, limit, unreadOnly)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 int
+	if rf, ok := ret.Get(2).(func(primitive.ObjectID, string, int, bool) int); ok {
+		r2 = rf(requesterID, cursor, limit, unreadOnly)
+	} else {
+		r2 = ret.Get(2).(int)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(primitive.ObjectID, string, int, bool) error); ok {
+		r3 = rf(requesterID, cursor, limit, unreadOnly)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// MarkAllAsRead provides a mock function with given fields: requesterID
+func (_m *Usecase) MarkAllAsRead(requesterID primitive.ObjectID) error {
+	ret := _m.Called(requesterID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
+		r0 = rf(requesterID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkAsRead provides a mock function with given fields: requesterID, notificationID
+func (_m *Usecase) MarkAsRead(requesterID primitive.ObjectID, notificationID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, notificationID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, notificationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsecase creates a new instance of Usecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsecase(t mockConstructorTestingTNewUsecase) *Usecase {
+	mock := &Usecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/notification"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type notificationRepository struct {
+	dbClient *db.Client
+}
+
+func NewNotificationRepository(dbClient *db.Client) notification.Repository {
+	return &notificationRepository{dbClient: dbClient}
+}
+
+// Create stores the notifications under the users they are for in a single write
+func (repo *notificationRepository) Create(notifications []*models.Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	updates := map[string]interface{}{}
+	now := time.Now()
+
+	for _, notification := range notifications {
+		notification.ID = primitive.NewObjectID()
+		notification.CreatedAt = now
+
+		updates[fmt.Sprintf("notifications/%s/%s", notification.UserID.Hex(), notification.ID.Hex())] = notification
+	}
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *notificationRepository) GetNotificationByID(userID, notificationID primitive.ObjectID) (*models.Notification, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("notifications/%s/%s", userID.Hex(), notificationID.Hex()))
+
+	notification := &models.Notification{}
+
+	err := ref.Get(ctx, &notification)
+	if err != nil {
+		return nil, err
+	}
+
+	if notification == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return notification, nil
+}
+
+func (repo *notificationRepository) GetUserNotifications(userID primitive.ObjectID) ([]*models.Notification, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("notifications/%s", userID.Hex()))
+
+	notificationsMap := make(map[string]*models.Notification)
+
+	err := ref.Get(ctx, &notificationsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	notifications := []*models.Notification{}
+
+	for _, notification := range notificationsMap {
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+// MarkAsRead only writes the read flag of the notifications in a single write
+func (repo *notificationRepository) MarkAsRead(userID primitive.ObjectID, notificationIDs []primitive.ObjectID) error {
+	if len(notificationIDs) == 0 {
+		return nil
+	}
+
+	updates := map[string]interface{}{}
+
+	for _, notificationID := range notificationIDs {
+		updates[fmt.Sprintf("notifications/%s/%s/read", userID.Hex(), notificationID.Hex())] = true
+	}
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"sort"
+
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/notification"
+	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type notificationUsecase struct {
+	notificationRepo notification.Repository
+}
+
+func NewNotificationUsecase(notificationRepo notification.Repository) notification.Usecase {
+	return &notificationUsecase{notificationRepo: notificationRepo}
+}
+
+// GetNotifications returns a page of the notifications of the requester from newest to oldest
+// together with the cursor of the next page and the number of unread notifications
+func (usecase *notificationUsecase) GetNotifications(requesterID primitive.ObjectID, cursor string, limit int, unreadOnly bool) ([]*models.Notification, string, int, error) {
+	if limit <= 0 {
+		limit = notification.DefaultNotificationsLimit
+	} else if limit > notification.MaxNotificationsLimit {
+		limit = notification.MaxNotificationsLimit
+	}
+
+	var cursorNotification *models.Notification
+	if cursor != "" {
+		cursorID, cursorCreatedAt, err := utils.DecodeCursor(cursor)
+		if err != nil {
+			return nil, "", 0, err
+		}
+
+		cursorNotification = &models.Notification{ID: cursorID, CreatedAt: cursorCreatedAt}
+	}
+
+	notifications, err := usecase.notificationRepo.GetUserNotifications(requesterID)
+	if err != nil {
+		return nil, "", 0, err
+	}
+
+	unreadCount := 0
+	filteredNotifications := []*models.Notification{}
+	for _, _notification := range notifications {
+		if !_notification.Read {
+			unreadCount++
+		}
+
+		if unreadOnly && _notification.Read {
+			continue
+		}
+
+		filteredNotifications = append(filteredNotifications, _notification)
+	}
+
+	sort.Slice(filteredNotifications, func(i, j int) bool {
+		return utils.IsNewerRecord(filteredNotifications[i].ID, filteredNotifications[i].CreatedAt, filteredNotifications[j].ID, filteredNotifications[j].CreatedAt)
+	})
+
+	start := 0
+	if cursorNotification != nil {
+		start = len(filteredNotifications)
+		for i, _notification := range filteredNotifications {
+			if utils.IsNewerRecord(cursorNotification.ID, cursorNotification.CreatedAt, _notification.ID, _notification.CreatedAt) {
+				start = i
+				break
+			}
+		}
+	}
+
+	end := start + limit
+	if end > len(filteredNotifications) {
+		end = len(filteredNotifications)
+	}
+
+	page := filteredNotifications[start:end]
+
+	nextCursor := ""
+	if end < len(filteredNotifications) {
+		last := page[len(page)-1]
+		nextCursor = utils.EncodeCursor(last.ID, last.CreatedAt)
+	}
+
+	return page, nextCursor, unreadCount, nil
+}
+
+func (usecase *notificationUsecase) MarkAsRead(requesterID, notificationID primitive.ObjectID) error {
+	// notifications are stored under the user they are for so
+	// notifications of other users are never found
+	_notification, err := usecase.notificationRepo.GetNotificationByID(requesterID, notificationID)
+	if err != nil {
+		return err
+	}
+
+	if _notification.Read {
+		return nil
+	}
+
+	err = usecase.notificationRepo.MarkAsRead(requesterID, []primitive.ObjectID{_notification.ID})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (usecase *notificationUsecase) MarkAllAsRead(requesterID primitive.ObjectID) error {
+	notifications, err := usecase.notificationRepo.GetUserNotifications(requesterID)
+	if err != nil {
+		return err
+	}
+
+	unreadNotificationIDs := []primitive.ObjectID{}
+	for _, _notification := range notifications {
+		if !_notification.Read {
+			unreadNotificationIDs = append(unreadNotificationIDs, _notification.ID)
+		}
+	}
+
+	err = usecase.notificationRepo.MarkAsRead(requesterID, unreadNotificationIDs)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/notification"
+	nr "github.com/jordyf15/thullo-api/notification/mocks"
+	"github.com/jordyf15/thullo-api/notification/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestNotificationUsecase(t *testing.T) {
+	suite.Run(t, new(notificationUsecaseSuite))
+}
+
+var (
+	userID = primitive.NewObjectID()
+
+	notification1 = &models.Notification{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		Type:   models.NotificationTypeBoardMemberAdded,
+		Read:   true,
+	}
+	notification2 = &models.Notification{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		Type:   models.NotificationTypeCardComment,
+	}
+	notification3 = &models.Notification{
+		ID:     primitive.NewObjectID(),
+		UserID: userID,
+		Type:   models.NotificationTypeCommentMention,
+	}
+)
+
+type notificationUsecaseSuite struct {
+	suite.Suite
+
+	usecase          notification.Usecase
+	notificationRepo *nr.Repository
+}
+
+func (s *notificationUsecaseSuite) SetupTest() {
+	s.notificationRepo = new(nr.Repository)
+
+	now := time.Now()
+	notification1.CreatedAt = now.Add(-2 * time.Hour)
+	notification2.CreatedAt = now.Add(-time.Hour)
+	notification3.CreatedAt = now
+
+	s.notificationRepo.On("GetUserNotifications", mock.AnythingOfType("primitive.ObjectID")).Return(func(_userID primitive.ObjectID) []*models.Notification {
+		if _userID == userID {
+			return []*models.Notification{notification2, notification1, notification3}
+		}
+
+		return []*models.Notification{}
+	}, nil)
+	s.notificationRepo.On("GetNotificationByID", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("primitive.ObjectID")).Return(func(_userID, notificationID primitive.ObjectID) *models.Notification {
+		if _userID != userID {
+			return nil
+		}
+
+		switch notificationID {
+		case notification1.ID:
+			return notification1
+		case notification2.ID:
+			return notification2
+		}
+
+		return nil
+	}, func(_userID, notificationID primitive.ObjectID) error {
+		if _userID != userID || (notificationID != notification1.ID && notificationID != notification2.ID) {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	s.notificationRepo.On("MarkAsRead", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("[]primitive.ObjectID")).Return(nil)
+
+	s.usecase = usecase.NewNotificationUsecase(s.notificationRepo)
+}
+
+func (s *notificationUsecaseSuite) TestGetNotificationsNewestFirst() {
+	notifications, nextCursor, unreadCount, err := s.usecase.GetNotifications(userID, "", 0, false)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Notification{notification3, notification2, notification1}, notifications)
+	assert.Empty(s.T(), nextCursor)
+	assert.Equal(s.T(), 2, unreadCount)
+}
+
+func (s *notificationUsecaseSuite) TestGetNotificationsUnreadOnly() {
+	notifications, _, unreadCount, err := s.usecase.GetNotifications(userID, "", 0, true)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Notification{notification3, notification2}, notifications)
+	assert.Equal(s.T(), 2, unreadCount)
+}
+
+func (s *notificationUsecaseSuite) TestGetNotificationsPaginated() {
+	notifications, nextCursor, _, err := s.usecase.GetNotifications(userID, "", 2, false)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Notification{notification3, notification2}, notifications)
+	assert.NotEmpty(s.T(), nextCursor)
+
+	notifications, nextCursor, _, err = s.usecase.GetNotifications(userID, nextCursor, 2, false)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Notification{notification1}, notifications)
+	assert.Empty(s.T(), nextCursor)
+}
+
+func (s *notificationUsecaseSuite) TestGetNotificationsInvalidCursor() {
+	_, _, _, err := s.usecase.GetNotifications(userID, "not-a-cursor", 0, false)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrInvalidCursor.Error(), err.Error())
+	s.notificationRepo.AssertNumberOfCalls(s.T(), "GetUserNotifications", 0)
+}
+
+func (s *notificationUsecaseSuite) TestMarkAsReadOtherUserNotification() {
+	err := s.usecase.MarkAsRead(primitive.NewObjectID(), notification2.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.notificationRepo.AssertNumberOfCalls(s.T(), "MarkAsRead", 0)
+}
+
+func (s *notificationUsecaseSuite) TestMarkAsReadAlreadyRead() {
+	err := s.usecase.MarkAsRead(userID, notification1.ID)
+
+	assert.NoError(s.T(), err)
+	s.notificationRepo.AssertNumberOfCalls(s.T(), "MarkAsRead", 0)
+}
+
+func (s *notificationUsecaseSuite) TestMarkAsReadSuccessful() {
+	err := s.usecase.MarkAsRead(userID, notification2.ID)
+
+	assert.NoError(s.T(), err)
+	s.notificationRepo.AssertCalled(s.T(), "MarkAsRead", userID, []primitive.ObjectID{notification2.ID})
+}
+
+func (s *notificationUsecaseSuite) TestMarkAllAsRead() {
+	err := s.usecase.MarkAllAsRead(userID)
+
+	assert.NoError(s.T(), err)
+	s.notificationRepo.AssertNumberOfCalls(s.T(), "MarkAsRead", 1)
+
+	markedIDs := s.notificationRepo.Calls[len(s.notificationRepo.Calls)-1].Arguments.Get(1).([]primitive.ObjectID)
+	assert.ElementsMatch(s.T(), []primitive.ObjectID{notification2.ID, notification3.ID}, markedIDs)
+}
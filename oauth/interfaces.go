@@ -2,6 +2,36 @@ package oauth
 
 import "github.com/jordyf15/thullo-api/models"
 
+const (
+	GithubBaseURL    = "https://github.com"
+	GithubAPIBaseURL = "https://api.github.com"
+
+	OIDCProviderGoogle = "google"
+	GoogleIssuer       = "https://accounts.google.com"
+)
+
+// GithubConfig is the github oauth app the users log in through, the base urls
+// can be pointed at another server such as an httptest server in tests
+type GithubConfig struct {
+	ClientID     string
+	ClientSecret string
+	BaseURL      string
+	APIBaseURL   string
+}
+
+// OIDCProviderConfig is an OpenID Connect provider whose id tokens users can log in with
+type OIDCProviderConfig struct {
+	// Name is how the provider is referred to when logging in
+	Name string
+	// Issuer is where the discovery document is fetched from and has to match the iss claim
+	Issuer string
+	// ClientIDs are the clients of this app registered at the provider, an id token has to be issued to one of them
+	ClientIDs []string
+}
+
 type Repository interface {
-	GetGoogleTokenInfo(token string) (*models.GoogleTokenInfo, error)
+	// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of an id token issued by the provider
+	VerifyIDToken(provider, idToken, nonce string) (*models.IDTokenClaims, error)
+	// GetGithubUserInfo exchanges the code github redirected the user back with for the user's account
+	GetGithubUserInfo(code string) (*models.GithubUserInfo, error)
 }
@@ -12,22 +12,45 @@ type Repository struct {
 	mock.Mock
 }
 
-// GetGoogleTokenInfo provides a mock function with given fields: token
-func (_m *Repository) GetGoogleTokenInfo(token string) (*models.GoogleTokenInfo, error) {
-	ret := _m.Called(token)
+// GetGithubUserInfo provides a mock function with given fields: code
+func (_m *Repository) GetGithubUserInfo(code string) (*models.GithubUserInfo, error) {
+	ret := _m.Called(code)
 
-	var r0 *models.GoogleTokenInfo
-	if rf, ok := ret.Get(0).(func(string) *models.GoogleTokenInfo); ok {
-		r0 = rf(token)
+	var r0 *models.GithubUserInfo
+	if rf, ok := ret.Get(0).(func(string) *models.GithubUserInfo); ok {
+		r0 = rf(code)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*models.GoogleTokenInfo)
+			r0 = ret.Get(0).(*models.GithubUserInfo)
 		}
 	}
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(token)
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyIDToken provides a mock function with given fields: provider, idToken, nonce
+func (_m *Repository) VerifyIDToken(provider string, idToken string, nonce string) (*models.IDTokenClaims, error) {
+	ret := _m.Called(provider, idToken, nonce)
+
+	var r0 *models.IDTokenClaims
+	if rf, ok := ret.Get(0).(func(string, string, string) *models.IDTokenClaims); ok {
+		r0 = rf(provider, idToken, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IDTokenClaims)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(provider, idToken, nonce)
 	} else {
 		r1 = ret.Error(1)
 	}
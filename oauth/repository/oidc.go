@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/oauth"
+)
+
+const (
+	jwksRefreshInterval = time.Hour
+	// jwksRefreshRateLimit keeps tokens signed with unknown keys from making us refetch the keys on every login
+	jwksRefreshRateLimit = 5 * time.Minute
+)
+
+var idTokenSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// oidcProvider holds the signing keys of a provider once they have been discovered
+type oidcProvider struct {
+	config oauth.OIDCProviderConfig
+
+	mutex sync.Mutex
+	jwks  *keyfunc.JWKS
+}
+
+type oidcDiscoveryDocument struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+func (repo *oauthRepository) VerifyIDToken(providerName, idToken, nonce string) (*models.IDTokenClaims, error) {
+	provider, isExist := repo.oidcProviders[providerName]
+	if !isExist {
+		return nil, custom_errors.ErrOIDCProviderUnknown
+	}
+
+	jwks, err := repo.getJWKS(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := &models.IDTokenClaims{}
+	_, err = jwt.NewParser(jwt.WithValidMethods(idTokenSigningMethods)).ParseWithClaims(idToken, claims, jwks.Keyfunc)
+	if err != nil {
+		// the expiry is only worth reporting when nothing else is wrong with the token
+		if validationErr, ok := err.(*jwt.ValidationError); ok && validationErr.Errors == jwt.ValidationErrorExpired {
+			return nil, custom_errors.ErrIDTokenExpired
+		}
+
+		return nil, custom_errors.ErrIDTokenInvalid
+	}
+
+	// google also issues id tokens with the scheme left out of the issuer
+	issuer := provider.config.Issuer
+	if claims.Issuer != issuer && claims.Issuer != strings.TrimPrefix(issuer, "https://") {
+		return nil, custom_errors.ErrIDTokenInvalid
+	}
+
+	isAudienceValid := false
+	for _, clientID := range provider.config.ClientIDs {
+		if claims.VerifyAudience(clientID, true) {
+			isAudienceValid = true
+			break
+		}
+	}
+
+	if !isAudienceValid || claims.Nonce != nonce {
+		return nil, custom_errors.ErrIDTokenInvalid
+	}
+
+	return claims, nil
+}
+
+// getJWKS discovers the provider on its first use, the keys are then kept and refreshed in the background
+func (repo *oauthRepository) getJWKS(provider *oidcProvider) (*keyfunc.JWKS, error) {
+	provider.mutex.Lock()
+	defer provider.mutex.Unlock()
+
+	if provider.jwks != nil {
+		return provider.jwks, nil
+	}
+
+	document, err := repo.getOIDCDiscoveryDocument(provider.config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	jwks, err := keyfunc.Get(document.JWKSURI, keyfunc.Options{
+		Client:            repo.httpclient,
+		RefreshInterval:   jwksRefreshInterval,
+		RefreshRateLimit:  jwksRefreshRateLimit,
+		RefreshUnknownKID: true,
+		RefreshErrorHandler: func(err error) {
+			log.Printf("failed to refresh the signing keys of %s: %s", provider.config.Name, err)
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	provider.jwks = jwks
+
+	return jwks, nil
+}
+
+func (repo *oauthRepository) getOIDCDiscoveryDocument(issuer string) (*oidcDiscoveryDocument, error) {
+	response, err := repo.httpclient.Get(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s responded with status %d for its discovery document", issuer, response.StatusCode)
+	}
+
+	document := &oidcDiscoveryDocument{}
+	err = json.NewDecoder(response.Body).Decode(document)
+	if err != nil {
+		return nil, err
+	}
+
+	if document.Issuer != issuer {
+		return nil, fmt.Errorf("discovery document of %s is for issuer %s", issuer, document.Issuer)
+	}
+
+	if document.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document of %s has no jwks_uri", issuer)
+	}
+
+	return document, nil
+}
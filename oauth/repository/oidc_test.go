@@ -0,0 +1,178 @@
+package repository_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/oauth"
+	"github.com/jordyf15/thullo-api/oauth/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOIDCVerifier(t *testing.T) {
+	suite.Run(t, new(oidcVerifierSuite))
+}
+
+var (
+	signingKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	otherKey, _   = rsa.GenerateKey(rand.Reader, 2048)
+)
+
+type oidcVerifierSuite struct {
+	suite.Suite
+	repository oauth.Repository
+	provider   *httptest.Server
+
+	discoveredIssuer  string
+	discoveryRequests int
+	jwksRequests      int
+}
+
+func (s *oidcVerifierSuite) SetupTest() {
+	s.discoveryRequests, s.jwksRequests = 0, 0
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		s.discoveryRequests++
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   s.discoveredIssuer,
+			"jwks_uri": s.provider.URL + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		s.jwksRequests++
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "key1",
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(signingKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(signingKey.E)).Bytes()),
+			}},
+		})
+	})
+	s.provider = httptest.NewServer(mux)
+	s.discoveredIssuer = s.provider.URL
+
+	s.repository = repository.NewOauthRepository(s.provider.Client(), oauth.GithubConfig{}, []oauth.OIDCProviderConfig{
+		{Name: "keycloak", Issuer: s.provider.URL, ClientIDs: []string{"web", "mobile"}},
+	})
+}
+
+func (s *oidcVerifierSuite) TearDownTest() {
+	s.provider.Close()
+}
+
+func (s *oidcVerifierSuite) newClaims() *models.IDTokenClaims {
+	return &models.IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.provider.URL,
+			Subject:   "1",
+			Audience:  jwt.ClaimStrings{"mobile"},
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Email:             "jonathan@gmail.com",
+		EmailVerified:     true,
+		Nonce:             "nonce",
+		Name:              "jonathan joestar",
+		PreferredUsername: "jojo",
+	}
+}
+
+func sign(claims *models.IDTokenClaims, key *rsa.PrivateKey) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "key1"
+	signedToken, _ := token.SignedString(key)
+
+	return signedToken
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenUnknownProvider() {
+	claims, err := s.repository.VerifyIDToken("unknown", sign(s.newClaims(), signingKey), "nonce")
+
+	assert.Nil(s.T(), claims)
+	assert.Equal(s.T(), custom_errors.ErrOIDCProviderUnknown, err)
+	assert.Equal(s.T(), 0, s.discoveryRequests)
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenDiscoveredIssuerMismatch() {
+	s.discoveredIssuer = "https://attacker.example.com"
+
+	claims, err := s.repository.VerifyIDToken("keycloak", sign(s.newClaims(), signingKey), "nonce")
+
+	assert.Nil(s.T(), claims)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), 0, s.jwksRequests)
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenInvalidSignature() {
+	claims, err := s.repository.VerifyIDToken("keycloak", sign(s.newClaims(), otherKey), "nonce")
+
+	assert.Nil(s.T(), claims)
+	assert.Equal(s.T(), custom_errors.ErrIDTokenInvalid, err)
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenExpired() {
+	expiredClaims := s.newClaims()
+	expiredClaims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+
+	claims, err := s.repository.VerifyIDToken("keycloak", sign(expiredClaims, signingKey), "nonce")
+
+	assert.Nil(s.T(), claims)
+	assert.Equal(s.T(), custom_errors.ErrIDTokenExpired, err)
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenWrongIssuer() {
+	otherIssuerClaims := s.newClaims()
+	otherIssuerClaims.Issuer = "https://attacker.example.com"
+
+	claims, err := s.repository.VerifyIDToken("keycloak", sign(otherIssuerClaims, signingKey), "nonce")
+
+	assert.Nil(s.T(), claims)
+	assert.Equal(s.T(), custom_errors.ErrIDTokenInvalid, err)
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenWrongAudience() {
+	otherAudienceClaims := s.newClaims()
+	otherAudienceClaims.Audience = jwt.ClaimStrings{"another-app"}
+
+	claims, err := s.repository.VerifyIDToken("keycloak", sign(otherAudienceClaims, signingKey), "nonce")
+
+	assert.Nil(s.T(), claims)
+	assert.Equal(s.T(), custom_errors.ErrIDTokenInvalid, err)
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenWrongNonce() {
+	claims, err := s.repository.VerifyIDToken("keycloak", sign(s.newClaims(), signingKey), "another-nonce")
+
+	assert.Nil(s.T(), claims)
+	assert.Equal(s.T(), custom_errors.ErrIDTokenInvalid, err)
+}
+
+func (s *oidcVerifierSuite) TestVerifyIDTokenSuccessful() {
+	claims, err := s.repository.VerifyIDToken("keycloak", sign(s.newClaims(), signingKey), "nonce")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "jonathan@gmail.com", claims.Email)
+	assert.True(s.T(), claims.EmailVerified)
+	assert.Equal(s.T(), "jojo", claims.PreferredUsername)
+
+	_, err = s.repository.VerifyIDToken("keycloak", sign(s.newClaims(), signingKey), "nonce")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 1, s.discoveryRequests)
+	assert.Equal(s.T(), 1, s.jwksRequests)
+}
@@ -1,43 +1,125 @@
 package repository
 
 import (
-	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
-	"github.com/golang-jwt/jwt/v4"
+	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/models"
 	"github.com/jordyf15/thullo-api/oauth"
-	"google.golang.org/api/oauth2/v2"
-	"google.golang.org/api/option"
 )
 
 type oauthRepository struct {
-	httpclient *http.Client
+	httpclient    *http.Client
+	githubConfig  oauth.GithubConfig
+	oidcProviders map[string]*oidcProvider
 }
 
-func NewOauthRepository(httpClient *http.Client) oauth.Repository {
-	return &oauthRepository{httpclient: httpClient}
+func NewOauthRepository(httpClient *http.Client, githubConfig oauth.GithubConfig, oidcProviderConfigs []oauth.OIDCProviderConfig) oauth.Repository {
+	oidcProviders := make(map[string]*oidcProvider)
+	for _, config := range oidcProviderConfigs {
+		oidcProviders[config.Name] = &oidcProvider{config: config}
+	}
+
+	return &oauthRepository{httpclient: httpClient, githubConfig: githubConfig, oidcProviders: oidcProviders}
 }
 
-func (repo *oauthRepository) GetGoogleTokenInfo(token string) (*models.GoogleTokenInfo, error) {
-	ctx := context.Background()
+func (repo *oauthRepository) GetGithubUserInfo(code string) (*models.GithubUserInfo, error) {
+	accessToken, err := repo.exchangeGithubCode(code)
+	if err != nil {
+		return nil, err
+	}
 
-	oauth2Service, err := oauth2.NewService(ctx, option.WithHTTPClient(repo.httpclient))
+	userInfo := &models.GithubUserInfo{}
+	err = repo.getFromGithubAPI("/user", accessToken, userInfo)
 	if err != nil {
 		return nil, err
 	}
 
-	tokenInfoCall := oauth2Service.Tokeninfo()
-	tokenInfoCall.IdToken(token)
-	_, err = tokenInfoCall.Do()
+	// the email on the profile is only the public one, which may be missing or unverified
+	emails := []*models.GithubEmail{}
+	err = repo.getFromGithubAPI("/user/emails", accessToken, &emails)
 	if err != nil {
 		return nil, err
 	}
 
-	tokenInfo := &models.GoogleTokenInfo{}
-	_, _ = jwt.ParseWithClaims(token, tokenInfo, func(token *jwt.Token) (interface{}, error) {
-		return []byte(""), nil
-	})
+	for _, email := range emails {
+		if email.Primary && email.Verified {
+			userInfo.Email = email.Email
+			break
+		}
+	}
+
+	if userInfo.Email == "" {
+		return nil, custom_errors.ErrGithubEmailNotVerified
+	}
+
+	return userInfo, nil
+}
+
+func (repo *oauthRepository) exchangeGithubCode(code string) (string, error) {
+	form := url.Values{}
+	form.Set("client_id", repo.githubConfig.ClientID)
+	form.Set("client_secret", repo.githubConfig.ClientSecret)
+	form.Set("code", code)
+
+	request, err := http.NewRequest(http.MethodPost, repo.githubConfig.BaseURL+"/login/oauth/access_token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+
+	response, err := repo.httpclient.Do(request)
+	if err != nil {
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("github responded with status %d when exchanging the code", response.StatusCode)
+	}
+
+	// github responds with 200 even when the code is rejected, the reason is in the error field
+	result := struct {
+		AccessToken string `json:"access_token"`
+		Error       string `json:"error"`
+	}{}
+
+	err = json.NewDecoder(response.Body).Decode(&result)
+	if err != nil {
+		return "", err
+	}
+
+	if result.Error != "" || result.AccessToken == "" {
+		return "", custom_errors.ErrGithubOauthCodeInvalid
+	}
+
+	return result.AccessToken, nil
+}
+
+func (repo *oauthRepository) getFromGithubAPI(path, accessToken string, result interface{}) error {
+	request, err := http.NewRequest(http.MethodGet, repo.githubConfig.APIBaseURL+path, nil)
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Authorization", "Bearer "+accessToken)
+	request.Header.Set("Accept", "application/vnd.github+json")
+
+	response, err := repo.httpclient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("github responded with status %d for %s", response.StatusCode, path)
+	}
 
-	return tokenInfo, nil
+	return json.NewDecoder(response.Body).Decode(result)
 }
@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/oauth"
+	"github.com/jordyf15/thullo-api/oauth/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestOauthRepository(t *testing.T) {
+	suite.Run(t, new(oauthRepositorySuite))
+}
+
+type oauthRepositorySuite struct {
+	suite.Suite
+	repository oauth.Repository
+	github     *httptest.Server
+
+	receivedCode        string
+	receivedClientID    string
+	receivedAuthHeaders []string
+	emails              []*models.GithubEmail
+}
+
+func (s *oauthRepositorySuite) SetupTest() {
+	s.receivedCode, s.receivedClientID, s.receivedAuthHeaders = "", "", nil
+	s.emails = []*models.GithubEmail{
+		{Email: "old@gmail.com", Primary: false, Verified: true},
+		{Email: "jonathan@gmail.com", Primary: true, Verified: true},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		s.receivedCode = r.PostFormValue("code")
+		s.receivedClientID = r.PostFormValue("client_id")
+
+		if s.receivedCode != "code" || r.PostFormValue("client_secret") != "secret" {
+			json.NewEncoder(w).Encode(map[string]string{"error": "bad_verification_code"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "accesstoken", "token_type": "bearer"})
+	})
+	mux.HandleFunc("/user", func(w http.ResponseWriter, r *http.Request) {
+		s.receivedAuthHeaders = append(s.receivedAuthHeaders, r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":         1,
+			"login":      "jojo",
+			"name":       "jonathan joestar",
+			"avatar_url": "https://avatars.githubusercontent.com/u/1",
+			"email":      "public@gmail.com",
+		})
+	})
+	mux.HandleFunc("/user/emails", func(w http.ResponseWriter, r *http.Request) {
+		s.receivedAuthHeaders = append(s.receivedAuthHeaders, r.Header.Get("Authorization"))
+		json.NewEncoder(w).Encode(s.emails)
+	})
+	s.github = httptest.NewServer(mux)
+
+	s.repository = repository.NewOauthRepository(s.github.Client(), oauth.GithubConfig{
+		ClientID:     "clientid",
+		ClientSecret: "secret",
+		BaseURL:      s.github.URL,
+		APIBaseURL:   s.github.URL,
+	}, nil)
+}
+
+func (s *oauthRepositorySuite) TearDownTest() {
+	s.github.Close()
+}
+
+func (s *oauthRepositorySuite) TestGetGithubUserInfoInvalidCode() {
+	userInfo, err := s.repository.GetGithubUserInfo("invalid")
+
+	assert.Nil(s.T(), userInfo)
+	assert.Equal(s.T(), custom_errors.ErrGithubOauthCodeInvalid, err)
+	assert.Empty(s.T(), s.receivedAuthHeaders)
+}
+
+func (s *oauthRepositorySuite) TestGetGithubUserInfoNoVerifiedPrimaryEmail() {
+	s.emails[1].Verified = false
+
+	userInfo, err := s.repository.GetGithubUserInfo("code")
+
+	assert.Nil(s.T(), userInfo)
+	assert.Equal(s.T(), custom_errors.ErrGithubEmailNotVerified, err)
+}
+
+func (s *oauthRepositorySuite) TestGetGithubUserInfoSuccessful() {
+	userInfo, err := s.repository.GetGithubUserInfo("code")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "clientid", s.receivedClientID)
+	assert.Equal(s.T(), []string{"Bearer accesstoken", "Bearer accesstoken"}, s.receivedAuthHeaders)
+	assert.Equal(s.T(), int64(1), userInfo.ID)
+	assert.Equal(s.T(), "jojo", userInfo.Login)
+	assert.Equal(s.T(), "jonathan joestar", userInfo.Name)
+	assert.Equal(s.T(), "https://avatars.githubusercontent.com/u/1", userInfo.AvatarURL)
+	assert.Equal(s.T(), "jonathan@gmail.com", userInfo.Email)
+}
@@ -0,0 +1,119 @@
+package rank
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+const (
+	alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+	base     = len(alphabet)
+
+	// MaxLength is the length after which the ranks of a list or board should be rebalanced
+	MaxLength = 16
+)
+
+var (
+	ErrInvalidRank  = errors.New("rank is invalid")
+	ErrInvalidRange = errors.New("previous rank must be lower than the next rank")
+)
+
+// Between returns a rank that sorts strictly after prev and strictly before next.
+// An empty prev stands for the start of the ordering and an empty next for its end.
+//
+// A rank is read as the fractional digits of a base 36 number, that is why
+// valid ranks never end with a "0", there would be nothing left to put before them.
+func Between(prev, next string) (string, error) {
+	if !isValid(prev) || !isValid(next) {
+		return "", ErrInvalidRank
+	}
+
+	if next != "" && prev >= next {
+		return "", ErrInvalidRange
+	}
+
+	key := []byte{}
+	boundedByNext := next != ""
+
+	for i := 0; ; i++ {
+		low := digitAt(prev, i)
+		high := base
+
+		if boundedByNext {
+			high = digitAt(next, i)
+		}
+
+		if high-low > 1 {
+			key = append(key, alphabet[(low+high)/2])
+			return string(key), nil
+		}
+
+		// there is no digit left in between, keep the lower digit
+		// and look for room in the next position
+		key = append(key, alphabet[low])
+		if high > low {
+			boundedByNext = false
+		}
+	}
+}
+
+// Spread returns n ranks that are evenly spaced over the whole ordering,
+// it is used to give a fresh set of short ranks to a list or a board
+func Spread(n int) []string {
+	ranks := make([]string, n)
+	if n == 0 {
+		return ranks
+	}
+
+	// use enough digits to leave roughly a full digit of room around every rank
+	length := 1
+	capacity := big.NewInt(int64(base))
+	minimumCapacity := big.NewInt(int64((n + 1) * base))
+	for capacity.Cmp(minimumCapacity) < 0 {
+		capacity.Mul(capacity, big.NewInt(int64(base)))
+		length++
+	}
+
+	gap := new(big.Int).Div(capacity, big.NewInt(int64(n+1)))
+	value := new(big.Int)
+
+	for i := range ranks {
+		value.Add(value, gap)
+
+		digits := value.Text(base)
+		digits = strings.Repeat("0", length-len(digits)) + digits
+
+		ranks[i] = strings.TrimRight(digits, "0")
+	}
+
+	return ranks
+}
+
+// NeedsRebalance reports whether rank has grown long enough that the items
+// it is ordered with should be given new ranks through Spread
+func NeedsRebalance(rank string) bool {
+	return len(rank) > MaxLength
+}
+
+func isValid(rank string) bool {
+	if strings.HasSuffix(rank, "0") {
+		return false
+	}
+
+	for _, char := range rank {
+		if !strings.ContainsRune(alphabet, char) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func digitAt(rank string, i int) int {
+	if i >= len(rank) {
+		return 0
+	}
+
+	return strings.IndexByte(alphabet, rank[i])
+}
@@ -0,0 +1,120 @@
+package rank_test
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/jordyf15/thullo-api/rank"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestRank(t *testing.T) {
+	suite.Run(t, new(rankSuite))
+}
+
+type rankSuite struct {
+	suite.Suite
+}
+
+func (s *rankSuite) TestBetweenEmptyBounds() {
+	key, err := rank.Between("", "")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "i", key)
+}
+
+func (s *rankSuite) TestBetweenAdjacentDigits() {
+	key, err := rank.Between("a", "b")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "ai", key)
+}
+
+func (s *rankSuite) TestBetweenInvalidRange() {
+	_, err := rank.Between("b", "a")
+
+	assert.Equal(s.T(), rank.ErrInvalidRange, err)
+
+	_, err = rank.Between("a", "a")
+
+	assert.Equal(s.T(), rank.ErrInvalidRange, err)
+}
+
+func (s *rankSuite) TestBetweenInvalidRank() {
+	_, err := rank.Between("a0", "b")
+
+	assert.Equal(s.T(), rank.ErrInvalidRank, err)
+
+	_, err = rank.Between("A", "")
+
+	assert.Equal(s.T(), rank.ErrInvalidRank, err)
+}
+
+func (s *rankSuite) TestBetweenRepeatedInsertions() {
+	ranks := []string{}
+	random := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		index := random.Intn(len(ranks) + 1)
+
+		prev, next := "", ""
+		if index > 0 {
+			prev = ranks[index-1]
+		}
+		if index < len(ranks) {
+			next = ranks[index]
+		}
+
+		key, err := rank.Between(prev, next)
+		assert.NoError(s.T(), err)
+		assert.Greater(s.T(), key, prev)
+		if next != "" {
+			assert.Less(s.T(), key, next)
+		}
+
+		ranks = append(ranks[:index], append([]string{key}, ranks[index:]...)...)
+	}
+
+	assert.True(s.T(), sort.StringsAreSorted(ranks))
+}
+
+func (s *rankSuite) TestBetweenAlwaysInsertingAtTheTop() {
+	key := ""
+
+	for i := 0; i < 100; i++ {
+		next, err := rank.Between("", key)
+
+		assert.NoError(s.T(), err)
+		if key != "" {
+			assert.Less(s.T(), next, key)
+		}
+
+		key = next
+	}
+}
+
+func (s *rankSuite) TestSpread() {
+	ranks := rank.Spread(100)
+
+	assert.Len(s.T(), ranks, 100)
+	assert.True(s.T(), sort.StringsAreSorted(ranks))
+
+	for i, key := range ranks {
+		assert.False(s.T(), rank.NeedsRebalance(key))
+		assert.NotEqual(s.T(), byte('0'), key[len(key)-1])
+
+		if i > 0 {
+			assert.NotEqual(s.T(), ranks[i-1], key)
+
+			// there has to be room left in between the spread ranks
+			_, err := rank.Between(ranks[i-1], key)
+			assert.NoError(s.T(), err)
+		}
+	}
+}
+
+func (s *rankSuite) TestSpreadEmpty() {
+	assert.Len(s.T(), rank.Spread(0), 0)
+}
@@ -1,49 +1,92 @@
 package main
 
 import (
+	"context"
 	"net/http"
+	"os"
+	"strings"
 
+	"github.com/gin-gonic/gin"
 	"github.com/jordyf15/thullo-api/controllers"
+	"github.com/jordyf15/thullo-api/mail"
+	"github.com/jordyf15/thullo-api/oauth"
 	"github.com/jordyf15/thullo-api/storage"
 	tr "github.com/jordyf15/thullo-api/token/repository"
 	tu "github.com/jordyf15/thullo-api/token/usecase"
+	"github.com/jordyf15/thullo-api/webhook"
 
+	acr "github.com/jordyf15/thullo-api/activity/repository"
+	ar "github.com/jordyf15/thullo-api/attachment/repository"
 	bmr "github.com/jordyf15/thullo-api/board_member/repository"
 	cr "github.com/jordyf15/thullo-api/card/repository"
+	chr "github.com/jordyf15/thullo-api/checklist/repository"
 	cmr "github.com/jordyf15/thullo-api/comment/repository"
+	er "github.com/jordyf15/thullo-api/event/repository"
+	lbr "github.com/jordyf15/thullo-api/label/repository"
+	nr "github.com/jordyf15/thullo-api/notification/repository"
+	or "github.com/jordyf15/thullo-api/oauth/repository"
 	ur "github.com/jordyf15/thullo-api/user/repository"
+	ubr "github.com/jordyf15/thullo-api/user_boards/repository"
+	wr "github.com/jordyf15/thullo-api/webhook/repository"
 
+	acu "github.com/jordyf15/thullo-api/activity/usecase"
+	au "github.com/jordyf15/thullo-api/attachment/usecase"
 	br "github.com/jordyf15/thullo-api/board/repository"
 	bu "github.com/jordyf15/thullo-api/board/usecase"
 	cu "github.com/jordyf15/thullo-api/card/usecase"
+	chu "github.com/jordyf15/thullo-api/checklist/usecase"
 	cmu "github.com/jordyf15/thullo-api/comment/usecase"
+	eu "github.com/jordyf15/thullo-api/event/usecase"
+	lbu "github.com/jordyf15/thullo-api/label/usecase"
 	lr "github.com/jordyf15/thullo-api/list/repository"
 	lu "github.com/jordyf15/thullo-api/list/usecase"
+	nu "github.com/jordyf15/thullo-api/notification/usecase"
 	unr "github.com/jordyf15/thullo-api/unsplash/repository"
 	uu "github.com/jordyf15/thullo-api/user/usecase"
-
-	or "github.com/jordyf15/thullo-api/oauth/repository"
+	wu "github.com/jordyf15/thullo-api/webhook/usecase"
 )
 
 func initializeRoutes() {
 	_storage := storage.NewImgurStorage(&http.Client{})
+	fileStorage := storage.NewDiskStorage(fileStorageDirectory(), os.Getenv("FILE_STORAGE_URL"))
+	mailSender := mail.NewSMTPSender(os.Getenv("SMTP_HOST"), os.Getenv("SMTP_PORT"), os.Getenv("SMTP_USERNAME"), os.Getenv("SMTP_PASSWORD"), os.Getenv("MAIL_FROM"))
 
 	tokenRepo := tr.NewTokenRepository(dbClient, redisClient)
 	userRepo := ur.NewUserRepository(dbClient)
-	oauthRepo := or.NewOauthRepository(&http.Client{})
+	oauthRepo := or.NewOauthRepository(&http.Client{}, oauth.GithubConfig{
+		ClientID:     os.Getenv("GITHUB_CLIENT_ID"),
+		ClientSecret: os.Getenv("GITHUB_CLIENT_SECRET"),
+		BaseURL:      oauth.GithubBaseURL,
+		APIBaseURL:   oauth.GithubAPIBaseURL,
+	}, oidcProvidersFromEnv())
 	boardRepo := br.NewBoardRepository(rtdbClient)
 	unsplashRepo := unr.NewUnsplashRepository(&http.Client{})
 	listRepo := lr.NewListRepository(rtdbClient)
 	cardRepo := cr.NewCardRepository(rtdbClient)
 	boardMemberRepo := bmr.NewBoardMemberRepository(rtdbClient)
 	commentRepo := cmr.NewCommentRepository(rtdbClient)
+	userBoardsRepo := ubr.NewUserBoardsRepository(rtdbClient)
+	attachmentRepo := ar.NewAttachmentRepository(rtdbClient)
+	labelRepo := lbr.NewLabelRepository(rtdbClient)
+	checklistRepo := chr.NewChecklistRepository(rtdbClient)
+	notificationRepo := nr.NewNotificationRepository(rtdbClient)
+	activityRepo := acr.NewActivityRepository(rtdbClient)
+	webhookRepo := wr.NewWebhookRepository(rtdbClient, redisClient)
+	eventRepo := er.NewEventRepository(redisClient, webhookRepo)
 
 	tokenUsecase := tu.NewTokenUsecase(tokenRepo)
-	userUsecase := uu.NewUserUsecase(userRepo, tokenRepo, oauthRepo, _storage)
-	boardUsecase := bu.NewBoardUsecase(boardRepo, unsplashRepo, boardMemberRepo, userRepo, _storage)
-	listUsecase := lu.NewListUsecase(listRepo, boardRepo, boardMemberRepo)
-	cardUsecase := cu.NewCardUsecase(listRepo, cardRepo, boardMemberRepo)
-	commentUsecase := cmu.NewCommentUsecase(boardMemberRepo, cardRepo, commentRepo, boardRepo, listRepo)
+	userUsecase := uu.NewUserUsecase(userRepo, tokenRepo, oauthRepo, _storage, mailSender)
+	boardUsecase := bu.NewBoardUsecase(boardRepo, unsplashRepo, boardMemberRepo, userRepo, listRepo, cardRepo, userBoardsRepo, commentRepo, attachmentRepo, labelRepo, notificationRepo, eventRepo, _storage, fileStorage)
+	listUsecase := lu.NewListUsecase(listRepo, boardRepo, boardMemberRepo, cardRepo, commentRepo, attachmentRepo, eventRepo, _storage, fileStorage)
+	cardUsecase := cu.NewCardUsecase(listRepo, cardRepo, boardMemberRepo, unsplashRepo, userRepo, notificationRepo, eventRepo, _storage)
+	commentUsecase := cmu.NewCommentUsecase(boardMemberRepo, cardRepo, commentRepo, boardRepo, listRepo, userRepo, notificationRepo, eventRepo, _storage)
+	attachmentUsecase := au.NewAttachmentUsecase(boardMemberRepo, listRepo, cardRepo, attachmentRepo, eventRepo, _storage, fileStorage)
+	labelUsecase := lbu.NewLabelUsecase(labelRepo, boardRepo, boardMemberRepo, listRepo, cardRepo, eventRepo)
+	checklistUsecase := chu.NewChecklistUsecase(checklistRepo, boardMemberRepo, listRepo, cardRepo, eventRepo)
+	notificationUsecase := nu.NewNotificationUsecase(notificationRepo)
+	eventUsecase := eu.NewEventUsecase(eventRepo, boardRepo, boardMemberRepo)
+	activityUsecase := acu.NewActivityUsecase(activityRepo, boardRepo, boardMemberRepo, listRepo, cardRepo)
+	webhookUsecase := wu.NewWebhookUsecase(webhookRepo, boardMemberRepo)
 
 	tokenController := controllers.NewTokenController(tokenUsecase)
 	userController := controllers.NewUserController(userUsecase)
@@ -51,29 +94,147 @@ func initializeRoutes() {
 	listController := controllers.NewListController(listUsecase)
 	cardController := controllers.NewCardController(cardUsecase)
 	commentController := controllers.NewCommentController(commentUsecase)
+	attachmentController := controllers.NewAttachmentController(attachmentUsecase)
+	labelController := controllers.NewLabelController(labelUsecase)
+	checklistController := controllers.NewChecklistController(checklistUsecase)
+	notificationController := controllers.NewNotificationController(notificationUsecase)
+	eventController := controllers.NewEventController(eventUsecase)
+	activityController := controllers.NewActivityController(activityUsecase)
+	webhookController := controllers.NewWebhookController(webhookUsecase)
+
+	webhookDispatcher := wu.NewWebhookDispatcher(webhookRepo, eventRepo, webhook.NewHTTPClient(webhook.DeliveryTimeout))
+	go webhookDispatcher.Run(context.Background())
 
 	router.GET("_health", health)
 
+	// the files are downloaded rather than rendered so an uploaded page can't run scripts on this origin
+	files := router.Group("files", func(c *gin.Context) {
+		c.Header("Content-Disposition", "attachment")
+		c.Header("X-Content-Type-Options", "nosniff")
+	})
+	files.Static("", fileStorageDirectory())
+
 	router.POST("tokens/refresh", tokenController.RefreshAccessToken)
 	router.POST("tokens/remove", tokenController.DeleteRefreshToken)
 
 	router.POST("register", userController.Register)
 	router.POST("login", userController.Login)
 	router.POST("login/google", userController.LoginWithGoogle)
-
+	router.POST("login/oidc/:provider", userController.LoginWithOIDC)
+	router.POST("login/github", userController.LoginWithGithub)
+	router.POST("password/forgot", userController.ForgotPassword)
+	router.POST("password/reset", userController.ResetPassword)
+	router.POST("email/verify", userController.VerifyEmail)
+
+	router.GET("users/me", userController.GetCurrentUser)
+	router.PATCH("users/me", userController.UpdateCurrentUser)
+	router.POST("users/me/password", userController.ChangePassword)
+	router.POST("users/me/verification", userController.SendVerificationEmail)
+	router.GET("users/:user_id", userController.GetUser)
+
+	router.GET("notifications", notificationController.GetNotifications)
+	router.POST("notifications/read", notificationController.MarkAllAsRead)
+	router.POST("notifications/:notification_id/read", notificationController.MarkAsRead)
+
+	router.GET("boards", boardController.GetUserBoards)
 	router.POST("boards", boardController.Create)
+	router.GET("boards/:board_id", boardController.Get)
 	router.PATCH("boards/:board_id", boardController.Update)
+	router.DELETE("boards/:board_id", boardController.Delete)
+	router.GET("boards/:board_id/archived", boardController.GetArchivedItems)
+	router.GET("boards/:board_id/events", eventController.Stream)
+	router.GET("boards/:board_id/activities", activityController.GetBoardActivities)
 
 	router.POST("boards/:board_id/members", boardController.AddMember)
 	router.PATCH("boards/:board_id/members/:member_id", boardController.UpdateMemberRole)
 	router.DELETE("boards/:board_id/members/:member_id", boardController.DeleteMember)
 
+	router.GET("boards/:board_id/webhooks", webhookController.GetBoardWebhooks)
+	router.POST("boards/:board_id/webhooks", webhookController.Create)
+	router.PATCH("boards/:board_id/webhooks/:webhook_id", webhookController.Update)
+	router.DELETE("boards/:board_id/webhooks/:webhook_id", webhookController.Delete)
+	router.GET("boards/:board_id/webhooks/:webhook_id/deliveries", webhookController.GetDeliveryLogs)
+
+	router.GET("boards/:board_id/labels", labelController.GetBoardLabels)
+	router.POST("boards/:board_id/labels", labelController.Create)
+	router.PATCH("boards/:board_id/labels/:label_id", labelController.Update)
+	router.DELETE("boards/:board_id/labels/:label_id", labelController.Delete)
+
 	router.POST("boards/:board_id/lists", listController.Create)
 	router.PATCH("boards/:board_id/lists/:list_id", listController.Update)
+	router.DELETE("boards/:board_id/lists/:list_id", listController.Delete)
 
+	router.GET("boards/:board_id/lists/:list_id/cards/:card_id/comments", commentController.GetCardComments)
 	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/comments", commentController.Create)
 	router.PATCH("boards/:board_id/lists/:list_id/cards/:card_id/comments/:comment_id", commentController.Update)
 	router.DELETE("boards/:board_id/lists/:list_id/cards/:card_id/comments/:comment_id", commentController.Delete)
 
 	router.POST("boards/:board_id/lists/:list_id/cards", cardController.Create)
+	router.PATCH("boards/:board_id/lists/:list_id/cards/:card_id", cardController.Update)
+	router.PATCH("boards/:board_id/lists/:list_id/cards/:card_id/move", cardController.Move)
+	router.GET("boards/:board_id/lists/:list_id/cards/:card_id/activities", activityController.GetCardActivities)
+	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/assignees", cardController.Assign)
+	router.DELETE("boards/:board_id/lists/:list_id/cards/:card_id/assignees/:user_id", cardController.Unassign)
+	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/watch", cardController.Watch)
+	router.DELETE("boards/:board_id/lists/:list_id/cards/:card_id/watch", cardController.Unwatch)
+
+	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/attachments", attachmentController.Upload)
+	router.GET("boards/:board_id/lists/:list_id/cards/:card_id/attachments", attachmentController.GetCardAttachments)
+	router.PATCH("boards/:board_id/lists/:list_id/cards/:card_id/attachments/:attachment_id/cover", attachmentController.SetAsCover)
+	router.DELETE("boards/:board_id/lists/:list_id/cards/:card_id/attachments/:attachment_id", attachmentController.Delete)
+
+	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/labels", labelController.AttachToCard)
+	router.DELETE("boards/:board_id/lists/:list_id/cards/:card_id/labels/:label_id", labelController.DetachFromCard)
+
+	router.GET("boards/:board_id/lists/:list_id/cards/:card_id/checklists", checklistController.GetCardChecklists)
+	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/checklists", checklistController.Create)
+	router.PATCH("boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id", checklistController.Update)
+	router.DELETE("boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id", checklistController.Delete)
+	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items", checklistController.AddItem)
+	router.PATCH("boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items/:item_id", checklistController.UpdateItem)
+	router.DELETE("boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items/:item_id", checklistController.DeleteItem)
+	router.POST("boards/:board_id/lists/:list_id/cards/:card_id/checklists/:checklist_id/items/:item_id/card", checklistController.ConvertItemToCard)
+}
+
+// oidcProvidersFromEnv reads the providers listed in OIDC_PROVIDERS, each configured by
+// OIDC_<NAME>_ISSUER and the comma separated OIDC_<NAME>_CLIENT_IDS
+func oidcProvidersFromEnv() []oauth.OIDCProviderConfig {
+	names := os.Getenv("OIDC_PROVIDERS")
+	if names == "" {
+		names = oauth.OIDCProviderGoogle
+	}
+
+	providers := []oauth.OIDCProviderConfig{}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		envPrefix := "OIDC_" + strings.ToUpper(name) + "_"
+		provider := oauth.OIDCProviderConfig{Name: name, Issuer: os.Getenv(envPrefix + "ISSUER")}
+		if provider.Issuer == "" && name == oauth.OIDCProviderGoogle {
+			provider.Issuer = oauth.GoogleIssuer
+		}
+
+		for _, clientID := range strings.Split(os.Getenv(envPrefix+"CLIENT_IDS"), ",") {
+			if clientID = strings.TrimSpace(clientID); clientID != "" {
+				provider.ClientIDs = append(provider.ClientIDs, clientID)
+			}
+		}
+
+		providers = append(providers, provider)
+	}
+
+	return providers
+}
+
+// fileStorageDirectory is where the attachments that are not images are kept, FILE_STORAGE_DIRECTORY
+// or uploads when it is not set. FILE_STORAGE_URL is the public URL of the files route serving it.
+func fileStorageDirectory() string {
+	if directory := os.Getenv("FILE_STORAGE_DIRECTORY"); directory != "" {
+		return directory
+	}
+
+	return "uploads"
 }
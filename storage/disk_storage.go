@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/jordyf15/thullo-api/models"
+)
+
+type diskStorage struct {
+	directory string
+	baseURL   string
+}
+
+// NewDiskStorage stores the files in directory and links to them under baseURL, which is where
+// the directory is served from. Unlike imgur it accepts any type of file.
+func NewDiskStorage(directory, baseURL string) Storage {
+	return &diskStorage{directory: directory, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+func (api *diskStorage) UploadFile(respond chan<- error, wg *sync.WaitGroup, currentImage *models.Image, file *os.File, metadata map[string]string) {
+	if wg != nil {
+		defer wg.Done()
+	}
+
+	// only the extension of the name is kept, the files are never stored under a name picked by the uploader
+	name, err := randomFileName(filepath.Ext(metadata["name"]))
+	if err != nil {
+		respond <- err
+		return
+	}
+
+	err = os.MkdirAll(api.directory, 0755)
+	if err != nil {
+		respond <- err
+		return
+	}
+
+	src, err := os.Open(file.Name())
+	if err != nil {
+		respond <- err
+		return
+	}
+	defer src.Close()
+
+	dst, err := os.OpenFile(filepath.Join(api.directory, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		respond <- err
+		return
+	}
+
+	_, err = io.Copy(dst, src)
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(dst.Name())
+		respond <- err
+		return
+	}
+
+	currentImage.ID = name
+	currentImage.URL = api.url(name)
+
+	respond <- nil
+}
+
+func (api *diskStorage) DeleteFile(respond chan<- error, wg *sync.WaitGroup, image *models.Image) {
+	if wg != nil {
+		defer wg.Done()
+	}
+
+	err := os.Remove(filepath.Join(api.directory, filepath.Base(image.ID)))
+	if err != nil && !os.IsNotExist(err) {
+		respond <- err
+		return
+	}
+
+	respond <- nil
+}
+
+func (api *diskStorage) AssignImageURLToUser(user *models.User) error {
+	for _, image := range user.Images {
+		image.URL = api.url(image.ID)
+	}
+
+	return nil
+}
+
+func (api *diskStorage) AssignImageURLToBoardCover(cover *models.BoardCover) error {
+	for _, image := range cover.Images {
+		image.URL = api.url(image.ID)
+	}
+
+	return nil
+}
+
+// randomFileName returns a name made of 128 random bits, the files are served without
+// authentication so the name is the only thing that keeps others from finding them
+func randomFileName(extension string) (string, error) {
+	name := make([]byte, 16)
+	_, err := rand.Read(name)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(name) + extension, nil
+}
+
+func (api *diskStorage) url(name string) string {
+	return fmt.Sprintf("%s/%s", api.baseURL, name)
+}
@@ -0,0 +1,96 @@
+package storage_test
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestDiskStorage(t *testing.T) {
+	suite.Run(t, new(diskStorageSuite))
+}
+
+type diskStorageSuite struct {
+	suite.Suite
+	directory string
+	storage   storage.Storage
+}
+
+func (s *diskStorageSuite) SetupTest() {
+	s.directory = s.T().TempDir()
+	s.storage = storage.NewDiskStorage(filepath.Join(s.directory, "files"), "https://api.thullo.com/files/")
+}
+
+func (s *diskStorageSuite) upload(content, name string) (*models.Image, error) {
+	file, err := os.CreateTemp(s.directory, "upload-*")
+	s.Require().NoError(err)
+	defer file.Close()
+
+	_, err = file.WriteString(content)
+	s.Require().NoError(err)
+
+	image := &models.Image{}
+	respond := make(chan error, 1)
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	s.storage.UploadFile(respond, &wg, image, file, map[string]string{"name": name})
+	wg.Wait()
+
+	return image, <-respond
+}
+
+func (s *diskStorageSuite) TestUploadFile() {
+	image, err := s.upload("%PDF-1.4", "../../report.pdf")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), ".pdf", filepath.Ext(image.ID))
+	// the name is 128 random bits in hex so it can not be guessed from the other uploads
+	assert.Regexp(s.T(), "^[0-9a-f]{32}\\.pdf$", image.ID)
+	assert.Equal(s.T(), "https://api.thullo.com/files/"+image.ID, image.URL)
+
+	content, err := os.ReadFile(filepath.Join(s.directory, "files", image.ID))
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "%PDF-1.4", string(content))
+}
+
+func (s *diskStorageSuite) TestUploadFileNamesAreUnrelated() {
+	image1, err := s.upload("first", "notes.txt")
+	s.Require().NoError(err)
+
+	image2, err := s.upload("second", "notes.txt")
+	s.Require().NoError(err)
+
+	assert.NotEqual(s.T(), image1.ID, image2.ID)
+	// object ids made right after each other share their timestamp, random names do not share a prefix
+	assert.NotEqual(s.T(), image1.ID[:8], image2.ID[:8])
+}
+
+func (s *diskStorageSuite) TestDeleteFile() {
+	image, err := s.upload("notes", "notes.txt")
+	s.Require().NoError(err)
+
+	respond := make(chan error, 2)
+	s.storage.DeleteFile(respond, nil, image)
+	// deleting a file that is already gone is not an error
+	s.storage.DeleteFile(respond, nil, image)
+
+	assert.NoError(s.T(), <-respond)
+	assert.NoError(s.T(), <-respond)
+	assert.NoFileExists(s.T(), filepath.Join(s.directory, "files", image.ID))
+}
+
+func (s *diskStorageSuite) TestAssignImageURLToBoardCover() {
+	cover := &models.BoardCover{Images: models.Images{{ID: "file.zip"}}}
+
+	err := s.storage.AssignImageURLToBoardCover(cover)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "https://api.thullo.com/files/file.zip", cover.Images[0].URL)
+}
@@ -120,6 +120,41 @@ func (api *imgurStorage) UploadFile(respond chan<- error, wg *sync.WaitGroup, cu
 	respond <- nil
 }
 
+func (api *imgurStorage) DeleteFile(respond chan<- error, wg *sync.WaitGroup, image *models.Image) {
+	if wg != nil {
+		defer wg.Done()
+	}
+
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/3/image/%s", baseURL, image.ID), nil)
+	if err != nil {
+		respond <- err
+		return
+	}
+	if api.accessToken == "" || api.accessTokenExpired < time.Now().Unix() {
+		api.accessToken, api.accessTokenExpired, err = api.getAccessToken()
+		if err != nil {
+			respond <- err
+			return
+		}
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", api.accessToken))
+
+	res, err := api.client.Do(req)
+	if err != nil {
+		respond <- err
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		respond <- custom_errors.ErrUnknownErrorOccured
+		return
+	}
+
+	respond <- nil
+}
+
 func (api *imgurStorage) AssignImageURLToUser(user *models.User) error {
 	var wg sync.WaitGroup
 	errorResults := make(chan error, len(user.Images))
@@ -141,6 +176,27 @@ func (api *imgurStorage) AssignImageURLToUser(user *models.User) error {
 	return nil
 }
 
+func (api *imgurStorage) AssignImageURLToBoardCover(cover *models.BoardCover) error {
+	var wg sync.WaitGroup
+	errorResults := make(chan error, len(cover.Images))
+
+	for _, image := range cover.Images {
+		wg.Add(1)
+		go api.getImage(errorResults, &wg, image)
+	}
+
+	wg.Wait()
+	close(errorResults)
+
+	for errorResult := range errorResults {
+		if errorResult != nil {
+			return custom_errors.ErrUnknownErrorOccured
+		}
+	}
+
+	return nil
+}
+
 func (api *imgurStorage) getImage(respond chan<- error, wg *sync.WaitGroup, currentImage *models.Image) {
 	if wg != nil {
 		defer wg.Done()
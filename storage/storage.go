@@ -9,5 +9,7 @@ import (
 
 type Storage interface {
 	UploadFile(respond chan<- error, wg *sync.WaitGroup, currentImage *models.Image, file *os.File, metadata map[string]string)
+	DeleteFile(respond chan<- error, wg *sync.WaitGroup, image *models.Image)
 	AssignImageURLToUser(*models.User) error
+	AssignImageURLToBoardCover(*models.BoardCover) error
 }
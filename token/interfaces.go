@@ -1,12 +1,25 @@
 package token
 
 import (
+	"time"
+
 	"github.com/jordyf15/thullo-api/models"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 const (
 	DefaultTokenLimitPerUser = 5
+
+	// PasswordResetTokenDuration is how long a password reset token can be used after it is sent
+	PasswordResetTokenDuration = time.Hour
+	// EmailVerificationTokenDuration is how long an email verification token can be used after it is sent
+	EmailVerificationTokenDuration = 24 * time.Hour
+)
+
+// purposes of the one time tokens that are emailed to users
+const (
+	OneTimeTokenPasswordReset     = "password_reset"
+	OneTimeTokenEmailVerification = "email_verification"
 )
 
 type Repository interface {
@@ -18,6 +31,11 @@ type Repository interface {
 	Update(tokenSet *models.TokenSet) error
 	Updates(tokenSet *models.TokenSet, changes map[string]interface{}) error
 	Delete(tokenSet *models.TokenSet) error
+	// DeleteAll removes every token set of the user which signs the user out everywhere
+	DeleteAll(userID primitive.ObjectID) error
+	SaveOneTimeToken(purpose, hashedToken string, userID primitive.ObjectID, duration time.Duration) error
+	// ConsumeOneTimeToken returns the user the token was made for and removes it so it can only be used once
+	ConsumeOneTimeToken(purpose, hashedToken string) (primitive.ObjectID, error)
 }
 
 type Usecase interface {
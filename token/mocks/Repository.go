@@ -7,6 +7,8 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
 )
 
 // Repository is an autogenerated mock type for the Repository type
@@ -14,6 +16,29 @@ type Repository struct {
 	mock.Mock
 }
 
+// ConsumeOneTimeToken provides a mock function with given fields: purpose, hashedToken
+func (_m *Repository) ConsumeOneTimeToken(purpose string, hashedToken string) (primitive.ObjectID, error) {
+	ret := _m.Called(purpose, hashedToken)
+
+	var r0 primitive.ObjectID
+	if rf, ok := ret.Get(0).(func(string, string) primitive.ObjectID); ok {
+		r0 = rf(purpose, hashedToken)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(primitive.ObjectID)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(purpose, hashedToken)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: tokenSet
 func (_m *Repository) Create(tokenSet *models.TokenSet) error {
 	ret := _m.Called(tokenSet)
@@ -42,6 +67,20 @@ func (_m *Repository) Delete(tokenSet *models.TokenSet) error {
 	return r0
 }
 
+// DeleteAll provides a mock function with given fields: userID
+func (_m *Repository) DeleteAll(userID primitive.ObjectID) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Exists provides a mock function with given fields: accessToken
 func (_m *Repository) Exists(accessToken *models.AccessToken) bool {
 	ret := _m.Called(accessToken)
@@ -107,6 +146,20 @@ func (_m *Repository) Save(accessToken *models.AccessToken) error {
 	return r0
 }
 
+// SaveOneTimeToken provides a mock function with given fields: purpose, hashedToken, userID, duration
+func (_m *Repository) SaveOneTimeToken(purpose string, hashedToken string, userID primitive.ObjectID, duration time.Duration) error {
+	ret := _m.Called(purpose, hashedToken, userID, duration)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, primitive.ObjectID, time.Duration) error); ok {
+		r0 = rf(purpose, hashedToken, userID, duration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Update provides a mock function with given fields: tokenSet
 func (_m *Repository) Update(tokenSet *models.TokenSet) error {
 	ret := _m.Called(tokenSet)
@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v9"
+	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/models"
 	"github.com/jordyf15/thullo-api/token"
 	"github.com/jordyf15/thullo-api/utils"
@@ -16,6 +18,7 @@ import (
 
 const (
 	RedisKeyFreshAccessTokens = "fresh-access-tokens"
+	RedisKeyOneTimeTokens     = "one-time-tokens"
 	contextTimeout            = time.Second * 30
 )
 
@@ -138,3 +141,50 @@ func (repo *tokenRepository) Delete(tokenSet *models.TokenSet) error {
 
 	return errors.New("Refresh token ID is empty")
 }
+
+func (repo *tokenRepository) DeleteAll(userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	filter := bson.D{{Key: "user_id", Value: userID}}
+	_, err := repo.db.DeleteMany(ctx, filter)
+
+	return err
+}
+
+func (repo *tokenRepository) SaveOneTimeToken(purpose, hashedToken string, userID primitive.ObjectID, duration time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	return repo.redis.Set(ctx, oneTimeTokenKey(purpose, hashedToken), userID.Hex(), duration).Err()
+}
+
+func (repo *tokenRepository) ConsumeOneTimeToken(purpose, hashedToken string) (primitive.ObjectID, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	// getting and deleting the token in one transaction keeps two requests from both using it
+	key := oneTimeTokenKey(purpose, hashedToken)
+	var get *redis.StringCmd
+	_, err := repo.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		get = pipe.Get(ctx, key)
+		pipe.Del(ctx, key)
+		return nil
+	})
+	if err != nil && err != redis.Nil {
+		return primitive.NilObjectID, err
+	}
+
+	userIDHex, err := get.Result()
+	if err == redis.Nil {
+		return primitive.NilObjectID, custom_errors.ErrRecordNotFound
+	} else if err != nil {
+		return primitive.NilObjectID, err
+	}
+
+	return primitive.ObjectIDFromHex(userIDHex)
+}
+
+func oneTimeTokenKey(purpose, hashedToken string) string {
+	return fmt.Sprintf("%s:%s:%s", RedisKeyOneTimeTokens, purpose, hashedToken)
+}
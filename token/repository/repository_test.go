@@ -8,6 +8,7 @@ import (
 	"github.com/alicebob/miniredis"
 	"github.com/go-redis/redis/v9"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/jordyf15/thullo-api/custom_errors"
 	"github.com/jordyf15/thullo-api/models"
 	"github.com/jordyf15/thullo-api/token"
 	"github.com/jordyf15/thullo-api/token/repository"
@@ -302,3 +303,36 @@ func (s *tokenRepositorySuite) TestRemove() {
 	accessTokenJson := s.redis.ZRange(context.TODO(), repository.RedisKeyFreshAccessTokens, 0, -1).Val()
 	assert.Len(s.T(), accessTokenJson, 0)
 }
+
+func (s *tokenRepositorySuite) TestDeleteAll() {
+	err := s.repository.DeleteAll(userID1)
+	assert.NoError(s.T(), err)
+
+	count, err := s.collection.CountDocuments(context.TODO(), bson.D{{Key: "user_id", Value: userID1}})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(0), count)
+
+	count, err = s.collection.CountDocuments(context.TODO(), bson.D{{Key: "user_id", Value: userID2}})
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), int64(1), count)
+}
+
+func (s *tokenRepositorySuite) TestConsumeOneTimeTokenOnlyOnce() {
+	err := s.repository.SaveOneTimeToken(token.OneTimeTokenPasswordReset, "hashedToken", userID1, time.Minute)
+	assert.NoError(s.T(), err)
+
+	foundUserID, err := s.repository.ConsumeOneTimeToken(token.OneTimeTokenPasswordReset, "hashedToken")
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), userID1, foundUserID)
+
+	_, err = s.repository.ConsumeOneTimeToken(token.OneTimeTokenPasswordReset, "hashedToken")
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound, err)
+}
+
+func (s *tokenRepositorySuite) TestConsumeOneTimeTokenOfOtherPurpose() {
+	err := s.repository.SaveOneTimeToken(token.OneTimeTokenPasswordReset, "hashedToken", userID1, time.Minute)
+	assert.NoError(s.T(), err)
+
+	_, err = s.repository.ConsumeOneTimeToken("other_purpose", "hashedToken")
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound, err)
+}
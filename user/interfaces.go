@@ -6,6 +6,11 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+const (
+	// MaxDisplayPictureSize is the largest image in bytes that can be uploaded as a display picture
+	MaxDisplayPictureSize = 5 << 20
+)
+
 var (
 	DisplayPictureSizes = []uint{100, 400}
 )
@@ -14,14 +19,30 @@ type Repository interface {
 	Create(user *models.User) error
 	FieldExists(key string, value string) (bool, error)
 	GetByEmail(email string) (*models.User, error)
+	GetByUsername(username string) (*models.User, error)
 	GetByID(userID primitive.ObjectID) (*models.User, error)
+	Update(user *models.User) error
+	UpdatePassword(user *models.User) error
+	SetEmailVerified(userID primitive.ObjectID) error
+	// VerifyExistingEmails marks the users that were created before email addresses had to be verified as verified
+	VerifyExistingEmails() (int64, error)
 }
 
 type Usecase interface {
 	Create(user *models.User, imageFile utils.NamedFileReader) (map[string]interface{}, error)
 	For(user *models.User) InstanceUsecase
-	LoginWithGoogle(token string) (map[string]interface{}, error)
+	LoginWithOIDC(provider, idToken, nonce string) (map[string]interface{}, error)
+	LoginWithGithub(code string) (map[string]interface{}, error)
 	Login(email, password string) (map[string]interface{}, error)
+	GetByID(userID primitive.ObjectID) (*models.User, error)
+	GetProfile(userID primitive.ObjectID) (*models.UserProfile, error)
+	UpdateProfile(userID primitive.ObjectID, fields map[string]string) error
+	UpdateDisplayPicture(userID primitive.ObjectID, imageFile utils.NamedFileReader) error
+	ChangePassword(userID primitive.ObjectID, currentPassword, newPassword string) error
+	ForgotPassword(email string) error
+	ResetPassword(resetToken, newPassword string) error
+	SendVerificationEmail(userID primitive.ObjectID) error
+	VerifyEmail(verificationToken string) error
 }
 
 type InstanceUsecase interface {
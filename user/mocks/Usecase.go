@@ -6,6 +6,8 @@ import (
 	models "github.com/jordyf15/thullo-api/models"
 	mock "github.com/stretchr/testify/mock"
 
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
 	user "github.com/jordyf15/thullo-api/user"
 
 	utils "github.com/jordyf15/thullo-api/utils"
@@ -16,6 +18,20 @@ type Usecase struct {
 	mock.Mock
 }
 
+// ChangePassword provides a mock function with given fields: userID, currentPassword, newPassword
+func (_m *Usecase) ChangePassword(userID primitive.ObjectID, currentPassword string, newPassword string) error {
+	ret := _m.Called(userID, currentPassword, newPassword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, string, string) error); ok {
+		r0 = rf(userID, currentPassword, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: _a0, imageFile
 func (_m *Usecase) Create(_a0 *models.User, imageFile utils.NamedFileReader) (map[string]interface{}, error) {
 	ret := _m.Called(_a0, imageFile)
@@ -55,6 +71,66 @@ func (_m *Usecase) For(_a0 *models.User) user.InstanceUsecase {
 	return r0
 }
 
+// ForgotPassword provides a mock function with given fields: email
+func (_m *Usecase) ForgotPassword(email string) error {
+	ret := _m.Called(email)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(email)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetByID provides a mock function with given fields: userID
+func (_m *Usecase) GetByID(userID primitive.ObjectID) (*models.User, error) {
+	ret := _m.Called(userID)
+
+	var r0 *models.User
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *models.User); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetProfile provides a mock function with given fields: userID
+func (_m *Usecase) GetProfile(userID primitive.ObjectID) (*models.UserProfile, error) {
+	ret := _m.Called(userID)
+
+	var r0 *models.UserProfile
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *models.UserProfile); ok {
+		r0 = rf(userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.UserProfile)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Login provides a mock function with given fields: email, password
 func (_m *Usecase) Login(email string, password string) (map[string]interface{}, error) {
 	ret := _m.Called(email, password)
@@ -78,13 +154,13 @@ func (_m *Usecase) Login(email string, password string) (map[string]interface{},
 	return r0, r1
 }
 
-// LoginWithGoogle provides a mock function with given fields: token
-func (_m *Usecase) LoginWithGoogle(token string) (map[string]interface{}, error) {
-	ret := _m.Called(token)
+// LoginWithGithub provides a mock function with given fields: code
+func (_m *Usecase) LoginWithGithub(code string) (map[string]interface{}, error) {
+	ret := _m.Called(code)
 
 	var r0 map[string]interface{}
 	if rf, ok := ret.Get(0).(func(string) map[string]interface{}); ok {
-		r0 = rf(token)
+		r0 = rf(code)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(map[string]interface{})
@@ -93,7 +169,30 @@ func (_m *Usecase) LoginWithGoogle(token string) (map[string]interface{}, error)
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(token)
+		r1 = rf(code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LoginWithOIDC provides a mock function with given fields: provider, idToken, nonce
+func (_m *Usecase) LoginWithOIDC(provider string, idToken string, nonce string) (map[string]interface{}, error) {
+	ret := _m.Called(provider, idToken, nonce)
+
+	var r0 map[string]interface{}
+	if rf, ok := ret.Get(0).(func(string, string, string) map[string]interface{}); ok {
+		r0 = rf(provider, idToken, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
+		r1 = rf(provider, idToken, nonce)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -101,6 +200,76 @@ func (_m *Usecase) LoginWithGoogle(token string) (map[string]interface{}, error)
 	return r0, r1
 }
 
+// ResetPassword provides a mock function with given fields: resetToken, newPassword
+func (_m *Usecase) ResetPassword(resetToken string, newPassword string) error {
+	ret := _m.Called(resetToken, newPassword)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(resetToken, newPassword)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SendVerificationEmail provides a mock function with given fields: userID
+func (_m *Usecase) SendVerificationEmail(userID primitive.ObjectID) error {
+	ret := _m.Called(userID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
+		r0 = rf(userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDisplayPicture provides a mock function with given fields: userID, imageFile
+func (_m *Usecase) UpdateDisplayPicture(userID primitive.ObjectID, imageFile utils.NamedFileReader) error {
+	ret := _m.Called(userID, imageFile)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, utils.NamedFileReader) error); ok {
+		r0 = rf(userID, imageFile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProfile provides a mock function with given fields: userID, fields
+func (_m *Usecase) UpdateProfile(userID primitive.ObjectID, fields map[string]string) error {
+	ret := _m.Called(userID, fields)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, map[string]string) error); ok {
+		r0 = rf(userID, fields)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyEmail provides a mock function with given fields: verificationToken
+func (_m *Usecase) VerifyEmail(verificationToken string) error {
+	ret := _m.Called(verificationToken)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(verificationToken)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 type mockConstructorTestingTNewUsecase interface {
 	mock.TestingT
 	Cleanup(func())
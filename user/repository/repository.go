@@ -67,6 +67,20 @@ func (repo *userRepository) GetByEmail(email string) (*models.User, error) {
 
 }
 
+func (repo *userRepository) GetByUsername(username string) (*models.User, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	filter := bson.D{
+		{Key: "username", Value: username},
+	}
+
+	foundUser := &models.User{}
+	err := repo.db.FindOne(ctx, filter).Decode(foundUser)
+
+	return foundUser, err
+}
+
 func (repo *userRepository) GetByID(userID primitive.ObjectID) (*models.User, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 	defer cancel()
@@ -80,3 +94,81 @@ func (repo *userRepository) GetByID(userID primitive.ObjectID) (*models.User, er
 
 	return foundUser, err
 }
+
+// Update only writes the profile fields of the user, the email address and password are left as they are
+func (repo *userRepository) Update(user *models.User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	user.UpdatedAt = time.Now()
+	data := utils.ToBSON(user)
+
+	updates := bson.D{
+		{
+			Key: "$set", Value: bson.D{
+				{Key: "updated_at", Value: user.UpdatedAt},
+				{Key: "username", Value: user.Username},
+				{Key: "name", Value: user.Name},
+				{Key: "bio", Value: user.Bio},
+				{Key: "images", Value: data["images"]},
+			},
+		},
+	}
+
+	_, err := repo.db.UpdateByID(ctx, user.ID, updates)
+
+	return err
+}
+
+func (repo *userRepository) UpdatePassword(user *models.User) error {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	user.UpdatedAt = time.Now()
+
+	updates := bson.D{
+		{
+			Key: "$set", Value: bson.D{
+				{Key: "updated_at", Value: user.UpdatedAt},
+				{Key: "encrypted_password", Value: user.EncryptedPassword},
+			},
+		},
+	}
+
+	_, err := repo.db.UpdateByID(ctx, user.ID, updates)
+
+	return err
+}
+
+func (repo *userRepository) SetEmailVerified(userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	updates := bson.D{
+		{
+			Key: "$set", Value: bson.D{
+				{Key: "updated_at", Value: time.Now()},
+				{Key: "email_verified", Value: true},
+			},
+		},
+	}
+
+	_, err := repo.db.UpdateByID(ctx, userID, updates)
+
+	return err
+}
+
+func (repo *userRepository) VerifyExistingEmails() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
+	defer cancel()
+
+	filter := bson.D{{Key: "email_verified", Value: bson.D{{Key: "$exists", Value: false}}}}
+	updates := bson.D{{Key: "$set", Value: bson.D{{Key: "email_verified", Value: true}}}}
+
+	result, err := repo.db.UpdateMany(ctx, filter, updates)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
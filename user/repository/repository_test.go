@@ -113,3 +113,70 @@ func (s *userRepositorySuite) TestGetByID() {
 	assert.Equal(s.T(), user1.Name, user.Name)
 	assert.Equal(s.T(), user1.Bio, user.Bio)
 }
+
+func (s *userRepositorySuite) TestUpdate() {
+	user := &models.User{
+		ID:                userID1,
+		Email:             "changed@gmail.com",
+		EncryptedPassword: "changedPassword",
+		Username:          "updateduser",
+		Name:              "updated user",
+		Bio:               "an updated user",
+		Images:            models.Images{{ID: "image1", Width: 100}},
+	}
+
+	err := s.repository.Update(user)
+	assert.NoError(s.T(), err)
+
+	var foundUser models.User
+	err = s.collection.FindOne(context.TODO(), bson.M{"_id": userID1}).Decode(&foundUser)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), user.Username, foundUser.Username)
+	assert.Equal(s.T(), user.Name, foundUser.Name)
+	assert.Equal(s.T(), user.Bio, foundUser.Bio)
+	assert.Len(s.T(), foundUser.Images, 1)
+	assert.Equal(s.T(), "image1", foundUser.Images[0].ID)
+	assert.Equal(s.T(), user1.Email, foundUser.Email)
+	assert.Equal(s.T(), user1.EncryptedPassword, foundUser.EncryptedPassword)
+}
+
+func (s *userRepositorySuite) TestUpdatePassword() {
+	user := &models.User{
+		ID:                userID1,
+		EncryptedPassword: "newHashedPassword",
+		Name:              "changed name",
+	}
+
+	err := s.repository.UpdatePassword(user)
+	assert.NoError(s.T(), err)
+
+	var foundUser models.User
+	err = s.collection.FindOne(context.TODO(), bson.M{"_id": userID1}).Decode(&foundUser)
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "newHashedPassword", foundUser.EncryptedPassword)
+	assert.Equal(s.T(), user1.Name, foundUser.Name)
+}
+
+func (s *userRepositorySuite) TestSetEmailVerified() {
+	err := s.repository.SetEmailVerified(userID1)
+	assert.NoError(s.T(), err)
+
+	var foundUser models.User
+	err = s.collection.FindOne(context.TODO(), bson.M{"_id": userID1}).Decode(&foundUser)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), foundUser.EmailVerified)
+}
+
+func (s *userRepositorySuite) TestVerifyExistingEmails() {
+	s.collection.InsertOne(context.TODO(), bson.M{"_id": primitive.NewObjectID(), "email": "legacy@gmail.com", "username": "legacy"})
+
+	count, err := s.repository.VerifyExistingEmails()
+	assert.NoError(s.T(), err)
+	// user1 already has the field, even though it is false
+	assert.Equal(s.T(), int64(1), count)
+
+	var foundUser models.User
+	err = s.collection.FindOne(context.TODO(), bson.M{"username": "legacy"}).Decode(&foundUser)
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), foundUser.EmailVerified)
+}
@@ -1,7 +1,10 @@
 package usecase
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/hex"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -13,12 +16,14 @@ import (
 	"time"
 
 	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/mail"
 	"github.com/jordyf15/thullo-api/models"
 	"github.com/jordyf15/thullo-api/oauth"
 	"github.com/jordyf15/thullo-api/storage"
 	"github.com/jordyf15/thullo-api/token"
 	"github.com/jordyf15/thullo-api/user"
 	"github.com/jordyf15/thullo-api/utils"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -28,11 +33,16 @@ var (
 	gidPictureSizeRegex     = regexp.MustCompile(`s\d+-c`)
 )
 
+const (
+	oneTimeTokenLength = 32
+)
+
 type userUsecase struct {
-	userRepo  user.Repository
-	tokenRepo token.Repository
-	oauthRepo oauth.Repository
-	storage   storage.Storage
+	userRepo   user.Repository
+	tokenRepo  token.Repository
+	oauthRepo  oauth.Repository
+	storage    storage.Storage
+	mailSender mail.Sender
 }
 
 type userInstanceUsecase struct {
@@ -40,8 +50,8 @@ type userInstanceUsecase struct {
 	userUsecase
 }
 
-func NewUserUsecase(userRepo user.Repository, tokenRepo token.Repository, oauthRepo oauth.Repository, storage storage.Storage) user.Usecase {
-	return &userUsecase{userRepo: userRepo, tokenRepo: tokenRepo, oauthRepo: oauthRepo, storage: storage}
+func NewUserUsecase(userRepo user.Repository, tokenRepo token.Repository, oauthRepo oauth.Repository, storage storage.Storage, mailSender mail.Sender) user.Usecase {
+	return &userUsecase{userRepo: userRepo, tokenRepo: tokenRepo, oauthRepo: oauthRepo, storage: storage, mailSender: mailSender}
 }
 
 func (usecase *userUsecase) Create(_user *models.User, imageFile utils.NamedFileReader) (map[string]interface{}, error) {
@@ -148,6 +158,13 @@ func (usecase *userUsecase) Create(_user *models.User, imageFile utils.NamedFile
 		return nil, err
 	}
 
+	// the user can ask for another verification email so failing to send this one does not fail the signup
+	if !_user.EmailVerified {
+		if err := usecase.sendVerificationEmail(_user); err != nil {
+			fmt.Println(err)
+		}
+	}
+
 	accessToken, refreshToken, _ := usecase.For(_user).GenerateTokens()
 
 	_user.EmptyImageIDs()
@@ -161,18 +178,91 @@ func (usecase *userUsecase) Create(_user *models.User, imageFile utils.NamedFile
 	return response, nil
 }
 
-func (usecase *userUsecase) LoginWithGoogle(token string) (map[string]interface{}, error) {
-	tokenInfo, err := usecase.oauthRepo.GetGoogleTokenInfo(token)
+func (usecase *userUsecase) LoginWithOIDC(provider, idToken, nonce string) (map[string]interface{}, error) {
+	claims, err := usecase.oauthRepo.VerifyIDToken(provider, idToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	name := claims.Name
+	if len(strings.TrimSpace(name)) == 0 {
+		name = claims.PreferredUsername
+	}
+
+	username := claims.PreferredUsername
+	if len(username) == 0 {
+		username = name
+	}
+
+	profile := &oauthProfile{
+		email:         claims.Email,
+		emailVerified: claims.EmailVerified,
+		name:          name,
+		username:      username,
+		pictureURL:    claims.Picture,
+	}
+
+	if provider == oauth.OIDCProviderGoogle && len(claims.Picture) > 0 {
+		profile.pictureURL = gidPictureSizeRegex.ReplaceAllString(claims.Picture, "s800-c")
+	}
+
+	return usecase.loginWithOauthProfile(profile)
+}
+
+func (usecase *userUsecase) LoginWithGithub(code string) (map[string]interface{}, error) {
+	userInfo, err := usecase.oauthRepo.GetGithubUserInfo(code)
 	if err != nil {
 		return nil, err
 	}
 
-	if tokenInfo.ExpiresAt < time.Now().Unix() {
-		return nil, custom_errors.ErrGoogleOauthTokenExpired
+	// the name is optional on github while the login always exists
+	name := userInfo.Name
+	if len(strings.TrimSpace(name)) == 0 {
+		name = userInfo.Login
 	}
 
-	user, err := usecase.userRepo.GetByEmail(tokenInfo.Email)
+	profile := &oauthProfile{
+		email: userInfo.Email,
+		// the repository only returns the primary email address when github has verified it
+		emailVerified: true,
+		name:          name,
+		username:      userInfo.Login,
+		pictureURL:    userInfo.AvatarURL,
+	}
+
+	return usecase.loginWithOauthProfile(profile)
+}
+
+// oauthProfile is what an oauth provider tells about the user that is logging in
+type oauthProfile struct {
+	email         string
+	emailVerified bool
+	name          string
+	// username is what the username of a new user is made from
+	username   string
+	pictureURL string
+}
+
+// loginWithOauthProfile logs in the user with the email address of the profile,
+// the user is created from the profile when there is no user with the email address yet
+func (usecase *userUsecase) loginWithOauthProfile(profile *oauthProfile) (map[string]interface{}, error) {
+	user, err := usecase.userRepo.GetByEmail(profile.email)
 	if err == nil {
+		// anyone can claim an address the provider has not verified, so it must not open an existing account
+		if !profile.emailVerified {
+			return nil, custom_errors.ErrOauthEmailNotVerified
+		}
+
+		// the provider has already verified the email address so there is no need to send a verification email
+		if !user.EmailVerified {
+			err = usecase.userRepo.SetEmailVerified(user.ID)
+			if err != nil {
+				return nil, err
+			}
+
+			user.EmailVerified = true
+		}
+
 		accessToken, refreshToken, err := usecase.For(user).GenerateTokens()
 		if err != nil {
 			return nil, err
@@ -200,8 +290,8 @@ func (usecase *userUsecase) LoginWithGoogle(token string) (map[string]interface{
 	password := "0.Aa" + utils.RandString(8)
 
 	var username string
-	if len(tokenInfo.Name) >= models.MinUsernameLength {
-		username = strings.ToLower(regex.ReplaceAllString(tokenInfo.Name, ""))
+	if len(profile.username) >= models.MinUsernameLength {
+		username = strings.ToLower(regex.ReplaceAllString(profile.username, ""))
 	} else {
 		username = fmt.Sprintf("User %d", rand.Intn(10000))
 		username = strings.ToLower(regex.ReplaceAllString(username, ""))
@@ -224,18 +314,17 @@ func (usecase *userUsecase) LoginWithGoogle(token string) (map[string]interface{
 		}
 	}
 
-	user = &models.User{Name: tokenInfo.Name, Username: username, Email: tokenInfo.Email, Password: password}
+	user = &models.User{Name: profile.name, Username: username, Email: profile.email, EmailVerified: profile.emailVerified, Password: password}
 
-	if len(tokenInfo.Picture) > 0 {
-		tmpFile, err := ioutil.TempFile(os.TempDir(), "googleimg-")
+	if len(profile.pictureURL) > 0 {
+		tmpFile, err := ioutil.TempFile(os.TempDir(), "oauthimg-")
 		if err != nil {
 			return usecase.Create(user, nil)
 		}
 
 		defer os.Remove(tmpFile.Name())
 
-		pictureURL := gidPictureSizeRegex.ReplaceAllString(tokenInfo.Picture, "s800-c")
-		respHeader, err := utils.DownloadFile(tmpFile.Name(), pictureURL)
+		respHeader, err := utils.DownloadFile(tmpFile.Name(), profile.pictureURL)
 		if err != nil {
 			return usecase.Create(user, nil)
 		}
@@ -251,7 +340,7 @@ func (usecase *userUsecase) LoginWithGoogle(token string) (map[string]interface{
 				split := strings.Split(contentType, "/")
 				filename = "a." + split[len(split)-1]
 			} else {
-				filename = filepath.Base(tokenInfo.Picture)
+				filename = filepath.Base(profile.pictureURL)
 			}
 		}
 
@@ -302,6 +391,318 @@ func (usecase *userUsecase) Login(email, password string) (map[string]interface{
 	return response, nil
 }
 
+func (usecase *userUsecase) GetByID(userID primitive.ObjectID) (*models.User, error) {
+	user, err := usecase.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = usecase.storage.AssignImageURLToUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	user.EmptyImageIDs()
+
+	return user, nil
+}
+
+func (usecase *userUsecase) GetProfile(userID primitive.ObjectID) (*models.UserProfile, error) {
+	user, err := usecase.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user.Profile(), nil
+}
+
+// UpdateProfile changes the name, username and bio found in fields, the rest of the user
+// is validated again along with them so the user is never saved in an invalid state
+func (usecase *userUsecase) UpdateProfile(userID primitive.ObjectID, fields map[string]string) error {
+	_user, err := usecase.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	currentUsername := _user.Username
+
+	if name, ok := fields["name"]; ok {
+		_user.Name = name
+	}
+
+	if username, ok := fields["username"]; ok {
+		_user.Username = strings.TrimSpace(username)
+	}
+
+	if bio, ok := fields["bio"]; ok {
+		_user.Bio = bio
+	}
+
+	errors := make([]error, 0)
+
+	validateFieldErrors := _user.VerifyFields()
+	if len(validateFieldErrors) > 0 {
+		errors = append(errors, validateFieldErrors...)
+	}
+
+	if _user.Username != currentUsername {
+		isUsernameExist, err := usecase.userRepo.FieldExists("username", _user.Username)
+		if err != nil {
+			return err
+		}
+		if isUsernameExist {
+			errors = append(errors, custom_errors.ErrUsernameAlreadyExists)
+		}
+	}
+
+	if len(errors) > 0 {
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	return usecase.userRepo.Update(_user)
+}
+
+// UpdateDisplayPicture uploads the image in every display picture size and replaces the
+// current display pictures of the user with them, the replaced images are then removed from the storage
+func (usecase *userUsecase) UpdateDisplayPicture(userID primitive.ObjectID, imageFile utils.NamedFileReader) error {
+	switch utils.GetFileExtension(imageFile.Name()) {
+	case "jpg", "jpeg", "png":
+	default:
+		return custom_errors.ErrImageFormatInvalid
+	}
+
+	size, err := imageFile.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+
+	if size > user.MaxDisplayPictureSize {
+		return custom_errors.ErrImageSizeTooLarge
+	}
+
+	_user, err := usecase.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	images := make(models.Images, len(user.DisplayPictureSizes))
+	resizedImageFiles := make([]*os.File, len(user.DisplayPictureSizes))
+	for i, width := range user.DisplayPictureSizes {
+		resizedImageFile, err := utils.ResizeImage(imageFile, int(width))
+		if resizedImageFile != nil {
+			defer os.Remove(resizedImageFile.Name())
+		}
+		if err != nil {
+			return err
+		}
+
+		images[i] = &models.Image{Width: width}
+		resizedImageFiles[i] = resizedImageFile
+	}
+
+	var wg sync.WaitGroup
+	uploadChannels := make(chan error, len(images))
+	wg.Add(len(images))
+
+	for i, image := range images {
+		name := utils.RandString(8)
+		fileName := fmt.Sprintf("%s.%s", name, utils.GetFileExtension(resizedImageFiles[i].Name()))
+
+		metaData := map[string]string{
+			"name":        fileName,
+			"title":       name,
+			"description": fmt.Sprintf("profile picture of %s with width and height of %v", _user.Username, image.Width),
+		}
+
+		go usecase.storage.UploadFile(uploadChannels, &wg, image, resizedImageFiles[i], metaData)
+	}
+
+	wg.Wait()
+	close(uploadChannels)
+
+	errors := []error{}
+	for err = range uploadChannels {
+		if err != nil {
+			errors = append(errors, err)
+		}
+	}
+
+	if len(errors) > 0 {
+		// the sizes that were uploaded before the others failed are not referenced anywhere
+		usecase.deleteDisplayPictures(images)
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	previousImages := _user.Images
+	_user.Images = images
+
+	err = usecase.userRepo.Update(_user)
+	if err != nil {
+		usecase.deleteDisplayPictures(images)
+		return err
+	}
+
+	usecase.deleteDisplayPictures(previousImages)
+
+	return nil
+}
+
+// deleteDisplayPictures removes the images from the storage, failures are only logged
+// since the images are no longer referenced by the user. Images that were never uploaded are skipped.
+func (usecase *userUsecase) deleteDisplayPictures(images models.Images) {
+	uploadedImages := models.Images{}
+	for _, image := range images {
+		if image != nil && image.ID != "" {
+			uploadedImages = append(uploadedImages, image)
+		}
+	}
+
+	var wg sync.WaitGroup
+	deleteChannels := make(chan error, len(uploadedImages))
+	wg.Add(len(uploadedImages))
+
+	for _, image := range uploadedImages {
+		go usecase.storage.DeleteFile(deleteChannels, &wg, image)
+	}
+
+	wg.Wait()
+	close(deleteChannels)
+
+	for err := range deleteChannels {
+		if err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+func (usecase *userUsecase) ChangePassword(userID primitive.ObjectID, currentPassword, newPassword string) error {
+	_user, err := usecase.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(_user.EncryptedPassword), []byte(currentPassword))
+	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return custom_errors.ErrCurrentPasswordWrong
+		}
+		return err
+	}
+
+	err = _user.SetPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	return usecase.userRepo.UpdatePassword(_user)
+}
+
+// ForgotPassword emails a password reset token to the user with the email address. Unknown email
+// addresses are not reported so the response does not reveal which addresses are registered.
+func (usecase *userUsecase) ForgotPassword(email string) error {
+	_user, err := usecase.userRepo.GetByEmail(strings.TrimSpace(email))
+	if err == mongo.ErrNoDocuments {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	resetToken, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	// only the hash is stored so the tokens can not be used by someone who can read redis
+	err = usecase.tokenRepo.SaveOneTimeToken(token.OneTimeTokenPasswordReset, utils.ToSHA256(resetToken), _user.ID, token.PasswordResetTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\n"+
+		"We received a request to reset the password of your Thullo account. "+
+		"Use the link below to choose a new password, it expires in %v.\n\n"+
+		"%s?token=%s\n\n"+
+		"If you did not request a password reset you can ignore this email.",
+		_user.Name, token.PasswordResetTokenDuration, os.Getenv("PASSWORD_RESET_URL"), resetToken)
+
+	return usecase.mailSender.Send(_user.Email, "Reset your Thullo password", body)
+}
+
+// ResetPassword sets the new password of the user the reset token was sent to and
+// signs the user out everywhere since the old password may have been compromised
+func (usecase *userUsecase) ResetPassword(resetToken, newPassword string) error {
+	// the password is checked first so a rejected password does not use up the token
+	passwordHolder := &models.User{}
+	err := passwordHolder.SetPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	userID, err := usecase.tokenRepo.ConsumeOneTimeToken(token.OneTimeTokenPasswordReset, utils.ToSHA256(resetToken))
+	if err == custom_errors.ErrRecordNotFound {
+		return custom_errors.ErrPasswordResetTokenInvalid
+	} else if err != nil {
+		return err
+	}
+
+	_user, err := usecase.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	_user.EncryptedPassword = passwordHolder.EncryptedPassword
+	err = usecase.userRepo.UpdatePassword(_user)
+	if err != nil {
+		return err
+	}
+
+	return usecase.tokenRepo.DeleteAll(_user.ID)
+}
+
+func (usecase *userUsecase) SendVerificationEmail(userID primitive.ObjectID) error {
+	_user, err := usecase.userRepo.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if _user.EmailVerified {
+		return custom_errors.ErrEmailAddressAlreadyVerified
+	}
+
+	return usecase.sendVerificationEmail(_user)
+}
+
+func (usecase *userUsecase) VerifyEmail(verificationToken string) error {
+	userID, err := usecase.tokenRepo.ConsumeOneTimeToken(token.OneTimeTokenEmailVerification, utils.ToSHA256(verificationToken))
+	if err == custom_errors.ErrRecordNotFound {
+		return custom_errors.ErrEmailVerificationTokenInvalid
+	} else if err != nil {
+		return err
+	}
+
+	return usecase.userRepo.SetEmailVerified(userID)
+}
+
+func (usecase *userUsecase) sendVerificationEmail(_user *models.User) error {
+	verificationToken, err := generateToken()
+	if err != nil {
+		return err
+	}
+
+	err = usecase.tokenRepo.SaveOneTimeToken(token.OneTimeTokenEmailVerification, utils.ToSHA256(verificationToken), _user.ID, token.EmailVerificationTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Hi %s,\n\n"+
+		"Please confirm that %s is your email address by opening the link below, it expires in %v.\n\n"+
+		"%s?token=%s\n\n"+
+		"If you did not sign up for Thullo you can ignore this email.",
+		_user.Name, _user.Email, token.EmailVerificationTokenDuration, os.Getenv("EMAIL_VERIFICATION_URL"), verificationToken)
+
+	return usecase.mailSender.Send(_user.Email, "Verify your Thullo email address", body)
+}
+
 // userInstanceUsecase
 func (usecase *userInstanceUsecase) GenerateTokens() (*models.AccessToken, *models.RefreshToken, error) {
 	refreshToken := (&models.RefreshToken{UserID: usecase.user.ID})
@@ -318,3 +719,14 @@ func (usecase *userInstanceUsecase) GenerateTokens() (*models.AccessToken, *mode
 
 	return accessToken, refreshToken, nil
 }
+
+func generateToken() (string, error) {
+	randomBytes := make([]byte, oneTimeTokenLength)
+
+	_, err := cryptorand.Read(randomBytes)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
@@ -1,21 +1,32 @@
 package usecase_test
 
 import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"sync"
 	"testing"
 
 	"github.com/jordyf15/thullo-api/custom_errors"
+	mr "github.com/jordyf15/thullo-api/mail/mocks"
 	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/oauth"
 	or "github.com/jordyf15/thullo-api/oauth/mocks"
 	sr "github.com/jordyf15/thullo-api/storage/mocks"
+	"github.com/jordyf15/thullo-api/token"
 	tr "github.com/jordyf15/thullo-api/token/mocks"
 	"github.com/jordyf15/thullo-api/user"
 	ur "github.com/jordyf15/thullo-api/user/mocks"
 	"github.com/jordyf15/thullo-api/user/usecase"
+	"github.com/jordyf15/thullo-api/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -31,6 +42,10 @@ type userUsecaseSuite struct {
 	tokenRepo *tr.Repository
 	oauthRepo *or.Repository
 	storage   *sr.Storage
+	mailer    *mr.Sender
+
+	updatedUser *models.User
+	deletedIDs  []string
 }
 
 func bcryptHash(str string) string {
@@ -61,6 +76,7 @@ func (s *userUsecaseSuite) SetupTest() {
 	s.userRepo = new(ur.Repository)
 	s.oauthRepo = new(or.Repository)
 	s.storage = new(sr.Storage)
+	s.mailer = new(mr.Sender)
 
 	fieldExists := func(key, value string) bool {
 		if key == "email" && value == "registered@gmail.com" {
@@ -74,9 +90,45 @@ func (s *userUsecaseSuite) SetupTest() {
 	}
 
 	s.userRepo.On("FieldExists", mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(fieldExists, nil)
-	s.userRepo.On("Create", mock.AnythingOfType("*models.User")).Return(nil)
-	s.userRepo.On("GetByEmail", mock.AnythingOfType("string")).Return(user1, nil)
+	s.userRepo.On("Create", mock.AnythingOfType("*models.User")).Run(func(args mock.Arguments) {
+		args[0].(*models.User).ID = primitive.NewObjectID()
+	}).Return(nil)
+	s.userRepo.On("GetByEmail", mock.AnythingOfType("string")).Return(func(email string) *models.User {
+		if email == user1.Email {
+			return user1
+		}
+
+		return nil
+	}, func(email string) error {
+		if email != user1.Email {
+			return mongo.ErrNoDocuments
+		}
+
+		return nil
+	})
+	// the usecase changes the user it gets so every call gets a fresh copy
+	s.userRepo.On("GetByID", mock.AnythingOfType("primitive.ObjectID")).Return(func(_ primitive.ObjectID) *models.User {
+		user := *user1
+		user.Images = models.Images{{ID: "oldimage1", Width: 100}, {ID: "oldimage2", Width: 400}}
+		return &user
+	}, nil)
+	s.updatedUser = nil
+	s.userRepo.On("Update", mock.AnythingOfType("*models.User")).Run(func(args mock.Arguments) {
+		s.updatedUser = args[0].(*models.User)
+	}).Return(nil)
 	s.storage.On("UploadFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image"), mock.AnythingOfType("*os.File"), mock.AnythingOfType("map[string]string")).Run(func(args mock.Arguments) {
+		args[2].(*models.Image).ID = primitive.NewObjectID().Hex()
+		arg1 := args[0].(chan<- error)
+		arg1 <- nil
+		arg2 := args[1].(*sync.WaitGroup)
+		arg2.Done()
+	})
+	s.deletedIDs = []string{}
+	var deletedIDsMu sync.Mutex
+	s.storage.On("DeleteFile", mock.AnythingOfType("chan<- error"), mock.AnythingOfType("*sync.WaitGroup"), mock.AnythingOfType("*models.Image")).Run(func(args mock.Arguments) {
+		deletedIDsMu.Lock()
+		s.deletedIDs = append(s.deletedIDs, args[2].(*models.Image).ID)
+		deletedIDsMu.Unlock()
 		arg1 := args[0].(chan<- error)
 		arg1 <- nil
 		arg2 := args[1].(*sync.WaitGroup)
@@ -84,8 +136,42 @@ func (s *userUsecaseSuite) SetupTest() {
 	})
 	s.storage.On("AssignImageURLToUser", mock.AnythingOfType("*models.User")).Return(nil)
 	s.tokenRepo.On("Create", mock.AnythingOfType("*models.TokenSet")).Return(nil)
+	s.tokenRepo.On("DeleteAll", mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.tokenRepo.On("SaveOneTimeToken", token.OneTimeTokenPasswordReset, mock.AnythingOfType("string"), mock.AnythingOfType("primitive.ObjectID"), token.PasswordResetTokenDuration).Return(nil)
+	s.tokenRepo.On("ConsumeOneTimeToken", token.OneTimeTokenPasswordReset, mock.AnythingOfType("string")).Return(func(_, hashedToken string) primitive.ObjectID {
+		if hashedToken == utils.ToSHA256("validtoken") {
+			return userID
+		}
+
+		return primitive.NilObjectID
+	}, func(_, hashedToken string) error {
+		if hashedToken != utils.ToSHA256("validtoken") {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	s.userRepo.On("UpdatePassword", mock.AnythingOfType("*models.User")).Run(func(args mock.Arguments) {
+		s.updatedUser = args[0].(*models.User)
+	}).Return(nil)
+	s.tokenRepo.On("SaveOneTimeToken", token.OneTimeTokenEmailVerification, mock.AnythingOfType("string"), mock.AnythingOfType("primitive.ObjectID"), token.EmailVerificationTokenDuration).Return(nil)
+	s.tokenRepo.On("ConsumeOneTimeToken", token.OneTimeTokenEmailVerification, mock.AnythingOfType("string")).Return(func(_, hashedToken string) primitive.ObjectID {
+		if hashedToken == utils.ToSHA256("verificationtoken") {
+			return userID
+		}
 
-	s.usecase = usecase.NewUserUsecase(s.userRepo, s.tokenRepo, s.oauthRepo, s.storage)
+		return primitive.NilObjectID
+	}, func(_, hashedToken string) error {
+		if hashedToken != utils.ToSHA256("verificationtoken") {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	s.userRepo.On("SetEmailVerified", mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.mailer.On("Send", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+
+	s.usecase = usecase.NewUserUsecase(s.userRepo, s.tokenRepo, s.oauthRepo, s.storage, s.mailer)
 }
 
 func (s *userUsecaseSuite) TestCreateInvalidFields() {
@@ -146,3 +232,319 @@ func (s *userUsecaseSuite) TestLoginSuccessful() {
 	assert.Equal(s.T(), "image2", data.Images[1].URL)
 	assert.Equal(s.T(), uint(400), data.Images[1].Width)
 }
+
+func (s *userUsecaseSuite) TestGetProfileLeavesOutEmail() {
+	profile, err := s.usecase.GetProfile(userID)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), userID, profile.ID)
+	assert.Equal(s.T(), "jojo", profile.Username)
+	assert.Equal(s.T(), "a joestar", profile.Bio)
+	assert.Len(s.T(), profile.Images, 2)
+	assert.Empty(s.T(), profile.Images[0].ID)
+}
+
+func (s *userUsecaseSuite) TestUpdateProfileInvalidFields() {
+	bio := make([]byte, 201)
+	for i := range bio {
+		bio[i] = 'a'
+	}
+
+	err := s.usecase.UpdateProfile(userID, map[string]string{"name": " ", "username": "_jojo", "bio": string(bio)})
+
+	expectedErrors := &custom_errors.MultipleErrors{Errors: []error{custom_errors.ErrUsernameInvalid, custom_errors.ErrNameTooShort, custom_errors.ErrBioTooLong}}
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), expectedErrors.Error(), err.Error())
+	s.userRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *userUsecaseSuite) TestUpdateProfileUsernameAlreadyExists() {
+	err := s.usecase.UpdateProfile(userID, map[string]string{"username": "AlreadyExist"})
+
+	expectedErrors := &custom_errors.MultipleErrors{Errors: []error{custom_errors.ErrUsernameAlreadyExists}}
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), expectedErrors.Error(), err.Error())
+	s.userRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *userUsecaseSuite) TestUpdateProfileKeepingUsername() {
+	err := s.usecase.UpdateProfile(userID, map[string]string{"username": "JOJO", "bio": " a new bio "})
+
+	assert.NoError(s.T(), err)
+	s.userRepo.AssertNotCalled(s.T(), "FieldExists", "username", "jojo")
+	assert.Equal(s.T(), "jojo", s.updatedUser.Username)
+	assert.Equal(s.T(), "joseph joestar", s.updatedUser.Name)
+	assert.Equal(s.T(), "a new bio", s.updatedUser.Bio)
+}
+
+func (s *userUsecaseSuite) TestUpdateProfileSuccessful() {
+	err := s.usecase.UpdateProfile(userID, map[string]string{"name": " jonathan   joestar ", "username": "jonathan"})
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "jonathan joestar", s.updatedUser.Name)
+	assert.Equal(s.T(), "jonathan", s.updatedUser.Username)
+	assert.Equal(s.T(), "a joestar", s.updatedUser.Bio)
+}
+
+func (s *userUsecaseSuite) TestUpdateDisplayPictureInvalidFormat() {
+	err := s.usecase.UpdateDisplayPicture(userID, utils.NewNamedFileReader(bytes.NewReader([]byte("gif")), "avatar.gif"))
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrImageFormatInvalid, err)
+	s.storage.AssertNumberOfCalls(s.T(), "UploadFile", 0)
+}
+
+func (s *userUsecaseSuite) TestUpdateDisplayPictureTooLarge() {
+	err := s.usecase.UpdateDisplayPicture(userID, utils.NewNamedFileReader(bytes.NewReader(make([]byte, user.MaxDisplayPictureSize+1)), "avatar.png"))
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrImageSizeTooLarge, err)
+	s.storage.AssertNumberOfCalls(s.T(), "UploadFile", 0)
+}
+
+func (s *userUsecaseSuite) TestUpdateDisplayPictureSuccessful() {
+	err := s.usecase.UpdateDisplayPicture(userID, utils.NewNamedFileReader(bytes.NewReader(pngImage()), "avatar.png"))
+
+	assert.NoError(s.T(), err)
+	s.storage.AssertNumberOfCalls(s.T(), "UploadFile", len(user.DisplayPictureSizes))
+	assert.Len(s.T(), s.updatedUser.Images, len(user.DisplayPictureSizes))
+	for i, width := range user.DisplayPictureSizes {
+		assert.Equal(s.T(), width, s.updatedUser.Images[i].Width)
+		assert.NotEmpty(s.T(), s.updatedUser.Images[i].ID)
+	}
+
+	// only the replaced images are removed from the storage
+	assert.ElementsMatch(s.T(), []string{"oldimage1", "oldimage2"}, s.deletedIDs)
+}
+
+func (s *userUsecaseSuite) TestChangePasswordWrongCurrentPassword() {
+	err := s.usecase.ChangePassword(userID, "wrongPassword", "NewPassword123!")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrCurrentPasswordWrong, err)
+	s.userRepo.AssertNumberOfCalls(s.T(), "UpdatePassword", 0)
+}
+
+func (s *userUsecaseSuite) TestChangePasswordInvalidNewPassword() {
+	err := s.usecase.ChangePassword(userID, "Password123!", "short")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrPasswordTooShort, err)
+	s.userRepo.AssertNumberOfCalls(s.T(), "UpdatePassword", 0)
+}
+
+func (s *userUsecaseSuite) TestChangePasswordSuccessful() {
+	err := s.usecase.ChangePassword(userID, "Password123!", "NewPassword123!")
+
+	assert.NoError(s.T(), err)
+	assert.NoError(s.T(), bcrypt.CompareHashAndPassword([]byte(s.updatedUser.EncryptedPassword), []byte("NewPassword123!")))
+	s.tokenRepo.AssertNumberOfCalls(s.T(), "DeleteAll", 0)
+}
+
+func (s *userUsecaseSuite) TestForgotPasswordUnknownEmail() {
+	err := s.usecase.ForgotPassword("unknown@gmail.com")
+
+	assert.NoError(s.T(), err)
+	s.tokenRepo.AssertNumberOfCalls(s.T(), "SaveOneTimeToken", 0)
+	s.mailer.AssertNumberOfCalls(s.T(), "Send", 0)
+}
+
+func (s *userUsecaseSuite) TestForgotPasswordSuccessful() {
+	err := s.usecase.ForgotPassword(" jojo@gmail.com ")
+
+	assert.NoError(s.T(), err)
+	s.mailer.AssertNumberOfCalls(s.T(), "Send", 1)
+	assert.Equal(s.T(), user1.Email, s.mailer.Calls[0].Arguments[0])
+
+	// the emailed token is the one whose hash was saved
+	body := s.mailer.Calls[0].Arguments[2].(string)
+	resetToken := strings.Fields(body[strings.Index(body, "token=")+len("token="):])[0]
+	s.tokenRepo.AssertCalled(s.T(), "SaveOneTimeToken", token.OneTimeTokenPasswordReset, utils.ToSHA256(resetToken), userID, token.PasswordResetTokenDuration)
+}
+
+func (s *userUsecaseSuite) TestResetPasswordInvalidPassword() {
+	err := s.usecase.ResetPassword("validtoken", "short")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrPasswordTooShort, err)
+	s.tokenRepo.AssertNumberOfCalls(s.T(), "ConsumeOneTimeToken", 0)
+}
+
+func (s *userUsecaseSuite) TestResetPasswordInvalidToken() {
+	err := s.usecase.ResetPassword("invalidtoken", "NewPassword123!")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrPasswordResetTokenInvalid, err)
+	s.userRepo.AssertNumberOfCalls(s.T(), "UpdatePassword", 0)
+	s.tokenRepo.AssertNumberOfCalls(s.T(), "DeleteAll", 0)
+}
+
+func (s *userUsecaseSuite) TestResetPasswordSuccessful() {
+	err := s.usecase.ResetPassword("validtoken", "NewPassword123!")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), userID, s.updatedUser.ID)
+	assert.NoError(s.T(), bcrypt.CompareHashAndPassword([]byte(s.updatedUser.EncryptedPassword), []byte("NewPassword123!")))
+	s.tokenRepo.AssertCalled(s.T(), "DeleteAll", userID)
+}
+
+func pngImage() []byte {
+	buf := new(bytes.Buffer)
+	png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 800, 800)))
+
+	return buf.Bytes()
+}
+
+func (s *userUsecaseSuite) TestCreateSendsVerificationEmail() {
+	user := &models.User{
+		Email:    "dio@gmail.com",
+		Name:     "dio brando",
+		Username: "dio",
+		Password: "Password123!",
+	}
+
+	_, err := s.usecase.Create(user, utils.NewNamedFileReader(bytes.NewReader(pngImage()), "avatar.png"))
+
+	assert.NoError(s.T(), err)
+	assert.False(s.T(), user.EmailVerified)
+	s.tokenRepo.AssertCalled(s.T(), "SaveOneTimeToken", token.OneTimeTokenEmailVerification, mock.AnythingOfType("string"), user.ID, token.EmailVerificationTokenDuration)
+	s.mailer.AssertCalled(s.T(), "Send", "dio@gmail.com", mock.AnythingOfType("string"), mock.AnythingOfType("string"))
+}
+
+func (s *userUsecaseSuite) TestLoginWithOIDCInvalidIDToken() {
+	s.oauthRepo.On("VerifyIDToken", "keycloak", "token", "nonce").Return(nil, custom_errors.ErrIDTokenInvalid)
+
+	response, err := s.usecase.LoginWithOIDC("keycloak", "token", "nonce")
+
+	assert.Nil(s.T(), response)
+	assert.Equal(s.T(), custom_errors.ErrIDTokenInvalid, err)
+	s.userRepo.AssertNumberOfCalls(s.T(), "GetByEmail", 0)
+}
+
+func (s *userUsecaseSuite) TestLoginWithOIDCUnverifiedEmailOfExistingUser() {
+	s.oauthRepo.On("VerifyIDToken", "keycloak", "token", "").Return(&models.IDTokenClaims{Email: user1.Email, EmailVerified: false}, nil)
+
+	response, err := s.usecase.LoginWithOIDC("keycloak", "token", "")
+
+	assert.Nil(s.T(), response)
+	assert.Equal(s.T(), custom_errors.ErrOauthEmailNotVerified, err)
+	s.userRepo.AssertNumberOfCalls(s.T(), "SetEmailVerified", 0)
+}
+
+func (s *userUsecaseSuite) TestLoginWithOIDCTrustsVerifiedEmailOfExistingUser() {
+	s.oauthRepo.On("VerifyIDToken", oauth.OIDCProviderGoogle, "token", "").Return(&models.IDTokenClaims{Email: user1.Email, EmailVerified: true}, nil)
+	defer func() { user1.EmailVerified = false }()
+
+	_, err := s.usecase.LoginWithOIDC(oauth.OIDCProviderGoogle, "token", "")
+
+	assert.NoError(s.T(), err)
+	s.userRepo.AssertCalled(s.T(), "SetEmailVerified", userID)
+	assert.True(s.T(), user1.EmailVerified)
+}
+
+func (s *userUsecaseSuite) TestLoginWithOIDCCreatesVerifiedUser() {
+	pictureServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(pngImage())
+	}))
+	defer pictureServer.Close()
+
+	s.oauthRepo.On("VerifyIDToken", "keycloak", "token", "nonce").Return(&models.IDTokenClaims{
+		Email:             "jonathan@gmail.com",
+		EmailVerified:     true,
+		Name:              "jonathan joestar",
+		PreferredUsername: "jojo_1",
+		Picture:           pictureServer.URL + "/picture",
+	}, nil)
+
+	response, err := s.usecase.LoginWithOIDC("keycloak", "token", "nonce")
+
+	assert.NoError(s.T(), err)
+	createdUser := response["data"].(*models.User)
+	assert.True(s.T(), createdUser.EmailVerified)
+	assert.Equal(s.T(), "jojo1", createdUser.Username)
+	assert.Equal(s.T(), "jonathan joestar", createdUser.Name)
+	s.mailer.AssertNumberOfCalls(s.T(), "Send", 0)
+}
+
+func (s *userUsecaseSuite) TestLoginWithGithubInvalidCode() {
+	s.oauthRepo.On("GetGithubUserInfo", "code").Return(nil, custom_errors.ErrGithubOauthCodeInvalid)
+
+	response, err := s.usecase.LoginWithGithub("code")
+
+	assert.Nil(s.T(), response)
+	assert.Equal(s.T(), custom_errors.ErrGithubOauthCodeInvalid, err)
+	s.userRepo.AssertNumberOfCalls(s.T(), "GetByEmail", 0)
+}
+
+func (s *userUsecaseSuite) TestLoginWithGithubExistingUser() {
+	s.oauthRepo.On("GetGithubUserInfo", "code").Return(&models.GithubUserInfo{Login: "jojo", Email: user1.Email}, nil)
+	defer func() { user1.EmailVerified = false }()
+
+	response, err := s.usecase.LoginWithGithub("code")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), user1, response["data"])
+	s.userRepo.AssertCalled(s.T(), "SetEmailVerified", userID)
+	s.userRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *userUsecaseSuite) TestLoginWithGithubCreatesVerifiedUser() {
+	avatarServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write(pngImage())
+	}))
+	defer avatarServer.Close()
+
+	s.oauthRepo.On("GetGithubUserInfo", "code").Return(&models.GithubUserInfo{
+		Login:     "Jonathan-Joestar",
+		Email:     "jonathan@gmail.com",
+		AvatarURL: avatarServer.URL + "/avatar",
+	}, nil)
+
+	response, err := s.usecase.LoginWithGithub("code")
+
+	assert.NoError(s.T(), err)
+	createdUser := response["data"].(*models.User)
+	assert.True(s.T(), createdUser.EmailVerified)
+	assert.Equal(s.T(), "jonathanjoestar", createdUser.Username)
+	assert.Equal(s.T(), "Jonathan-Joestar", createdUser.Name)
+	s.mailer.AssertNumberOfCalls(s.T(), "Send", 0)
+}
+
+func (s *userUsecaseSuite) TestSendVerificationEmailAlreadyVerified() {
+	user1.EmailVerified = true
+	defer func() { user1.EmailVerified = false }()
+
+	err := s.usecase.SendVerificationEmail(userID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrEmailAddressAlreadyVerified, err)
+	s.mailer.AssertNumberOfCalls(s.T(), "Send", 0)
+}
+
+func (s *userUsecaseSuite) TestSendVerificationEmailSuccessful() {
+	err := s.usecase.SendVerificationEmail(userID)
+
+	assert.NoError(s.T(), err)
+	s.mailer.AssertNumberOfCalls(s.T(), "Send", 1)
+
+	body := s.mailer.Calls[0].Arguments[2].(string)
+	verificationToken := strings.Fields(body[strings.Index(body, "token=")+len("token="):])[0]
+	s.tokenRepo.AssertCalled(s.T(), "SaveOneTimeToken", token.OneTimeTokenEmailVerification, utils.ToSHA256(verificationToken), userID, token.EmailVerificationTokenDuration)
+}
+
+func (s *userUsecaseSuite) TestVerifyEmailInvalidToken() {
+	err := s.usecase.VerifyEmail("invalidtoken")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrEmailVerificationTokenInvalid, err)
+	s.userRepo.AssertNumberOfCalls(s.T(), "SetEmailVerified", 0)
+}
+
+func (s *userUsecaseSuite) TestVerifyEmailSuccessful() {
+	err := s.usecase.VerifyEmail("verificationtoken")
+
+	assert.NoError(s.T(), err)
+	s.userRepo.AssertCalled(s.T(), "SetEmailVerified", userID)
+}
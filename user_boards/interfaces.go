@@ -0,0 +1,12 @@
+package user_boards
+
+import (
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Repository interface {
+	GetUserBoards(userID primitive.ObjectID) (*models.UserBoards, error)
+	AddBoard(userID, boardID primitive.ObjectID) error
+	RemoveBoard(userID, boardID primitive.ObjectID) error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/user_boards"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type userBoardsRepository struct {
+	dbClient *db.Client
+}
+
+func NewUserBoardsRepository(dbClient *db.Client) user_boards.Repository {
+	return &userBoardsRepository{dbClient: dbClient}
+}
+
+// GetUserBoards reads the index of the user, it is stored as user_boards/{userID}/{boardID}
+// so a board can be added to or removed from it as part of any multi-path update
+func (repo *userBoardsRepository) GetUserBoards(userID primitive.ObjectID) (*models.UserBoards, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("user_boards/%s", userID.Hex()))
+
+	boardIDsMap := map[string]bool{}
+
+	err := ref.Get(ctx, &boardIDsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	// a user that has never joined a board will not have an index yet
+	userBoards := &models.UserBoards{UserID: userID, BoardIDs: []primitive.ObjectID{}}
+	for hexBoardID := range boardIDsMap {
+		boardID, err := primitive.ObjectIDFromHex(hexBoardID)
+		if err != nil {
+			return nil, err
+		}
+
+		userBoards.BoardIDs = append(userBoards.BoardIDs, boardID)
+	}
+
+	return userBoards, nil
+}
+
+func (repo *userBoardsRepository) AddBoard(userID, boardID primitive.ObjectID) error {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("user_boards/%s/%s", userID.Hex(), boardID.Hex()))
+
+	return ref.Set(ctx, true)
+}
+
+func (repo *userBoardsRepository) RemoveBoard(userID, boardID primitive.ObjectID) error {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("user_boards/%s/%s", userID.Hex(), boardID.Hex()))
+
+	return ref.Delete(ctx)
+}
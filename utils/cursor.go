@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// IsNewerRecord orders records from the newest to the oldest, records created at the
+// same time are ordered by ID so every record has a fixed place to continue from
+func IsNewerRecord(aID primitive.ObjectID, aCreatedAt time.Time, bID primitive.ObjectID, bCreatedAt time.Time) bool {
+	if !aCreatedAt.Equal(bCreatedAt) {
+		return aCreatedAt.After(bCreatedAt)
+	}
+
+	return aID.Hex() > bID.Hex()
+}
+
+// EncodeCursor returns the pagination cursor that holds the ID and the creation time of the last returned record
+func EncodeCursor(id primitive.ObjectID, createdAt time.Time) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%s|%s", id.Hex(), createdAt.Format(time.RFC3339Nano))))
+}
+
+// DecodeCursor returns the ID and the creation time held by a cursor from EncodeCursor,
+// it fails with custom_errors.ErrInvalidCursor for anything else
+func DecodeCursor(cursor string) (primitive.ObjectID, time.Time, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return primitive.NilObjectID, time.Time{}, custom_errors.ErrInvalidCursor
+	}
+
+	parts := strings.SplitN(string(decoded), "|", 2)
+	if len(parts) != 2 {
+		return primitive.NilObjectID, time.Time{}, custom_errors.ErrInvalidCursor
+	}
+
+	id, err := primitive.ObjectIDFromHex(parts[0])
+	if err != nil {
+		return primitive.NilObjectID, time.Time{}, custom_errors.ErrInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[1])
+	if err != nil {
+		return primitive.NilObjectID, time.Time{}, custom_errors.ErrInvalidCursor
+	}
+
+	return id, createdAt, nil
+}
@@ -0,0 +1,48 @@
+package utils_test
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/utils"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestDecodeCursorReturnsEncodedRecord(t *testing.T) {
+	id := primitive.NewObjectID()
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	decodedID, decodedCreatedAt, err := utils.DecodeCursor(utils.EncodeCursor(id, createdAt))
+
+	assert.NoError(t, err)
+	assert.Equal(t, id, decodedID)
+	assert.True(t, createdAt.Equal(decodedCreatedAt))
+}
+
+func TestDecodeCursorInvalid(t *testing.T) {
+	cursors := []string{
+		"not base64!",
+		base64.RawURLEncoding.EncodeToString([]byte("no separator")),
+		base64.RawURLEncoding.EncodeToString([]byte("not an id|2023-01-02T03:04:05Z")),
+		base64.RawURLEncoding.EncodeToString([]byte(primitive.NewObjectID().Hex() + "|yesterday")),
+	}
+
+	for _, cursor := range cursors {
+		_, _, err := utils.DecodeCursor(cursor)
+		assert.Equal(t, custom_errors.ErrInvalidCursor, err, cursor)
+	}
+}
+
+func TestIsNewerRecord(t *testing.T) {
+	now := time.Now()
+	olderID, newerID := primitive.NewObjectID(), primitive.NewObjectID()
+
+	assert.True(t, utils.IsNewerRecord(olderID, now, newerID, now.Add(-time.Second)))
+	assert.False(t, utils.IsNewerRecord(newerID, now.Add(-time.Second), olderID, now))
+	// records created at the same time are ordered by ID
+	assert.True(t, utils.IsNewerRecord(newerID, now, olderID, now))
+	assert.False(t, utils.IsNewerRecord(olderID, now, newerID, now))
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/custom_errors"
+)
+
+const (
+	LockLease = time.Second * 10
+
+	maxConflictAttempts = 5
+	conflictRetryDelay  = time.Millisecond * 20
+)
+
+// AcquireLock claims the lock stored at ref through a transaction, it fails with
+// custom_errors.ErrConflictingUpdate while another request holds an unexpired lock.
+// The lock is released by deleting ref, usually in the same multi-path update
+// that writes the changes it guards. When those changes are never written the
+// returned lease has to be handed to ReleaseLock instead.
+func AcquireLock(ctx context.Context, ref *db.Ref) (int64, error) {
+	var lease int64
+
+	err := ref.Transaction(ctx, func(node db.TransactionNode) (interface{}, error) {
+		var expiresAt int64
+
+		err := node.Unmarshal(&expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		now := time.Now()
+		if expiresAt > now.UnixMilli() {
+			return nil, custom_errors.ErrConflictingUpdate
+		}
+
+		lease = now.Add(LockLease).UnixMilli()
+		return lease, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return lease, nil
+}
+
+// ReleaseLock deletes the lock stored at ref through a transaction, a lock whose lease
+// has run out and has been claimed by another request in the meantime is left alone
+func ReleaseLock(ctx context.Context, ref *db.Ref, lease int64) error {
+	return ref.Transaction(ctx, func(node db.TransactionNode) (interface{}, error) {
+		var expiresAt int64
+
+		err := node.Unmarshal(&expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		if expiresAt != 0 && expiresAt != lease {
+			return expiresAt, nil
+		}
+
+		return nil, nil
+	})
+}
+
+// RetryOnConflict calls fn until it stops failing with custom_errors.ErrConflictingUpdate
+// or the attempts run out, waiting a bit longer before every retry
+func RetryOnConflict(fn func() error) error {
+	var err error
+
+	for attempt := 1; attempt <= maxConflictAttempts; attempt++ {
+		err = fn()
+		if err != custom_errors.ErrConflictingUpdate || attempt == maxConflictAttempts {
+			return err
+		}
+
+		delay := time.Duration(attempt)*conflictRetryDelay + time.Duration(rand.Int63n(int64(conflictRetryDelay)))
+		time.Sleep(delay)
+	}
+
+	return err
+}
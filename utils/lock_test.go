@@ -0,0 +1,126 @@
+package utils_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/utils"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+func TestLock(t *testing.T) {
+	suite.Run(t, new(lockSuite))
+}
+
+type lockSuite struct {
+	suite.Suite
+	server  *rtdbtest.Server
+	lockRef *db.Ref
+}
+
+func (s *lockSuite) SetupTest() {
+	s.server = rtdbtest.NewServer()
+
+	dbClient, err := s.server.NewDatabaseClient()
+	s.Require().NoError(err)
+
+	s.lockRef = dbClient.NewRef("locks/1")
+}
+
+func (s *lockSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *lockSuite) TestAcquireLockHeldByAnotherRequest() {
+	_, err := utils.AcquireLock(context.Background(), s.lockRef)
+	assert.NoError(s.T(), err)
+
+	_, err = utils.AcquireLock(context.Background(), s.lockRef)
+
+	assert.Equal(s.T(), custom_errors.ErrConflictingUpdate, err)
+}
+
+func (s *lockSuite) TestAcquireLockWithExpiredLease() {
+	s.server.Set("locks/1", time.Now().Add(-time.Second).UnixMilli())
+
+	lease, err := utils.AcquireLock(context.Background(), s.lockRef)
+
+	assert.NoError(s.T(), err)
+	assert.Greater(s.T(), lease, time.Now().UnixMilli())
+}
+
+func (s *lockSuite) TestAcquireLockOnlyOneOfConcurrentRequests() {
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			_, err := utils.AcquireLock(context.Background(), s.lockRef)
+			errs <- err
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	acquired := 0
+	for err := range errs {
+		if err == nil {
+			acquired++
+		}
+	}
+
+	assert.Equal(s.T(), 1, acquired)
+}
+
+func (s *lockSuite) TestReleaseLock() {
+	lease, err := utils.AcquireLock(context.Background(), s.lockRef)
+	assert.NoError(s.T(), err)
+
+	err = utils.ReleaseLock(context.Background(), s.lockRef, lease)
+	assert.NoError(s.T(), err)
+
+	_, err = utils.AcquireLock(context.Background(), s.lockRef)
+	assert.NoError(s.T(), err)
+}
+
+func (s *lockSuite) TestReleaseLockClaimedByAnotherRequest() {
+	otherLease := time.Now().Add(utils.LockLease).UnixMilli()
+	s.server.Set("locks/1", otherLease)
+
+	err := utils.ReleaseLock(context.Background(), s.lockRef, otherLease-utils.LockLease.Milliseconds())
+	assert.NoError(s.T(), err)
+
+	var lease int64
+	s.server.Get("locks/1", &lease)
+	assert.Equal(s.T(), otherLease, lease)
+}
+
+func (s *lockSuite) TestRetryOnConflictWaitsForRelease() {
+	lease, err := utils.AcquireLock(context.Background(), s.lockRef)
+	assert.NoError(s.T(), err)
+
+	go func() {
+		time.Sleep(30 * time.Millisecond)
+		utils.ReleaseLock(context.Background(), s.lockRef, lease)
+	}()
+
+	attempts := 0
+	err = utils.RetryOnConflict(func() error {
+		attempts++
+		_, err := utils.AcquireLock(context.Background(), s.lockRef)
+		return err
+	})
+
+	assert.NoError(s.T(), err)
+	assert.Greater(s.T(), attempts, 1)
+}
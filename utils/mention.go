@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// a mention has to start the text or follow a character that can not be part of an email address
+	mentionRegex = regexp.MustCompile(`(?:^|[^\w@.])@([A-Za-z0-9._]+)`)
+)
+
+// ParseMentions returns the lowercased usernames mentioned with an @ in the text without duplicates,
+// fullstops and underscores that end a mention are left out since usernames can not end with them
+func ParseMentions(text string) []string {
+	usernames := []string{}
+	isParsed := make(map[string]bool)
+
+	for _, match := range mentionRegex.FindAllStringSubmatch(text, -1) {
+		username := strings.ToLower(strings.TrimRight(match[1], "._"))
+		if username == "" || isParsed[username] {
+			continue
+		}
+
+		isParsed[username] = true
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
@@ -0,0 +1,286 @@
+// Package rtdbtest provides an in-memory stand-in for the REST API of the firebase realtime database,
+// so repositories can be tested with a real db.Client including its transactions and multi-path updates.
+package rtdbtest
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+
+	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/db"
+)
+
+// Server keeps the whole database as decoded JSON and serves the subset of the REST API that
+// db.Client uses: reads with orderBy and equalTo, ETag based transactions, multi-path updates and deletes.
+type Server struct {
+	*httptest.Server
+
+	mutex          sync.Mutex
+	root           interface{}
+	requests       map[string]int
+	failingMethods map[string]bool
+}
+
+func NewServer() *Server {
+	server := &Server{requests: make(map[string]int), failingMethods: make(map[string]bool)}
+	server.Server = httptest.NewServer(http.HandlerFunc(server.handle))
+
+	return server
+}
+
+// NewDatabaseClient returns a client of the database served by the server
+func (server *Server) NewDatabaseClient() (*db.Client, error) {
+	ctx := context.Background()
+
+	// a database url without a scheme is how the client is pointed at an emulator
+	databaseURL := strings.Replace(server.URL, "http://127.0.0.1", "localhost", 1) + "?ns=rtdbtest"
+
+	app, err := firebase.NewApp(ctx, &firebase.Config{DatabaseURL: databaseURL, ProjectID: "rtdbtest"})
+	if err != nil {
+		return nil, err
+	}
+
+	return app.Database(ctx)
+}
+
+// Requests returns how many requests with the method have been made for the path, such as "GET /lists"
+func (server *Server) Requests(method, path string) int {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	return server.requests[method+" "+path]
+}
+
+// FailRequests makes every following request with the method fail with an internal server error
+// until it is called again with fail set to false
+func (server *Server) FailRequests(method string, fail bool) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.failingMethods[method] = fail
+}
+
+// Get decodes the value stored at path into v
+func (server *Server) Get(path string, v interface{}) error {
+	server.mutex.Lock()
+	value, _ := json.Marshal(server.get(splitPath(path)))
+	server.mutex.Unlock()
+
+	return json.Unmarshal(value, v)
+}
+
+// Set stores v at path, a nil v deletes the value
+func (server *Server) Set(path string, v interface{}) error {
+	value, err := decode(v)
+	if err != nil {
+		return err
+	}
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.set(splitPath(path), value)
+
+	return nil
+}
+
+func (server *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, ".json")
+	segments := splitPath(path)
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+
+	server.requests[r.Method+" /"+strings.Join(segments, "/")]++
+
+	if server.failingMethods[r.Method] {
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("%s requests are failing", r.Method))
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		value := server.get(segments)
+
+		if orderBy := r.URL.Query().Get("orderBy"); orderBy != "" {
+			filtered, err := filter(value, orderBy, r.URL.Query().Get("equalTo"))
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+
+			value = filtered
+		}
+
+		if r.Header.Get("X-Firebase-ETag") == "true" {
+			w.Header().Set("ETag", etag(value))
+		}
+
+		writeJSON(w, http.StatusOK, value)
+	case http.MethodPut:
+		var value interface{}
+		err := json.NewDecoder(r.Body).Decode(&value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		// a transaction only writes when nobody has changed the value since it was read
+		current := server.get(segments)
+		if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != etag(current) {
+			w.Header().Set("ETag", etag(current))
+			writeJSON(w, http.StatusPreconditionFailed, current)
+			return
+		}
+
+		server.set(segments, value)
+		w.Header().Set("ETag", etag(value))
+		writeJSON(w, http.StatusOK, value)
+	case http.MethodPatch:
+		updates := map[string]interface{}{}
+		err := json.NewDecoder(r.Body).Decode(&updates)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		for updatePath, value := range updates {
+			server.set(append(append([]string{}, segments...), splitPath(updatePath)...), value)
+		}
+
+		if r.URL.Query().Get("print") == "silent" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, updates)
+	case http.MethodDelete:
+		server.set(segments, nil)
+		writeJSON(w, http.StatusOK, nil)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s is not supported", r.Method))
+	}
+}
+
+func (server *Server) get(segments []string) interface{} {
+	value := server.root
+	for _, segment := range segments {
+		node, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		value = node[segment]
+	}
+
+	return value
+}
+
+// set stores value at the segments, the nodes that are left empty are removed just like the database does
+func (server *Server) set(segments []string, value interface{}) {
+	server.root = setIn(server.root, segments, value)
+}
+
+func setIn(node interface{}, segments []string, value interface{}) interface{} {
+	if len(segments) == 0 {
+		if children, ok := value.(map[string]interface{}); ok && len(children) == 0 {
+			return nil
+		}
+
+		return value
+	}
+
+	children, ok := node.(map[string]interface{})
+	if !ok {
+		children = map[string]interface{}{}
+	}
+
+	child := setIn(children[segments[0]], segments[1:], value)
+	if child == nil {
+		delete(children, segments[0])
+	} else {
+		children[segments[0]] = child
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+
+	return children
+}
+
+// filter keeps the children of value whose orderBy child equals equalTo, both are JSON encoded
+func filter(value interface{}, orderBy, equalTo string) (interface{}, error) {
+	var child string
+	err := json.Unmarshal([]byte(orderBy), &child)
+	if err != nil {
+		return nil, err
+	}
+
+	var expected interface{}
+	if equalTo != "" {
+		err = json.Unmarshal([]byte(equalTo), &expected)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	filtered := map[string]interface{}{}
+	children, _ := value.(map[string]interface{})
+	for key, childValue := range children {
+		fields, _ := childValue.(map[string]interface{})
+		if equalTo == "" || reflect.DeepEqual(fields[child], expected) {
+			filtered[key] = childValue
+		}
+	}
+
+	return filtered, nil
+}
+
+func splitPath(path string) []string {
+	segments := []string{}
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+
+	return segments
+}
+
+func decode(v interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var value interface{}
+	err = json.Unmarshal(encoded, &value)
+
+	return value, err
+}
+
+func etag(value interface{}) string {
+	encoded, _ := json.Marshal(value)
+	sum := sha256.Sum256(encoded)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, err error) {
+	writeJSON(w, statusCode, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AddFieldsToUpdates adds the fields of value, named by their json keys, to the updates as their
+// own paths below path so the other fields that are stored there are left untouched. The value is
+// encoded the same way as when it is written as a whole so its dates keep their format.
+func AddFieldsToUpdates(updates map[string]interface{}, path string, value interface{}, fields ...string) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]interface{}{}
+	err = json.Unmarshal(data, &values)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		fieldValue, isExist := values[field]
+		if !isExist {
+			return fmt.Errorf("%s has no field %s", path, field)
+		}
+
+		updates[fmt.Sprintf("%s/%s", path, field)] = fieldValue
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/jordyf15/thullo-api/models"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	// MaxDeliveryAttempts is how many times a delivery is tried before it counts as failed
+	MaxDeliveryAttempts = 6
+	// RetryBaseDelay is the wait before the first retry, it doubles with every following retry
+	RetryBaseDelay = 30 * time.Second
+	// MaxConsecutiveFailures is how many deliveries in a row may fail before the webhook is disabled
+	MaxConsecutiveFailures = 5
+	// MaxDeliveryLogs is how many of the latest delivery logs are returned
+	MaxDeliveryLogs = 50
+
+	DeliveryTimeout      = 10 * time.Second
+	DeliveryPollInterval = time.Second
+	DeliveryBatchSize    = 20
+
+	HeaderEvent     = "X-Thullo-Event"
+	HeaderDelivery  = "X-Thullo-Delivery"
+	HeaderSignature = "X-Thullo-Signature"
+)
+
+// EventTypes are the board events that can be sent to webhooks
+var EventTypes = []string{
+	models.BoardEventMemberAdded,
+	models.BoardEventMemberUpdated,
+	models.BoardEventMemberRemoved,
+	models.BoardEventListCreated,
+	models.BoardEventListUpdated,
+	models.BoardEventListMoved,
+	models.BoardEventListArchived,
+	models.BoardEventListUnarchived,
+	models.BoardEventListDeleted,
+	models.BoardEventCardCreated,
+	models.BoardEventCardUpdated,
+	models.BoardEventCardMoved,
+	models.BoardEventCardArchived,
+	models.BoardEventCardUnarchived,
+	models.BoardEventCommentCreated,
+	models.BoardEventCommentUpdated,
+	models.BoardEventCommentDeleted,
+}
+
+type Repository interface {
+	Create(webhook *models.Webhook) error
+	GetWebhookByID(webhookID primitive.ObjectID) (*models.Webhook, error)
+	GetBoardWebhooks(boardID primitive.ObjectID) ([]*models.Webhook, error)
+	Update(webhook *models.Webhook) error
+	// UpdateDeliveryStatus applies update to the stored webhook in a transaction so deliveries
+	// that finish at the same time and edits that admins make in the meantime are not lost
+	UpdateDeliveryStatus(webhookID primitive.ObjectID, update func(webhook *models.Webhook)) error
+	Delete(webhookID primitive.ObjectID) error
+	CreateDeliveryLog(deliveryLog *models.WebhookDeliveryLog) error
+	GetDeliveryLogs(webhookID primitive.ObjectID) ([]*models.WebhookDeliveryLog, error)
+	// ScheduleDelivery queues the delivery to be sent once at has passed
+	ScheduleDelivery(delivery *models.WebhookDelivery, at time.Time) error
+	// ClaimDueDeliveries takes the deliveries that are due off the queue, a delivery
+	// is only claimed by one of the instances that are running
+	ClaimDueDeliveries(now time.Time, limit int) ([]*models.WebhookDelivery, error)
+}
+
+type Usecase interface {
+	Create(requesterID, boardID primitive.ObjectID, url string, eventTypes []string) error
+	GetBoardWebhooks(requesterID, boardID primitive.ObjectID) ([]*models.Webhook, error)
+	UpdateURL(requesterID, boardID, webhookID primitive.ObjectID, url string) error
+	UpdateEventTypes(requesterID, boardID, webhookID primitive.ObjectID, eventTypes []string) error
+	UpdateActive(requesterID, boardID, webhookID primitive.ObjectID, active bool) error
+	Delete(requesterID, boardID, webhookID primitive.ObjectID) error
+	GetDeliveryLogs(requesterID, boardID, webhookID primitive.ObjectID) ([]*models.WebhookDeliveryLog, error)
+}
+
+type Dispatcher interface {
+	// Run queues the published events for the webhooks of their board and delivers them until ctx is done
+	Run(ctx context.Context)
+	Dispatch(event *models.BoardEvent) error
+	Deliver(delivery *models.WebhookDelivery) error
+}
@@ -0,0 +1,208 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+
+	time "time"
+)
+
+// Repository is an autogenerated mock type for the Repository type
+type Repository struct {
+	mock.Mock
+}
+
+// ClaimDueDeliveries provides a mock function with given fields: now, limit
+func (_m *Repository) ClaimDueDeliveries(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	ret := _m.Called(now, limit)
+
+	var r0 []*models.WebhookDelivery
+	if rf, ok := ret.Get(0).(func(time.Time, int) []*models.WebhookDelivery); ok {
+		r0 = rf(now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDelivery)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(time.Time, int) error); ok {
+		r1 = rf(now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: _a0
+func (_m *Repository) Create(_a0 *models.Webhook) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Webhook) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateDeliveryLog provides a mock function with given fields: deliveryLog
+func (_m *Repository) CreateDeliveryLog(deliveryLog *models.WebhookDeliveryLog) error {
+	ret := _m.Called(deliveryLog)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.WebhookDeliveryLog) error); ok {
+		r0 = rf(deliveryLog)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: webhookID
+func (_m *Repository) Delete(webhookID primitive.ObjectID) error {
+	ret := _m.Called(webhookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) error); ok {
+		r0 = rf(webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBoardWebhooks provides a mock function with given fields: boardID
+func (_m *Repository) GetBoardWebhooks(boardID primitive.ObjectID) ([]*models.Webhook, error) {
+	ret := _m.Called(boardID)
+
+	var r0 []*models.Webhook
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.Webhook); ok {
+		r0 = rf(boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveryLogs provides a mock function with given fields: webhookID
+func (_m *Repository) GetDeliveryLogs(webhookID primitive.ObjectID) ([]*models.WebhookDeliveryLog, error) {
+	ret := _m.Called(webhookID)
+
+	var r0 []*models.WebhookDeliveryLog
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) []*models.WebhookDeliveryLog); ok {
+		r0 = rf(webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDeliveryLog)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookByID provides a mock function with given fields: webhookID
+func (_m *Repository) GetWebhookByID(webhookID primitive.ObjectID) (*models.Webhook, error) {
+	ret := _m.Called(webhookID)
+
+	var r0 *models.Webhook
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID) *models.Webhook); ok {
+		r0 = rf(webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID) error); ok {
+		r1 = rf(webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScheduleDelivery provides a mock function with given fields: delivery, at
+func (_m *Repository) ScheduleDelivery(delivery *models.WebhookDelivery, at time.Time) error {
+	ret := _m.Called(delivery, at)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.WebhookDelivery, time.Time) error); ok {
+		r0 = rf(delivery, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: _a0
+func (_m *Repository) Update(_a0 *models.Webhook) error {
+	ret := _m.Called(_a0)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*models.Webhook) error); ok {
+		r0 = rf(_a0)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateDeliveryStatus provides a mock function with given fields: webhookID, update
+func (_m *Repository) UpdateDeliveryStatus(webhookID primitive.ObjectID, update func(*models.Webhook)) error {
+	ret := _m.Called(webhookID, update)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, func(*models.Webhook)) error); ok {
+		r0 = rf(webhookID, update)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewRepository interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewRepository creates a new instance of Repository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewRepository(t mockConstructorTestingTNewRepository) *Repository {
+	mock := &Repository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,146 @@
+// Code generated by mockery v2.14.0. DO NOT EDIT.
+
+package mocks
+
+import (
+	models "github.com/jordyf15/thullo-api/models"
+	mock "github.com/stretchr/testify/mock"
+
+	primitive "go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Usecase is an autogenerated mock type for the Usecase type
+type Usecase struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: requesterID, boardID, url, eventTypes
+func (_m *Usecase) Create(requesterID primitive.ObjectID, boardID primitive.ObjectID, url string, eventTypes []string) error {
+	ret := _m.Called(requesterID, boardID, url, eventTypes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, string, []string) error); ok {
+		r0 = rf(requesterID, boardID, url, eventTypes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: requesterID, boardID, webhookID
+func (_m *Usecase) Delete(requesterID primitive.ObjectID, boardID primitive.ObjectID, webhookID primitive.ObjectID) error {
+	ret := _m.Called(requesterID, boardID, webhookID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r0 = rf(requesterID, boardID, webhookID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBoardWebhooks provides a mock function with given fields: requesterID, boardID
+func (_m *Usecase) GetBoardWebhooks(requesterID primitive.ObjectID, boardID primitive.ObjectID) ([]*models.Webhook, error) {
+	ret := _m.Called(requesterID, boardID)
+
+	var r0 []*models.Webhook
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID) []*models.Webhook); ok {
+		r0 = rf(requesterID, boardID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Webhook)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(requesterID, boardID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveryLogs provides a mock function with given fields: requesterID, boardID, webhookID
+func (_m *Usecase) GetDeliveryLogs(requesterID primitive.ObjectID, boardID primitive.ObjectID, webhookID primitive.ObjectID) ([]*models.WebhookDeliveryLog, error) {
+	ret := _m.Called(requesterID, boardID, webhookID)
+
+	var r0 []*models.WebhookDeliveryLog
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) []*models.WebhookDeliveryLog); ok {
+		r0 = rf(requesterID, boardID, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.WebhookDeliveryLog)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID) error); ok {
+		r1 = rf(requesterID, boardID, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateActive provides a mock function with given fields: requesterID, boardID, webhookID, active
+func (_m *Usecase) UpdateActive(requesterID primitive.ObjectID, boardID primitive.ObjectID, webhookID primitive.ObjectID, active bool) error {
+	ret := _m.Called(requesterID, boardID, webhookID, active)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, bool) error); ok {
+		r0 = rf(requesterID, boardID, webhookID, active)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateEventTypes provides a mock function with given fields: requesterID, boardID, webhookID, eventTypes
+func (_m *Usecase) UpdateEventTypes(requesterID primitive.ObjectID, boardID primitive.ObjectID, webhookID primitive.ObjectID, eventTypes []string) error {
+	ret := _m.Called(requesterID, boardID, webhookID, eventTypes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, []string) error); ok {
+		r0 = rf(requesterID, boardID, webhookID, eventTypes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateURL provides a mock function with given fields: requesterID, boardID, webhookID, url
+func (_m *Usecase) UpdateURL(requesterID primitive.ObjectID, boardID primitive.ObjectID, webhookID primitive.ObjectID, url string) error {
+	ret := _m.Called(requesterID, boardID, webhookID, url)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(primitive.ObjectID, primitive.ObjectID, primitive.ObjectID, string) error); ok {
+		r0 = rf(requesterID, boardID, webhookID, url)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewUsecase interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewUsecase creates a new instance of Usecase. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewUsecase(t mockConstructorTestingTNewUsecase) *Usecase {
+	mock := &Usecase{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"firebase.google.com/go/v4/db"
+	"github.com/go-redis/redis/v9"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RedisKeyWebhookDeliveries = "webhook_deliveries"
+)
+
+type webhookRepository struct {
+	dbClient *db.Client
+	redis    *redis.Client
+}
+
+func NewWebhookRepository(dbClient *db.Client, redis *redis.Client) webhook.Repository {
+	return &webhookRepository{dbClient: dbClient, redis: redis}
+}
+
+func (repo *webhookRepository) Create(webhook *models.Webhook) error {
+	webhook.ID = primitive.NewObjectID()
+	webhook.CreatedAt = time.Now()
+	webhook.UpdatedAt = webhook.CreatedAt
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("webhooks/%s", webhook.ID.Hex()))
+
+	return ref.Set(ctx, webhook)
+}
+
+func (repo *webhookRepository) GetWebhookByID(webhookID primitive.ObjectID) (*models.Webhook, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("webhooks/%s", webhookID.Hex()))
+
+	webhook := &models.Webhook{}
+
+	err := ref.Get(ctx, &webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook == nil {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return webhook, nil
+}
+
+func (repo *webhookRepository) GetBoardWebhooks(boardID primitive.ObjectID) ([]*models.Webhook, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("webhooks").OrderByChild("board_id").EqualTo(boardID.Hex())
+
+	webhooksMap := make(map[string]*models.Webhook)
+
+	err := ref.Get(ctx, &webhooksMap)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := []*models.Webhook{}
+
+	for _, webhook := range webhooksMap {
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (repo *webhookRepository) Update(webhook *models.Webhook) error {
+	webhook.UpdatedAt = time.Now()
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("webhooks/%s", webhook.ID.Hex()))
+
+	return ref.Set(ctx, webhook)
+}
+
+func (repo *webhookRepository) UpdateDeliveryStatus(webhookID primitive.ObjectID, update func(webhook *models.Webhook)) error {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("webhooks/%s", webhookID.Hex()))
+
+	return ref.Transaction(ctx, func(node db.TransactionNode) (interface{}, error) {
+		var webhook *models.Webhook
+
+		err := node.Unmarshal(&webhook)
+		if err != nil {
+			return nil, err
+		}
+
+		// the webhook has been deleted in the meantime
+		if webhook == nil {
+			return nil, nil
+		}
+
+		update(webhook)
+		webhook.UpdatedAt = time.Now()
+
+		return webhook, nil
+	})
+}
+
+// Delete removes the webhook and its delivery logs, deliveries that are still queued
+// are dropped by the dispatcher once it finds out that their webhook is gone
+func (repo *webhookRepository) Delete(webhookID primitive.ObjectID) error {
+	deliveryLogs, err := repo.GetDeliveryLogs(webhookID)
+	if err != nil {
+		return err
+	}
+
+	updates := map[string]interface{}{
+		fmt.Sprintf("webhooks/%s", webhookID.Hex()): nil,
+	}
+
+	for _, deliveryLog := range deliveryLogs {
+		updates[fmt.Sprintf("webhook_delivery_logs/%s", deliveryLog.ID.Hex())] = nil
+	}
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("")
+
+	return ref.Update(ctx, updates)
+}
+
+func (repo *webhookRepository) CreateDeliveryLog(deliveryLog *models.WebhookDeliveryLog) error {
+	deliveryLog.ID = primitive.NewObjectID()
+	deliveryLog.CreatedAt = time.Now()
+
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef(fmt.Sprintf("webhook_delivery_logs/%s", deliveryLog.ID.Hex()))
+
+	return ref.Set(ctx, deliveryLog)
+}
+
+func (repo *webhookRepository) GetDeliveryLogs(webhookID primitive.ObjectID) ([]*models.WebhookDeliveryLog, error) {
+	ctx := context.Background()
+	ref := repo.dbClient.NewRef("webhook_delivery_logs").OrderByChild("webhook_id").EqualTo(webhookID.Hex())
+
+	deliveryLogsMap := make(map[string]*models.WebhookDeliveryLog)
+
+	err := ref.Get(ctx, &deliveryLogsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryLogs := []*models.WebhookDeliveryLog{}
+
+	for _, deliveryLog := range deliveryLogsMap {
+		deliveryLogs = append(deliveryLogs, deliveryLog)
+	}
+
+	return deliveryLogs, nil
+}
+
+// ScheduleDelivery adds the delivery to a sorted set that is scored by the time it is due
+func (repo *webhookRepository) ScheduleDelivery(delivery *models.WebhookDelivery, at time.Time) error {
+	payload, err := json.Marshal(delivery)
+	if err != nil {
+		return err
+	}
+
+	return repo.redis.ZAdd(context.Background(), RedisKeyWebhookDeliveries, redis.Z{
+		Score:  float64(at.UnixMilli()),
+		Member: payload,
+	}).Err()
+}
+
+func (repo *webhookRepository) ClaimDueDeliveries(now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	ctx := context.Background()
+
+	payloads, err := repo.redis.ZRangeByScore(ctx, RedisKeyWebhookDeliveries, &redis.ZRangeBy{
+		Min:   "-inf",
+		Max:   strconv.FormatInt(now.UnixMilli(), 10),
+		Count: int64(limit),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := []*models.WebhookDelivery{}
+	for _, payload := range payloads {
+		// the instance that manages to remove the delivery is the one that sends it
+		removed, err := repo.redis.ZRem(ctx, RedisKeyWebhookDeliveries, payload).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		if removed == 0 {
+			continue
+		}
+
+		delivery := &models.WebhookDelivery{}
+		err = json.Unmarshal([]byte(payload), delivery)
+		if err != nil {
+			log.Printf("failed to decode webhook delivery: %s", err)
+			continue
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v9"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/webhook"
+	"github.com/jordyf15/thullo-api/webhook/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWebhookRepository(t *testing.T) {
+	suite.Run(t, new(webhookRepositorySuite))
+}
+
+type webhookRepositorySuite struct {
+	suite.Suite
+	miniredis  *miniredis.Miniredis
+	repository webhook.Repository
+}
+
+func newDelivery() *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: primitive.NewObjectID(),
+		Event:     &models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: primitive.NewObjectID()},
+	}
+}
+
+func (s *webhookRepositorySuite) SetupTest() {
+	_miniredis, err := miniredis.Run()
+	if err != nil {
+		s.T().Fatalf("An error occured: %s", err)
+	}
+
+	s.miniredis = _miniredis
+	// the delivery queue only lives in redis
+	s.repository = repository.NewWebhookRepository(nil, redis.NewClient(&redis.Options{
+		Addr: _miniredis.Addr(),
+	}))
+}
+
+func (s *webhookRepositorySuite) TearDownTest() {
+	s.miniredis.Close()
+}
+
+func (s *webhookRepositorySuite) TestClaimDueDeliveriesOnlyClaimsDueDeliveries() {
+	now := time.Now()
+	dueDelivery, laterDelivery := newDelivery(), newDelivery()
+
+	assert.NoError(s.T(), s.repository.ScheduleDelivery(dueDelivery, now.Add(-time.Second)))
+	assert.NoError(s.T(), s.repository.ScheduleDelivery(laterDelivery, now.Add(time.Minute)))
+
+	deliveries, err := s.repository.ClaimDueDeliveries(now, 10)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), deliveries, 1)
+	assert.Equal(s.T(), dueDelivery.ID, deliveries[0].ID)
+	assert.Equal(s.T(), models.BoardEventCardCreated, deliveries[0].Event.Type)
+
+	deliveries, err = s.repository.ClaimDueDeliveries(now.Add(time.Minute), 10)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), deliveries, 1)
+	assert.Equal(s.T(), laterDelivery.ID, deliveries[0].ID)
+}
+
+func (s *webhookRepositorySuite) TestClaimDueDeliveriesClaimsOnce() {
+	assert.NoError(s.T(), s.repository.ScheduleDelivery(newDelivery(), time.Now()))
+
+	deliveries, err := s.repository.ClaimDueDeliveries(time.Now(), 10)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), deliveries, 1)
+
+	deliveries, err = s.repository.ClaimDueDeliveries(time.Now(), 10)
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), deliveries, 0)
+}
+
+func (s *webhookRepositorySuite) TestClaimDueDeliveriesRespectsLimit() {
+	for i := 0; i < 3; i++ {
+		assert.NoError(s.T(), s.repository.ScheduleDelivery(newDelivery(), time.Now()))
+	}
+
+	deliveries, err := s.repository.ClaimDueDeliveries(time.Now(), 2)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), deliveries, 2)
+}
@@ -0,0 +1,220 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/event"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type webhookDispatcher struct {
+	webhookRepo webhook.Repository
+	eventRepo   event.Repository
+	httpClient  *http.Client
+}
+
+func NewWebhookDispatcher(webhookRepo webhook.Repository, eventRepo event.Repository, httpClient *http.Client) webhook.Dispatcher {
+	return &webhookDispatcher{webhookRepo: webhookRepo, eventRepo: eventRepo, httpClient: httpClient}
+}
+
+func (dispatcher *webhookDispatcher) Run(ctx context.Context) {
+	go dispatcher.consumeEvents(ctx)
+
+	ticker := time.NewTicker(webhook.DeliveryPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			dispatcher.deliverDueDeliveries()
+		}
+	}
+}
+
+// Dispatch queues the event for every active webhook of its board that is subscribed to it
+func (dispatcher *webhookDispatcher) Dispatch(_event *models.BoardEvent) error {
+	webhooks, err := dispatcher.webhookRepo.GetBoardWebhooks(_event.BoardID)
+	if err != nil {
+		return err
+	}
+
+	for _, _webhook := range webhooks {
+		if !_webhook.Active || !_webhook.IsSubscribedTo(_event.Type) {
+			continue
+		}
+
+		err = dispatcher.webhookRepo.ScheduleDelivery(&models.WebhookDelivery{
+			ID:        primitive.NewObjectID(),
+			WebhookID: _webhook.ID,
+			Event:     _event,
+		}, time.Now())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Deliver sends the delivery to its webhook and logs the attempt. Failed attempts are retried with
+// an exponential backoff until the delivery runs out of attempts, which then counts towards disabling the webhook.
+func (dispatcher *webhookDispatcher) Deliver(delivery *models.WebhookDelivery) error {
+	_webhook, err := dispatcher.webhookRepo.GetWebhookByID(delivery.WebhookID)
+	if err == custom_errors.ErrRecordNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	// deliveries that were queued before the webhook was disabled are dropped
+	if !_webhook.Active {
+		return nil
+	}
+
+	delivery.Attempt++
+
+	statusCode, sendErr := dispatcher.send(_webhook, delivery)
+
+	deliveryLog := &models.WebhookDeliveryLog{
+		WebhookID:  _webhook.ID,
+		DeliveryID: delivery.ID,
+		EventType:  delivery.Event.Type,
+		Attempt:    delivery.Attempt,
+		StatusCode: statusCode,
+		Succeeded:  sendErr == nil,
+	}
+	if sendErr != nil {
+		deliveryLog.Error = sendErr.Error()
+	}
+
+	// the log is only informational, failing to write it should not cause the event to be sent again
+	err = dispatcher.webhookRepo.CreateDeliveryLog(deliveryLog)
+	if err != nil {
+		log.Printf("failed to log delivery %s of webhook %s: %s", delivery.ID.Hex(), _webhook.ID.Hex(), err)
+	}
+
+	if sendErr == nil {
+		if _webhook.ConsecutiveFailures == 0 {
+			return nil
+		}
+
+		return dispatcher.webhookRepo.UpdateDeliveryStatus(_webhook.ID, func(storedWebhook *models.Webhook) {
+			storedWebhook.ConsecutiveFailures = 0
+		})
+	}
+
+	if delivery.Attempt < webhook.MaxDeliveryAttempts {
+		return dispatcher.webhookRepo.ScheduleDelivery(delivery, time.Now().Add(retryDelay(delivery.Attempt)))
+	}
+
+	// other deliveries of the webhook may fail at the same time so the counter is
+	// incremented on the stored webhook rather than on the one that was read above
+	return dispatcher.webhookRepo.UpdateDeliveryStatus(_webhook.ID, func(storedWebhook *models.Webhook) {
+		storedWebhook.ConsecutiveFailures++
+		if storedWebhook.Active && storedWebhook.ConsecutiveFailures >= webhook.MaxConsecutiveFailures {
+			disabledAt := time.Now()
+			storedWebhook.Active = false
+			storedWebhook.DisabledAt = &disabledAt
+		}
+	})
+}
+
+func (dispatcher *webhookDispatcher) consumeEvents(ctx context.Context) {
+	for {
+		_event, err := dispatcher.eventRepo.Consume(ctx)
+		if ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.Printf("failed to consume board events: %s", err)
+
+			// waiting before trying again keeps an unreachable redis from flooding the logs
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(webhook.DeliveryPollInterval):
+			}
+
+			continue
+		}
+
+		err = dispatcher.Dispatch(_event)
+		if err != nil {
+			log.Printf("failed to dispatch %s event of board %s: %s", _event.Type, _event.BoardID.Hex(), err)
+		}
+	}
+}
+
+// deliverDueDeliveries sends a batch of due deliveries at the same time so one slow receiver
+// does not hold up the rest, the next batch is claimed once all of them are done
+func (dispatcher *webhookDispatcher) deliverDueDeliveries() {
+	deliveries, err := dispatcher.webhookRepo.ClaimDueDeliveries(time.Now(), webhook.DeliveryBatchSize)
+	if err != nil {
+		log.Printf("failed to claim webhook deliveries: %s", err)
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+
+			err := dispatcher.Deliver(delivery)
+			if err != nil {
+				log.Printf("failed to deliver %s of webhook %s: %s", delivery.ID.Hex(), delivery.WebhookID.Hex(), err)
+			}
+		}(delivery)
+	}
+
+	wg.Wait()
+}
+
+// send posts the event of the delivery to the webhook, receivers have to respond with a 2xx status
+func (dispatcher *webhookDispatcher) send(_webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	payload, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return 0, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, _webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(webhook.HeaderEvent, delivery.Event.Type)
+	request.Header.Set(webhook.HeaderDelivery, delivery.ID.Hex())
+	request.Header.Set(webhook.HeaderSignature, webhook.Sign(_webhook.Secret, payload))
+
+	response, err := dispatcher.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	// draining the body lets the connection be reused
+	io.Copy(io.Discard, response.Body)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("receiver responded with status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+func retryDelay(attempt int) time.Duration {
+	return webhook.RetryBaseDelay * time.Duration(1<<(attempt-1))
+}
@@ -0,0 +1,258 @@
+package usecase_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	er "github.com/jordyf15/thullo-api/event/mocks"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/utils/rtdbtest"
+	"github.com/jordyf15/thullo-api/webhook"
+	wr "github.com/jordyf15/thullo-api/webhook/mocks"
+	wrepository "github.com/jordyf15/thullo-api/webhook/repository"
+	"github.com/jordyf15/thullo-api/webhook/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWebhookDispatcher(t *testing.T) {
+	suite.Run(t, new(webhookDispatcherSuite))
+}
+
+type webhookDispatcherSuite struct {
+	suite.Suite
+
+	dispatcher  webhook.Dispatcher
+	webhookRepo *wr.Repository
+	eventRepo   *er.Repository
+	receiver    *httptest.Server
+
+	responseStatus  int
+	receivedRequest *http.Request
+	receivedBody    []byte
+
+	receiverWebhook *models.Webhook
+	deliveryLogs    []*models.WebhookDeliveryLog
+	scheduledAt     time.Time
+	scheduled       []*models.WebhookDelivery
+	updatedStatus   *models.Webhook
+}
+
+func (s *webhookDispatcherSuite) SetupTest() {
+	s.webhookRepo = new(wr.Repository)
+	s.eventRepo = new(er.Repository)
+
+	s.responseStatus = http.StatusOK
+	s.receivedRequest, s.receivedBody = nil, nil
+	s.receiver = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.receivedRequest = r
+		s.receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(s.responseStatus)
+	}))
+
+	s.receiverWebhook = &models.Webhook{
+		ID:      primitive.NewObjectID(),
+		BoardID: boardID,
+		URL:     s.receiver.URL,
+		Secret:  "secret",
+		Active:  true,
+	}
+
+	s.deliveryLogs, s.scheduled, s.updatedStatus = nil, nil, nil
+	s.webhookRepo.On("GetWebhookByID", mock.AnythingOfType("primitive.ObjectID")).Return(s.receiverWebhook, nil)
+	s.webhookRepo.On("CreateDeliveryLog", mock.AnythingOfType("*models.WebhookDeliveryLog")).Run(func(args mock.Arguments) {
+		s.deliveryLogs = append(s.deliveryLogs, args[0].(*models.WebhookDeliveryLog))
+	}).Return(nil)
+	s.webhookRepo.On("ScheduleDelivery", mock.AnythingOfType("*models.WebhookDelivery"), mock.AnythingOfType("time.Time")).Run(func(args mock.Arguments) {
+		s.scheduled = append(s.scheduled, args[0].(*models.WebhookDelivery))
+		s.scheduledAt = args[1].(time.Time)
+	}).Return(nil)
+	s.webhookRepo.On("UpdateDeliveryStatus", mock.AnythingOfType("primitive.ObjectID"), mock.AnythingOfType("func(*models.Webhook)")).Run(func(args mock.Arguments) {
+		storedWebhook := *s.receiverWebhook
+		args[1].(func(*models.Webhook))(&storedWebhook)
+		s.updatedStatus = &storedWebhook
+	}).Return(nil)
+
+	s.dispatcher = usecase.NewWebhookDispatcher(s.webhookRepo, s.eventRepo, s.receiver.Client())
+}
+
+func (s *webhookDispatcherSuite) TearDownTest() {
+	s.receiver.Close()
+}
+
+func (s *webhookDispatcherSuite) newDelivery(attempt int) *models.WebhookDelivery {
+	return &models.WebhookDelivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: s.receiverWebhook.ID,
+		Event: &models.BoardEvent{
+			Type:    models.BoardEventCardCreated,
+			BoardID: boardID,
+			ActorID: adminBoardMember.UserID,
+		},
+		Attempt: attempt,
+	}
+}
+
+func (s *webhookDispatcherSuite) TestDispatchOnlyToActiveSubscribedWebhooks() {
+	subscribed := &models.Webhook{ID: primitive.NewObjectID(), Active: true, EventTypes: []string{models.BoardEventCardCreated}}
+	everyEvent := &models.Webhook{ID: primitive.NewObjectID(), Active: true}
+	unsubscribed := &models.Webhook{ID: primitive.NewObjectID(), Active: true, EventTypes: []string{models.BoardEventListCreated}}
+	inactive := &models.Webhook{ID: primitive.NewObjectID(), Active: false}
+	s.webhookRepo.On("GetBoardWebhooks", boardID).Return([]*models.Webhook{subscribed, everyEvent, unsubscribed, inactive}, nil)
+
+	err := s.dispatcher.Dispatch(&models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID})
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.scheduled, 2)
+	assert.Equal(s.T(), subscribed.ID, s.scheduled[0].WebhookID)
+	assert.Equal(s.T(), everyEvent.ID, s.scheduled[1].WebhookID)
+	assert.Equal(s.T(), 0, s.scheduled[0].Attempt)
+}
+
+func (s *webhookDispatcherSuite) TestDeliverToInactiveWebhook() {
+	s.receiverWebhook.Active = false
+
+	err := s.dispatcher.Deliver(s.newDelivery(0))
+
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), s.receivedRequest)
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "CreateDeliveryLog", 0)
+}
+
+func (s *webhookDispatcherSuite) TestDeliverSuccessful() {
+	delivery := s.newDelivery(0)
+
+	err := s.dispatcher.Deliver(delivery)
+
+	assert.NoError(s.T(), err)
+	assert.NotNil(s.T(), s.receivedRequest)
+	assert.Equal(s.T(), "application/json", s.receivedRequest.Header.Get("Content-Type"))
+	assert.Equal(s.T(), models.BoardEventCardCreated, s.receivedRequest.Header.Get(webhook.HeaderEvent))
+	assert.Equal(s.T(), delivery.ID.Hex(), s.receivedRequest.Header.Get(webhook.HeaderDelivery))
+	assert.Equal(s.T(), webhook.Sign("secret", s.receivedBody), s.receivedRequest.Header.Get(webhook.HeaderSignature))
+
+	assert.Len(s.T(), s.deliveryLogs, 1)
+	assert.True(s.T(), s.deliveryLogs[0].Succeeded)
+	assert.Equal(s.T(), http.StatusOK, s.deliveryLogs[0].StatusCode)
+	assert.Equal(s.T(), 1, s.deliveryLogs[0].Attempt)
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "ScheduleDelivery", 0)
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "UpdateDeliveryStatus", 0)
+}
+
+func (s *webhookDispatcherSuite) TestDeliverSuccessfulResetsFailures() {
+	s.receiverWebhook.ConsecutiveFailures = 2
+
+	err := s.dispatcher.Deliver(s.newDelivery(0))
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), 0, s.updatedStatus.ConsecutiveFailures)
+}
+
+func (s *webhookDispatcherSuite) TestDeliverToInternalAddress() {
+	// the receiver listens on a loopback address
+	s.dispatcher = usecase.NewWebhookDispatcher(s.webhookRepo, s.eventRepo, webhook.NewHTTPClient(webhook.DeliveryTimeout))
+
+	err := s.dispatcher.Deliver(s.newDelivery(0))
+
+	assert.NoError(s.T(), err)
+	assert.Nil(s.T(), s.receivedRequest)
+	assert.Len(s.T(), s.deliveryLogs, 1)
+	assert.False(s.T(), s.deliveryLogs[0].Succeeded)
+	assert.Contains(s.T(), s.deliveryLogs[0].Error, "is not allowed")
+	assert.Len(s.T(), s.scheduled, 1)
+}
+
+func (s *webhookDispatcherSuite) TestDeliverFailedIsRetried() {
+	s.responseStatus = http.StatusInternalServerError
+
+	err := s.dispatcher.Deliver(s.newDelivery(1))
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), s.deliveryLogs, 1)
+	assert.False(s.T(), s.deliveryLogs[0].Succeeded)
+	assert.Equal(s.T(), http.StatusInternalServerError, s.deliveryLogs[0].StatusCode)
+	assert.NotEmpty(s.T(), s.deliveryLogs[0].Error)
+
+	assert.Len(s.T(), s.scheduled, 1)
+	assert.Equal(s.T(), 2, s.scheduled[0].Attempt)
+	assert.WithinDuration(s.T(), time.Now().Add(2*webhook.RetryBaseDelay), s.scheduledAt, time.Second)
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "UpdateDeliveryStatus", 0)
+}
+
+func (s *webhookDispatcherSuite) TestDeliverLastAttemptFailed() {
+	s.responseStatus = http.StatusInternalServerError
+
+	err := s.dispatcher.Deliver(s.newDelivery(webhook.MaxDeliveryAttempts - 1))
+
+	assert.NoError(s.T(), err)
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "ScheduleDelivery", 0)
+	assert.Equal(s.T(), 1, s.updatedStatus.ConsecutiveFailures)
+	assert.True(s.T(), s.updatedStatus.Active)
+}
+
+func (s *webhookDispatcherSuite) TestDeliverFailuresDisableWebhook() {
+	s.responseStatus = http.StatusInternalServerError
+	s.receiverWebhook.ConsecutiveFailures = webhook.MaxConsecutiveFailures - 1
+
+	err := s.dispatcher.Deliver(s.newDelivery(webhook.MaxDeliveryAttempts - 1))
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), webhook.MaxConsecutiveFailures, s.updatedStatus.ConsecutiveFailures)
+	assert.False(s.T(), s.updatedStatus.Active)
+	assert.NotNil(s.T(), s.updatedStatus.DisabledAt)
+}
+
+// TestConcurrentFailedDeliveriesAreAllCounted lets deliveries of the same webhook fail at the same
+// time against the realtime database repository, none of the failures may be lost
+func TestConcurrentFailedDeliveriesAreAllCounted(t *testing.T) {
+	server := rtdbtest.NewServer()
+	defer server.Close()
+
+	dbClient, err := server.NewDatabaseClient()
+	if err != nil {
+		t.Fatalf("An error occured: %s", err)
+	}
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer receiver.Close()
+
+	_webhook := &models.Webhook{ID: primitive.NewObjectID(), BoardID: boardID, URL: receiver.URL, Secret: "secret", Active: true}
+	assert.NoError(t, server.Set(fmt.Sprintf("webhooks/%s", _webhook.ID.Hex()), _webhook))
+
+	// deliveries on their last attempt are not queued again so redis is not needed
+	webhookRepo := wrepository.NewWebhookRepository(dbClient, nil)
+	dispatcher := usecase.NewWebhookDispatcher(webhookRepo, nil, receiver.Client())
+
+	failures := webhook.MaxConsecutiveFailures - 1
+
+	var wg sync.WaitGroup
+	for i := 0; i < failures; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			assert.NoError(t, dispatcher.Deliver(&models.WebhookDelivery{
+				ID:        primitive.NewObjectID(),
+				WebhookID: _webhook.ID,
+				Event:     &models.BoardEvent{Type: models.BoardEventCardCreated, BoardID: boardID},
+				Attempt:   webhook.MaxDeliveryAttempts - 1,
+			}))
+		}()
+	}
+	wg.Wait()
+
+	storedWebhook, err := webhookRepo.GetWebhookByID(_webhook.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, failures, storedWebhook.ConsecutiveFailures)
+	assert.True(t, storedWebhook.Active)
+	assert.Equal(t, _webhook.URL, storedWebhook.URL)
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/jordyf15/thullo-api/board_member"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/webhook"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	secretLength = 32
+)
+
+type webhookUsecase struct {
+	webhookRepo     webhook.Repository
+	boardMemberRepo board_member.Repository
+}
+
+func NewWebhookUsecase(webhookRepo webhook.Repository, boardMemberRepo board_member.Repository) webhook.Usecase {
+	return &webhookUsecase{webhookRepo: webhookRepo, boardMemberRepo: boardMemberRepo}
+}
+
+func (usecase *webhookUsecase) Create(requesterID, boardID primitive.ObjectID, url string, eventTypes []string) error {
+	errors := []error{}
+	if err := verifyURL(url); err != nil {
+		errors = append(errors, err)
+	}
+
+	if err := verifyEventTypes(eventTypes); err != nil {
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return &custom_errors.MultipleErrors{Errors: errors}
+	}
+
+	err := usecase.checkIfRequesterIsAdminOfBoard(requesterID, boardID)
+	if err != nil {
+		return err
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return err
+	}
+
+	_webhook := &models.Webhook{
+		BoardID:    boardID,
+		URL:        url,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+
+	return usecase.webhookRepo.Create(_webhook)
+}
+
+func (usecase *webhookUsecase) GetBoardWebhooks(requesterID, boardID primitive.ObjectID) ([]*models.Webhook, error) {
+	err := usecase.checkIfRequesterIsAdminOfBoard(requesterID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := usecase.webhookRepo.GetBoardWebhooks(boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(webhooks, func(i, j int) bool {
+		return webhooks[i].CreatedAt.Before(webhooks[j].CreatedAt)
+	})
+
+	return webhooks, nil
+}
+
+func (usecase *webhookUsecase) UpdateURL(requesterID, boardID, webhookID primitive.ObjectID, url string) error {
+	err := verifyURL(url)
+	if err != nil {
+		return err
+	}
+
+	_webhook, err := usecase.getBoardWebhook(requesterID, boardID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	_webhook.URL = url
+
+	return usecase.webhookRepo.Update(_webhook)
+}
+
+func (usecase *webhookUsecase) UpdateEventTypes(requesterID, boardID, webhookID primitive.ObjectID, eventTypes []string) error {
+	err := verifyEventTypes(eventTypes)
+	if err != nil {
+		return err
+	}
+
+	_webhook, err := usecase.getBoardWebhook(requesterID, boardID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	_webhook.EventTypes = eventTypes
+
+	return usecase.webhookRepo.Update(_webhook)
+}
+
+// UpdateActive enables or disables the webhook, enabling it again gives it a clean slate
+// so a webhook that was disabled for failing is not disabled again by its next failure
+func (usecase *webhookUsecase) UpdateActive(requesterID, boardID, webhookID primitive.ObjectID, active bool) error {
+	_webhook, err := usecase.getBoardWebhook(requesterID, boardID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	if _webhook.Active == active {
+		return nil
+	}
+
+	_webhook.Active = active
+	if active {
+		_webhook.ConsecutiveFailures = 0
+		_webhook.DisabledAt = nil
+	}
+
+	return usecase.webhookRepo.Update(_webhook)
+}
+
+func (usecase *webhookUsecase) Delete(requesterID, boardID, webhookID primitive.ObjectID) error {
+	_webhook, err := usecase.getBoardWebhook(requesterID, boardID, webhookID)
+	if err != nil {
+		return err
+	}
+
+	return usecase.webhookRepo.Delete(_webhook.ID)
+}
+
+// GetDeliveryLogs returns the latest delivery attempts of the webhook, newest first
+func (usecase *webhookUsecase) GetDeliveryLogs(requesterID, boardID, webhookID primitive.ObjectID) ([]*models.WebhookDeliveryLog, error) {
+	_webhook, err := usecase.getBoardWebhook(requesterID, boardID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveryLogs, err := usecase.webhookRepo.GetDeliveryLogs(_webhook.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(deliveryLogs, func(i, j int) bool {
+		return deliveryLogs[i].CreatedAt.After(deliveryLogs[j].CreatedAt)
+	})
+
+	if len(deliveryLogs) > webhook.MaxDeliveryLogs {
+		deliveryLogs = deliveryLogs[:webhook.MaxDeliveryLogs]
+	}
+
+	return deliveryLogs, nil
+}
+
+func (usecase *webhookUsecase) getBoardWebhook(requesterID, boardID, webhookID primitive.ObjectID) (*models.Webhook, error) {
+	err := usecase.checkIfRequesterIsAdminOfBoard(requesterID, boardID)
+	if err != nil {
+		return nil, err
+	}
+
+	_webhook, err := usecase.webhookRepo.GetWebhookByID(webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _webhook.BoardID != boardID {
+		return nil, custom_errors.ErrRecordNotFound
+	}
+
+	return _webhook, nil
+}
+
+// webhooks carry the secret they are signed with, so only admins can see or change them
+func (usecase *webhookUsecase) checkIfRequesterIsAdminOfBoard(requesterID, boardID primitive.ObjectID) error {
+	boardMembers, err := usecase.boardMemberRepo.GetBoardMembers(boardID)
+	if err != nil {
+		return err
+	}
+
+	// if there are no board members it means there are no board
+	// a board will always atleast have 1 member
+	if len(boardMembers) == 0 {
+		return custom_errors.ErrRecordNotFound
+	}
+
+	for _, boardMember := range boardMembers {
+		if boardMember.UserID == requesterID && boardMember.Role == models.MemberRoleAdmin {
+			return nil
+		}
+	}
+
+	return custom_errors.ErrNotAuthorized
+}
+
+// verifyURL rejects the urls that obviously point into our own network, hosts that resolve
+// to such an address are refused by the client of the dispatcher when they are delivered to
+func verifyURL(rawURL string) error {
+	parsedURL, err := url.Parse(rawURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Hostname() == "" {
+		return custom_errors.ErrWebhookURLInvalid
+	}
+
+	hostname := strings.ToLower(parsedURL.Hostname())
+	if hostname == "localhost" || strings.HasSuffix(hostname, ".localhost") {
+		return custom_errors.ErrWebhookURLInvalid
+	}
+
+	if ip := net.ParseIP(hostname); ip != nil && !webhook.IsPublicIP(ip) {
+		return custom_errors.ErrWebhookURLInvalid
+	}
+
+	return nil
+}
+
+func verifyEventTypes(eventTypes []string) error {
+	for _, eventType := range eventTypes {
+		isSupported := false
+		for _, supportedEventType := range webhook.EventTypes {
+			if eventType == supportedEventType {
+				isSupported = true
+				break
+			}
+		}
+
+		if !isSupported {
+			return custom_errors.ErrWebhookEventTypeInvalid
+		}
+	}
+
+	return nil
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, secretLength)
+
+	_, err := rand.Read(secret)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
@@ -0,0 +1,257 @@
+package usecase_test
+
+import (
+	"testing"
+	"time"
+
+	bmr "github.com/jordyf15/thullo-api/board_member/mocks"
+	"github.com/jordyf15/thullo-api/custom_errors"
+	"github.com/jordyf15/thullo-api/models"
+	"github.com/jordyf15/thullo-api/webhook"
+	wr "github.com/jordyf15/thullo-api/webhook/mocks"
+	"github.com/jordyf15/thullo-api/webhook/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestWebhookUsecase(t *testing.T) {
+	suite.Run(t, new(webhookUsecaseSuite))
+}
+
+var (
+	boardID = primitive.NewObjectID()
+
+	adminBoardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		BoardID: boardID,
+		UserID:  primitive.NewObjectID(),
+		Role:    models.MemberRoleAdmin,
+	}
+	memberBoardMember = &models.BoardMember{
+		ID:      primitive.NewObjectID(),
+		BoardID: boardID,
+		UserID:  primitive.NewObjectID(),
+		Role:    models.MemberRoleMember,
+	}
+
+	webhook1 = &models.Webhook{
+		ID:      primitive.NewObjectID(),
+		BoardID: boardID,
+		URL:     "https://example.com/hooks/1",
+		Active:  true,
+	}
+	otherBoardWebhook = &models.Webhook{
+		ID:      primitive.NewObjectID(),
+		BoardID: primitive.NewObjectID(),
+		URL:     "https://example.com/hooks/2",
+		Active:  true,
+	}
+)
+
+type webhookUsecaseSuite struct {
+	suite.Suite
+
+	usecase         webhook.Usecase
+	webhookRepo     *wr.Repository
+	boardMemberRepo *bmr.Repository
+
+	createdWebhook *models.Webhook
+	updatedWebhook *models.Webhook
+}
+
+func (s *webhookUsecaseSuite) SetupTest() {
+	s.webhookRepo = new(wr.Repository)
+	s.boardMemberRepo = new(bmr.Repository)
+
+	webhook1.URL = "https://example.com/hooks/1"
+	webhook1.EventTypes = nil
+	webhook1.Active = true
+	webhook1.ConsecutiveFailures = 0
+	webhook1.DisabledAt = nil
+
+	s.boardMemberRepo.On("GetBoardMembers", mock.AnythingOfType("primitive.ObjectID")).Return(func(_boardID primitive.ObjectID) []*models.BoardMember {
+		if _boardID == boardID {
+			return []*models.BoardMember{adminBoardMember, memberBoardMember}
+		}
+
+		return []*models.BoardMember{}
+	}, nil)
+
+	s.createdWebhook, s.updatedWebhook = nil, nil
+	s.webhookRepo.On("Create", mock.AnythingOfType("*models.Webhook")).Run(func(args mock.Arguments) {
+		s.createdWebhook = args[0].(*models.Webhook)
+	}).Return(nil)
+	s.webhookRepo.On("Update", mock.AnythingOfType("*models.Webhook")).Run(func(args mock.Arguments) {
+		s.updatedWebhook = args[0].(*models.Webhook)
+	}).Return(nil)
+	s.webhookRepo.On("Delete", mock.AnythingOfType("primitive.ObjectID")).Return(nil)
+	s.webhookRepo.On("GetWebhookByID", mock.AnythingOfType("primitive.ObjectID")).Return(func(webhookID primitive.ObjectID) *models.Webhook {
+		switch webhookID {
+		case webhook1.ID:
+			return webhook1
+		case otherBoardWebhook.ID:
+			return otherBoardWebhook
+		}
+
+		return nil
+	}, func(webhookID primitive.ObjectID) error {
+		if webhookID != webhook1.ID && webhookID != otherBoardWebhook.ID {
+			return custom_errors.ErrRecordNotFound
+		}
+
+		return nil
+	})
+	s.webhookRepo.On("GetBoardWebhooks", mock.AnythingOfType("primitive.ObjectID")).Return([]*models.Webhook{webhook1}, nil)
+
+	now := time.Now()
+	deliveryLogs := make([]*models.WebhookDeliveryLog, webhook.MaxDeliveryLogs+1)
+	for i := range deliveryLogs {
+		deliveryLogs[i] = &models.WebhookDeliveryLog{ID: primitive.NewObjectID(), WebhookID: webhook1.ID, CreatedAt: now.Add(time.Duration(i) * time.Second)}
+	}
+	s.webhookRepo.On("GetDeliveryLogs", mock.AnythingOfType("primitive.ObjectID")).Return(deliveryLogs, nil)
+
+	s.usecase = usecase.NewWebhookUsecase(s.webhookRepo, s.boardMemberRepo)
+}
+
+func (s *webhookUsecaseSuite) TestCreateInvalidURL() {
+	for _, url := range []string{"", "example.com/hooks", "ftp://example.com/hooks", "https://"} {
+		err := s.usecase.Create(adminBoardMember.UserID, boardID, url, nil)
+
+		assert.Error(s.T(), err)
+		assert.Equal(s.T(), custom_errors.ErrWebhookURLInvalid.Error(), err.Error())
+	}
+
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *webhookUsecaseSuite) TestCreateInternalURL() {
+	for _, url := range []string{"http://localhost:8080/hooks", "http://127.0.0.1/hooks", "http://10.0.0.2/hooks", "http://169.254.169.254/latest/meta-data", "http://[::1]/hooks", "http://0.0.0.0/hooks"} {
+		err := s.usecase.Create(adminBoardMember.UserID, boardID, url, nil)
+
+		assert.Error(s.T(), err)
+		assert.Equal(s.T(), custom_errors.ErrWebhookURLInvalid.Error(), err.Error())
+	}
+
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *webhookUsecaseSuite) TestCreateInvalidEventType() {
+	err := s.usecase.Create(adminBoardMember.UserID, boardID, "https://example.com/hooks", []string{models.BoardEventCardCreated, models.BoardEventLabelCreated})
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrWebhookEventTypeInvalid.Error(), err.Error())
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *webhookUsecaseSuite) TestCreateBoardNotFound() {
+	err := s.usecase.Create(adminBoardMember.UserID, primitive.NewObjectID(), "https://example.com/hooks", nil)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *webhookUsecaseSuite) TestCreateAsMember() {
+	err := s.usecase.Create(memberBoardMember.UserID, boardID, "https://example.com/hooks", nil)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Create", 0)
+}
+
+func (s *webhookUsecaseSuite) TestCreateSuccessful() {
+	err := s.usecase.Create(adminBoardMember.UserID, boardID, "https://example.com/hooks", []string{models.BoardEventCardCreated})
+
+	assert.NoError(s.T(), err)
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Create", 1)
+	assert.Equal(s.T(), boardID, s.createdWebhook.BoardID)
+	assert.Equal(s.T(), "https://example.com/hooks", s.createdWebhook.URL)
+	assert.Equal(s.T(), []string{models.BoardEventCardCreated}, s.createdWebhook.EventTypes)
+	assert.True(s.T(), s.createdWebhook.Active)
+	assert.Len(s.T(), s.createdWebhook.Secret, 64)
+}
+
+func (s *webhookUsecaseSuite) TestGetBoardWebhooksAsMember() {
+	webhooks, err := s.usecase.GetBoardWebhooks(memberBoardMember.UserID, boardID)
+
+	assert.Nil(s.T(), webhooks)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+}
+
+func (s *webhookUsecaseSuite) TestGetBoardWebhooksSuccessful() {
+	webhooks, err := s.usecase.GetBoardWebhooks(adminBoardMember.UserID, boardID)
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), []*models.Webhook{webhook1}, webhooks)
+}
+
+func (s *webhookUsecaseSuite) TestUpdateURLOfOtherBoardWebhook() {
+	err := s.usecase.UpdateURL(adminBoardMember.UserID, boardID, otherBoardWebhook.ID, "https://example.com/hooks/new")
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrRecordNotFound.Error(), err.Error())
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *webhookUsecaseSuite) TestUpdateURLSuccessful() {
+	err := s.usecase.UpdateURL(adminBoardMember.UserID, boardID, webhook1.ID, "https://example.com/hooks/new")
+
+	assert.NoError(s.T(), err)
+	assert.Equal(s.T(), "https://example.com/hooks/new", s.updatedWebhook.URL)
+}
+
+func (s *webhookUsecaseSuite) TestUpdateEventTypesToEveryEvent() {
+	webhook1.EventTypes = []string{models.BoardEventCardCreated}
+
+	err := s.usecase.UpdateEventTypes(adminBoardMember.UserID, boardID, webhook1.ID, []string{})
+
+	assert.NoError(s.T(), err)
+	assert.Empty(s.T(), s.updatedWebhook.EventTypes)
+	assert.True(s.T(), s.updatedWebhook.IsSubscribedTo(models.BoardEventCommentDeleted))
+}
+
+func (s *webhookUsecaseSuite) TestUpdateActiveUnchanged() {
+	err := s.usecase.UpdateActive(adminBoardMember.UserID, boardID, webhook1.ID, true)
+
+	assert.NoError(s.T(), err)
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Update", 0)
+}
+
+func (s *webhookUsecaseSuite) TestUpdateActiveReenablesDisabledWebhook() {
+	disabledAt := time.Now()
+	webhook1.Active = false
+	webhook1.ConsecutiveFailures = webhook.MaxConsecutiveFailures
+	webhook1.DisabledAt = &disabledAt
+
+	err := s.usecase.UpdateActive(adminBoardMember.UserID, boardID, webhook1.ID, true)
+
+	assert.NoError(s.T(), err)
+	assert.True(s.T(), s.updatedWebhook.Active)
+	assert.Equal(s.T(), 0, s.updatedWebhook.ConsecutiveFailures)
+	assert.Nil(s.T(), s.updatedWebhook.DisabledAt)
+}
+
+func (s *webhookUsecaseSuite) TestDeleteAsMember() {
+	err := s.usecase.Delete(memberBoardMember.UserID, boardID, webhook1.ID)
+
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), custom_errors.ErrNotAuthorized.Error(), err.Error())
+	s.webhookRepo.AssertNumberOfCalls(s.T(), "Delete", 0)
+}
+
+func (s *webhookUsecaseSuite) TestDeleteSuccessful() {
+	err := s.usecase.Delete(adminBoardMember.UserID, boardID, webhook1.ID)
+
+	assert.NoError(s.T(), err)
+	s.webhookRepo.AssertCalled(s.T(), "Delete", webhook1.ID)
+}
+
+func (s *webhookUsecaseSuite) TestGetDeliveryLogsNewestFirst() {
+	deliveryLogs, err := s.usecase.GetDeliveryLogs(adminBoardMember.UserID, boardID, webhook1.ID)
+
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), deliveryLogs, webhook.MaxDeliveryLogs)
+	assert.True(s.T(), deliveryLogs[0].CreatedAt.After(deliveryLogs[1].CreatedAt))
+}